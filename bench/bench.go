@@ -0,0 +1,82 @@
+/*
+Package bench provides a benchmark harness that compares implementations of
+search trees of this module on different distributions of keys and workloads.
+
+Each tree is wrapped by the common Tree interface, trees that support deletion of
+keys also implement the Deleter interface. A benchmark runs a workload -
+a sequence of insertions, lookups and deletions - on a new tree created for each
+iteration and reports the time and allocations per workload, the time per tree
+operation and the height of the resulting tree. Keys are generated by one of
+distributions: sequential, random, reverse-sorted or Zipfian.
+
+Benchmarks are defined in the test file of the package, run them by:
+
+	go test -run XXX -bench . -benchmem ./bench
+
+Results can also be collected by Measure and written as CSV by WriteCSV, the test
+of the package does it if the -csv flag is set, the time of each benchmark can be
+limited by the -test.benchtime flag:
+
+	go test -run TestCSVReport ./bench -csv results.csv -test.benchtime 10x
+*/
+package bench
+
+import (
+	"github.com/r-che/algorithms/bst/aggrbtree"
+	"github.com/r-che/algorithms/bst/arenarbtree"
+	"github.com/r-che/algorithms/bst/nbtree"
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/bst/tdrbtree"
+	"github.com/r-che/algorithms/bst/wbtree"
+	"github.com/r-che/algorithms/list/skiplist"
+	"github.com/r-che/algorithms/mwt/btree"
+	"github.com/r-che/algorithms/mwt/tree234"
+	"github.com/r-che/algorithms/prefix/art"
+)
+
+// KeyType represents the key type used by benchmarks, it is the same as the key type of the red-black tree
+type KeyType = rbtree.KeyType
+
+// Degree of B-trees used by benchmarks
+const bTreeDegree = 16
+
+// Tree is the common interface of trees used by benchmarks
+type Tree interface {
+	// Insert inserts the key k, it returns false if the key already exists
+	Insert(k KeyType) bool
+	// Search returns true if the key k exists
+	Search(k KeyType) bool
+	// Height returns the height of the tree - the number of nodes on the longest path from the root
+	Height() int
+}
+
+// Deleter is implemented by trees that support deletion of keys, workloads with
+// deletions are run only on such trees
+type Deleter interface {
+	// Delete deletes the key k, it returns false if there is no such key
+	Delete(k KeyType) bool
+}
+
+// Impl describes an implementation of the tree
+type Impl struct {
+	Name	string
+	// New returns new empty tree
+	New		func() Tree
+}
+
+// Impls returns all implementations of trees.
+func Impls() []Impl {
+	return []Impl{
+		{"nbtree", func() Tree { return &nbTree{nbtree.NewBSTree()} }},
+		{"rbtree", func() Tree { return &rbTree{rbtree.NewRBTree()} }},
+		{"aggrbtree", func() Tree { return &aggTree{aggrbtree.NewAggRBTree[int](countMonoid{})} }},
+		{"arenarbtree", func() Tree { return &arenaTree{arenarbtree.NewArenaRBTree(0)} }},
+		{"tdrbtree", func() Tree { return &tdTree{tdrbtree.NewTDRBTree()} }},
+		{"wbtree", func() Tree { return &wbTree{wbtree.NewWBTree()} }},
+		{"btree", func() Tree { return &bTree{btree.NewBTree(bTreeDegree)} }},
+		{"bplustree", func() Tree { return &bTree{btree.NewBPlusTree(bTreeDegree)} }},
+		{"tree234", func() Tree { return &t234Tree{tree234.NewTree234()} }},
+		{"skiplist", func() Tree { return &skipList{skiplist.NewSkipList()} }},
+		{"art", func() Tree { return &artTree{art.NewART()} }},
+	}
+}
@@ -0,0 +1,208 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const (
+	// Number of keys used by benchmarks
+	benchKeys	=	4096
+	// Number of keys used by tests
+	testKeys	=	512
+
+	seed		=	2022
+)
+
+//nolint:gochecknoglobals // Flags of the test
+var csvFile = flag.String("csv", "", "write results of all benchmarks to the CSV file")
+
+//nolint:gochecknoglobals // Implementations that are not balanced binary trees, their heights are not checked
+var notBalancedBinary = map[string]bool{"nbtree": true, "btree": true, "bplustree": true, "tree234": true, "skiplist": true, "art": true}
+
+// mapTree is the reference model of the tree
+type mapTree map[KeyType]bool
+
+func (m mapTree) Insert(k KeyType) bool { ok := !m[k]; m[k] = true; return ok }
+func (m mapTree) Search(k KeyType) bool { return m[k] }
+func (m mapTree) Delete(k KeyType) bool { ok := m[k]; delete(m, k); return ok }
+func (m mapTree) Height() int { return 0 }
+
+func BenchmarkTrees(b *testing.B) {
+	for _, d := range Distributions() {
+		keys := Keys(d, benchKeys, seed)
+
+		for _, w := range Workloads() {
+			for _, impl := range Impls() {
+				if !impl.Supports(w) {
+					continue
+				}
+
+				b.Run(fmt.Sprintf("%v/%v/%s", d, w, impl.Name), Benchmark(impl, w, keys))
+			}
+		}
+	}
+}
+
+func TestKeys(t *testing.T) {
+	sorted := func(keys []KeyType) []KeyType {
+		s := append([]KeyType(nil), keys...)
+		sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+		return s
+	}
+
+	seq := Keys(Sequential, testKeys, seed)
+	if len(seq) != testKeys || !sort.SliceIsSorted(seq, func(i, j int) bool { return seq[i] < seq[j] }) {
+		t.Errorf("Sequential keys are not sorted: %v", seq)
+	}
+
+	rev := Keys(Reverse, testKeys, seed)
+	if !reflect.DeepEqual(sorted(rev), seq) || rev[0] != testKeys - 1 {
+		t.Errorf("Reverse keys are not reverse-sorted: %v", rev)
+	}
+
+	rnd := Keys(Random, testKeys, seed)
+	if !reflect.DeepEqual(sorted(rnd), seq) || reflect.DeepEqual(rnd, seq) {
+		t.Errorf("Random keys are not a permutation of sequential keys: %v", rnd)
+	}
+	if other := Keys(Random, testKeys, seed + 1); reflect.DeepEqual(rnd, other) {
+		t.Errorf("Random keys do not depend on the seed")
+	}
+
+	// Zipfian keys are in range and small keys are repeated
+	counts := map[KeyType]int{}
+	for _, k := range Keys(Zipf, testKeys, seed) {
+		if k < 0 || k > testKeys * zipfRange {
+			t.Errorf("Zipf key %v is out of range", k)
+		}
+		counts[k]++
+	}
+	if counts[0] < counts[testKeys / 2] || counts[0] < 2 {
+		t.Errorf("Zipf keys are not skewed: key 0 found %d times", counts[0])
+	}
+}
+
+func TestTrees(t *testing.T) {
+	for _, d := range Distributions() {
+		keys := Keys(d, testKeys, seed)
+
+		for _, w := range Workloads() {
+			// Run the workload on the model
+			model := mapTree{}
+			wantOps := Run(model, w, keys)
+
+			for _, impl := range Impls() {
+				if !impl.Supports(w) {
+					continue
+				}
+
+				name := fmt.Sprintf("%v/%v/%s", d, w, impl.Name)
+				tree := impl.New()
+				if ops := Run(tree, w, keys); ops != wantOps {
+					t.Errorf("%s: workload returned %d operations, want - %d", name, ops, wantOps)
+				}
+
+				// Compare the tree with the model
+				for k := KeyType(-1); k <= testKeys * zipfRange; k++ {
+					if found := tree.Search(k); found != model[k] {
+						t.Errorf("%s: search of the key %v returned %t, want - %t", name, k, found, model[k])
+						break
+					}
+				}
+
+				// Check the height of balanced binary trees
+				if notBalancedBinary[impl.Name] {
+					continue
+				}
+				h, min := tree.Height(), int(math.Ceil(math.Log2(float64(len(model) + 1))))
+				if h < min || h > 2 * min {
+					t.Errorf("%s: tree with %d keys has height %d", name, len(model), h)
+				}
+			}
+		}
+	}
+}
+
+func TestSupports(t *testing.T) {
+	for _, impl := range Impls() {
+		// The 2-3-4 tree is the only tree without deletion
+		if want := impl.Name != "tree234"; impl.Supports(Mixed) != want {
+			t.Errorf("%s: Supports(%v) returned %t, want - %t", impl.Name, Mixed, !want, want)
+		}
+		if !impl.Supports(InsertHeavy) || !impl.Supports(LookupHeavy) {
+			t.Errorf("%s: workloads without deletion are not supported", impl.Name)
+		}
+	}
+}
+
+func TestDegenerateHeight(t *testing.T) {
+	for _, d := range []Distribution{Sequential, Reverse} {
+		tree := Impls()[0].New()
+		Run(tree, InsertHeavy, Keys(d, testKeys, seed))
+
+		if h := tree.Height(); h != testKeys {
+			t.Errorf("non-balanced tree built from %v keys has height %d, want - %d", d, h, testKeys)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	results := []Result{
+		{"rbtree", Random, Mixed, 10, 22, 4400, 11, 512, 17},
+		{"nbtree", Sequential, InsertHeavy, 10, 11, 1000, 10, 480, 10},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, results); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("cannot read written CSV: %v", err)
+	}
+
+	want := [][]string{
+		{"tree", "distribution", "workload", "keys", "ops", "ns_per_op", "ns_per_tree_op", "allocs_per_op", "bytes_per_op", "height"},
+		{"rbtree", "Random", "Mixed", "10", "22", "4400", "200.00", "11", "512", "17"},
+		{"nbtree", "Sequential", "InsertHeavy", "10", "11", "1000", "90.91", "10", "480", "10"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("WriteCSV wrote %v, want - %v", records, want)
+	}
+}
+
+// TestCSVReport runs all benchmarks and writes their results to the file set by the -csv flag
+func TestCSVReport(t *testing.T) {
+	if *csvFile == "" {
+		t.Skip("CSV file is not set by the -csv flag")
+	}
+
+	var results []Result
+	for _, d := range Distributions() {
+		for _, w := range Workloads() {
+			for _, impl := range Impls() {
+				if impl.Supports(w) {
+					results = append(results, Measure(impl, d, w, benchKeys, seed))
+				}
+			}
+		}
+	}
+
+	f, err := os.Create(*csvFile)
+	if err != nil {
+		t.Fatalf("cannot create CSV file: %v", err)
+	}
+	defer f.Close()
+
+	if err := WriteCSV(f, results); err != nil {
+		t.Fatalf("cannot write CSV file: %v", err)
+	}
+}
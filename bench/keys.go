@@ -0,0 +1,73 @@
+package bench
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Distribution defines the order and the values of generated keys
+type Distribution int
+const (
+	// Sequential - keys 0, 1, ..., n-1 in ascending order
+	Sequential = Distribution(iota)
+	// Random - keys 0, 1, ..., n-1 in random order
+	Random
+	// Reverse - keys n-1, ..., 1, 0 in descending order
+	Reverse
+	// Zipf - keys from the Zipfian distribution, small keys are frequent and repeated
+	Zipf
+)
+
+// Parameters of the Zipfian distribution
+const (
+	zipfS		=	1.1
+	zipfV		=	1
+	// Keys are in range [0, n * zipfRange]
+	zipfRange	=	10
+)
+
+func (d Distribution) String() string {
+	switch d {
+		case Sequential:	return "Sequential"
+		case Random:		return "Random"
+		case Reverse:		return "Reverse"
+		case Zipf:			return "Zipf"
+	}
+
+	panic(fmt.Sprintf("Unexpected distribution value: %d", d))
+}
+
+// Distributions returns all distributions of keys.
+func Distributions() []Distribution {
+	return []Distribution{Sequential, Random, Reverse, Zipf}
+}
+
+// Keys returns n keys with the distribution d, the random source is initialized by the seed.
+func Keys(d Distribution, n int, seed int64) []KeyType {
+	rnd := rand.New(rand.NewSource(seed))	//nolint:gosec // Reproducible sequence is required
+	keys := make([]KeyType, n)
+
+	switch d {
+	case Sequential:
+		for i := range keys {
+			keys[i] = KeyType(i)
+		}
+	case Random:
+		for i, k := range rnd.Perm(n) {
+			keys[i] = KeyType(k)
+		}
+	case Reverse:
+		for i := range keys {
+			keys[i] = KeyType(n - 1 - i)
+		}
+	case Zipf:
+		z := rand.NewZipf(rnd, zipfS, zipfV, uint64(n * zipfRange))
+		for i := range keys {
+			keys[i] = KeyType(z.Uint64())
+		}
+	default:
+		panic(fmt.Sprintf("Unexpected distribution value: %d", d))
+	}
+
+	return keys
+}
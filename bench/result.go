@@ -0,0 +1,107 @@
+package bench
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Result contains results of the benchmark of the tree implementation on the workload
+type Result struct {
+	Tree			string
+	Distribution	Distribution
+	Workload		Workload
+	// Number of keys and number of operations of the workload
+	Keys			int
+	Ops				int
+	// Time and allocations per workload
+	NsPerOp			int64
+	AllocsPerOp		int64
+	BytesPerOp		int64
+	// Height of the tree after the workload
+	Height			int
+}
+
+// NsPerTreeOp returns the average time of a single operation on the tree.
+func (r *Result) NsPerTreeOp() float64 {
+	if r.Ops == 0 {
+		return 0
+	}
+
+	return float64(r.NsPerOp) / float64(r.Ops)
+}
+
+// Benchmark returns the benchmark function that runs the workload w with keys on
+// new trees of the implementation impl and reports the height of the tree and
+// the time per tree operation.
+func Benchmark(impl Impl, w Workload, keys []KeyType) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+
+		var t Tree
+		ops := 0
+		start := time.Now()
+		for n := 0; n < b.N; n++ {
+			t = impl.New()
+			ops = Run(t, w, keys)
+		}
+		elapsed := time.Since(start)
+
+		b.StopTimer()
+		b.ReportMetric(float64(t.Height()), "height")
+		b.ReportMetric(float64(elapsed.Nanoseconds()) / float64(b.N * ops), "ns/treeop")
+	}
+}
+
+// Measure runs the benchmark of the implementation impl on the workload w with n keys
+// with the distribution d and returns its result.
+func Measure(impl Impl, d Distribution, w Workload, n int, seed int64) Result {
+	keys := Keys(d, n, seed)
+	br := testing.Benchmark(Benchmark(impl, w, keys))
+
+	// Run the workload once more to get the height and the number of operations
+	t := impl.New()
+	ops := Run(t, w, keys)
+
+	return Result{
+		Tree:			impl.Name,
+		Distribution:	d,
+		Workload:		w,
+		Keys:			n,
+		Ops:			ops,
+		NsPerOp:		br.NsPerOp(),
+		AllocsPerOp:	br.AllocsPerOp(),
+		BytesPerOp:		br.AllocedBytesPerOp(),
+		Height:			t.Height(),
+	}
+}
+
+// WriteCSV writes results to w in CSV format with the header.
+func WriteCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{
+		"tree", "distribution", "workload", "keys", "ops",
+		"ns_per_op", "ns_per_tree_op", "allocs_per_op", "bytes_per_op", "height",
+	}); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if err := cw.Write([]string{
+			r.Tree, r.Distribution.String(), r.Workload.String(),
+			strconv.Itoa(r.Keys), strconv.Itoa(r.Ops),
+			strconv.FormatInt(r.NsPerOp, 10), strconv.FormatFloat(r.NsPerTreeOp(), 'f', 2, 64),
+			strconv.FormatInt(r.AllocsPerOp, 10), strconv.FormatInt(r.BytesPerOp, 10),
+			strconv.Itoa(r.Height),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,136 @@
+package bench
+
+import (
+	"github.com/r-che/algorithms/bst/aggrbtree"
+	"github.com/r-che/algorithms/bst/arenarbtree"
+	"github.com/r-che/algorithms/bst/nbtree"
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/bst/tdrbtree"
+	"github.com/r-che/algorithms/bst/wbtree"
+	"github.com/r-che/algorithms/internal/bintree"
+	"github.com/r-che/algorithms/list/skiplist"
+	"github.com/r-che/algorithms/mwt/btree"
+	"github.com/r-che/algorithms/mwt/tree234"
+	"github.com/r-che/algorithms/prefix/art"
+)
+
+// Adapters of trees to the Tree interface
+
+type nbTree struct{ t *nbtree.BSTree }
+
+func (a *nbTree) Insert(k KeyType) bool { return a.t.Insert(nbtree.NewBSTNode(nbtree.KeyType(k), nil)) != nil }
+func (a *nbTree) Search(k KeyType) bool { return a.t.Search(nbtree.KeyType(k)) != nil }
+func (a *nbTree) Height() int { return bintree.Height(a.t.Root()) }
+func (a *nbTree) Delete(k KeyType) bool {
+	n := a.t.Search(nbtree.KeyType(k))
+	if n == nil {
+		return false
+	}
+
+	a.t.Delete(n)
+
+	return true
+}
+
+type rbTree struct{ t *rbtree.RBTree }
+
+func (a *rbTree) Insert(k KeyType) bool { return a.t.Insert(rbtree.NewRBNode(k, nil)) != nil }
+func (a *rbTree) Search(k KeyType) bool { return a.t.Search(k) != nil }
+func (a *rbTree) Height() int { return bintree.Height(a.t.Root()) }
+func (a *rbTree) Delete(k KeyType) bool { return a.t.DeleteKey(k) }
+
+// countMonoid counts nodes of the augmented tree
+type countMonoid struct{}
+func (countMonoid) Identity() int { return 0 }
+func (countMonoid) Combine(a, b int) int { return a + b }
+func (countMonoid) Measure(*aggrbtree.AggNode[int]) int { return 1 }
+
+type aggTree struct{ t *aggrbtree.AggRBTree[int] }
+
+func (a *aggTree) Insert(k KeyType) bool { return a.t.Insert(aggrbtree.NewAggNode[int](k, nil)) != nil }
+func (a *aggTree) Search(k KeyType) bool { return a.t.Search(k) != nil }
+func (a *aggTree) Height() int { return bintree.Height(a.t.Root()) }
+func (a *aggTree) Delete(k KeyType) bool {
+	n := a.t.Search(k)
+	if n == nil {
+		return false
+	}
+
+	a.t.Delete(n)
+
+	return true
+}
+
+type arenaTree struct{ t *arenarbtree.ArenaRBTree }
+
+func (a *arenaTree) Insert(k KeyType) bool { return a.t.Insert(k, nil) != arenarbtree.Nil }
+func (a *arenaTree) Search(k KeyType) bool { return a.t.Search(k) != arenarbtree.Nil }
+func (a *arenaTree) Delete(k KeyType) bool { return a.t.Delete(a.t.Search(k)) }
+func (a *arenaTree) Height() int { return a.height(a.t.Root()) }
+
+func (a *arenaTree) height(n arenarbtree.NodeID) int {
+	if n == arenarbtree.Nil {
+		return 0
+	}
+
+	hl, hr := a.height(a.t.Left(n)), a.height(a.t.Right(n))
+	if hl > hr {
+		return hl + 1
+	}
+
+	return hr + 1
+}
+
+type tdTree struct{ t *tdrbtree.TDRBTree }
+
+func (a *tdTree) Insert(k KeyType) bool { return a.t.Insert(tdrbtree.NewTDNode(k, nil)) != nil }
+func (a *tdTree) Search(k KeyType) bool { return a.t.Search(k) != nil }
+func (a *tdTree) Delete(k KeyType) bool { return a.t.Delete(k) != nil }
+func (a *tdTree) Height() int { return bintree.Height(a.t.Root()) }
+
+type wbTree struct{ t *wbtree.WBTree }
+
+func (a *wbTree) Insert(k KeyType) bool { return a.t.Insert(k, nil) }
+func (a *wbTree) Search(k KeyType) bool { return a.t.Search(k) != nil }
+func (a *wbTree) Delete(k KeyType) bool { return a.t.Delete(k) != nil }
+func (a *wbTree) Height() int { h, _ := a.t.SelfTest(); return h }
+
+type bTree struct{ t *btree.BTree }
+
+func (a *bTree) Insert(k KeyType) bool { return a.t.Insert(btree.KeyType(k), nil) }
+func (a *bTree) Search(k KeyType) bool { _, ok := a.t.Search(btree.KeyType(k)); return ok }
+func (a *bTree) Delete(k KeyType) bool { _, ok := a.t.Delete(btree.KeyType(k)); return ok }
+func (a *bTree) Height() int { h, _ := a.t.SelfTest(); return h }
+
+// t234Tree does not support deletion
+type t234Tree struct{ t *tree234.Tree234 }
+
+func (a *t234Tree) Insert(k KeyType) bool { return a.t.Insert(k, nil) }
+func (a *t234Tree) Search(k KeyType) bool { _, ok := a.t.Search(k); return ok }
+func (a *t234Tree) Height() int { h, _ := a.t.SelfTest(); return h }
+
+// skipList is not a tree, its height is the number of levels
+type skipList struct{ l *skiplist.SkipList }
+
+func (a *skipList) Insert(k KeyType) bool { return a.l.Insert(skiplist.NewSLNode(skiplist.KeyType(k), nil)) != nil }
+func (a *skipList) Search(k KeyType) bool { return a.l.Search(skiplist.KeyType(k)) != nil }
+func (a *skipList) Height() int { return a.l.Level() }
+func (a *skipList) Delete(k KeyType) bool {
+	n := a.l.Search(skiplist.KeyType(k))
+	if n == nil {
+		return false
+	}
+
+	a.l.Delete(n)
+
+	return true
+}
+
+// artTree is not a binary tree, integer keys are encoded to byte keys, its height is
+// the number of inner nodes on the longest path from the root plus the leaf
+type artTree struct{ t *art.ART }
+
+func (a *artTree) Insert(k KeyType) bool { return a.t.Insert(art.IntKey(k), nil) }
+func (a *artTree) Search(k KeyType) bool { _, ok := a.t.Search(art.IntKey(k)); return ok }
+func (a *artTree) Delete(k KeyType) bool { _, ok := a.t.Delete(art.IntKey(k)); return ok }
+func (a *artTree) Height() int { h, _ := a.t.SelfTest(); return h }
@@ -0,0 +1,103 @@
+package bench
+
+import "fmt"
+
+// Workload defines the mix of operations performed on the tree
+type Workload int
+const (
+	// InsertHeavy - insertion of all keys followed by lookups of every 10th key
+	InsertHeavy = Workload(iota)
+	// LookupHeavy - insertion of all keys followed by 10 lookups of each key
+	LookupHeavy
+	// Mixed - insertion of the first half of keys followed by steps with insertion of
+	// the next key, deletion of the oldest key and two lookups
+	Mixed
+)
+
+// Ratio of lookups and insertions in insert-heavy and lookup-heavy workloads
+const lookupRatio = 10
+
+func (w Workload) String() string {
+	switch w {
+		case InsertHeavy:	return "InsertHeavy"
+		case LookupHeavy:	return "LookupHeavy"
+		case Mixed:			return "Mixed"
+	}
+
+	panic(fmt.Sprintf("Unexpected workload value: %d", w))
+}
+
+// Workloads returns all workloads.
+func Workloads() []Workload {
+	return []Workload{InsertHeavy, LookupHeavy, Mixed}
+}
+
+// Supports returns true if the implementation of the tree supports the workload w,
+// the Mixed workload requires the tree to implement Deleter.
+func (impl Impl) Supports(w Workload) bool {
+	if w != Mixed {
+		return true
+	}
+
+	_, ok := impl.New().(Deleter)
+
+	return ok
+}
+
+// Run runs the workload w with keys on the tree t and returns the number of performed operations.
+// It panics if the workload is not supported by the tree.
+func Run(t Tree, w Workload, keys []KeyType) int {
+	switch w {
+	case InsertHeavy:
+		for _, k := range keys {
+			t.Insert(k)
+		}
+		for i := 0; i < len(keys); i += lookupRatio {
+			t.Search(keys[i])
+		}
+
+		return len(keys) + (len(keys) + lookupRatio - 1) / lookupRatio
+
+	case LookupHeavy:
+		for _, k := range keys {
+			t.Insert(k)
+		}
+		for i := 0; i < lookupRatio; i++ {
+			for _, k := range keys {
+				t.Search(k)
+			}
+		}
+
+		return len(keys) * (lookupRatio + 1)
+
+	case Mixed:
+		d, ok := t.(Deleter)
+		if !ok {
+			panic(fmt.Sprintf("Workload %v requires deletion that is not supported by %T", w, t))
+		}
+
+		half := len(keys) / 2
+		for _, k := range keys[:half] {
+			t.Insert(k)
+		}
+
+		ops := half
+		for i, j := half, uint32(1); i < len(keys); i++ {
+			t.Insert(keys[i])
+			d.Delete(keys[i - half])
+
+			// Lookup the recently inserted key and the pseudo-random key that can be deleted already
+			j ^= j << 13
+			j ^= j >> 17
+			j ^= j << 5
+			t.Search(keys[i - 1])
+			t.Search(keys[int(j % uint32(i))])
+
+			ops += 4
+		}
+
+		return ops
+	}
+
+	panic(fmt.Sprintf("Unexpected workload value: %d", w))
+}
@@ -0,0 +1,441 @@
+/*
+Package aggrbtree provides an example of an augmented Red-black search tree implementation.
+
+In addition to the key and the data, each node of the tree keeps an aggregate of
+its subtree calculated by a user-supplied monoid: an associative Combine operation
+with an Identity element, and a Measure function that converts a single node
+to the aggregate value. Sums, minimums, maximums of values or any custom
+aggregates can be calculated this way. Aggregates are maintained by insertion,
+deletion and by all rotations performed by the rebalancing, so the aggregate
+over any range of keys is obtained in O(log n).
+
+Combine is not required to be commutative, aggregates are always combined in
+the ascending order of keys.
+
+It supports standard tree procedures, such as: inserting and deleting nodes,
+finding nodes by given arbitrary key, finding the root, maximum and minimum
+nodes, finding the predecessor and successor of a node.
+
+The tree can be converted to the [rbtree.RBTree] with the same structure, that is
+used for the colored output of graphical representation of the tree using ASCII
+graphics and to check properties of the red-black tree by SelfTest.
+
+[rbtree]: https://pkg.go.dev/github.com/r-che/algorithms/bst/rbtree
+*/
+package aggrbtree
+
+import "fmt"
+
+// Monoid describes aggregates of type A kept by the tree.
+type Monoid[A any] interface {
+	// Identity returns the identity element: Combine(Identity(), a) == Combine(a, Identity()) == a
+	Identity() A
+	// Combine returns the aggregate of two adjacent ranges of keys, a - left range, b - right range,
+	// it must be associative
+	Combine(a, b A) A
+	// Measure returns the aggregate of the single node n
+	Measure(n *AggNode[A]) A
+}
+
+// AggRBTree implements an augmented red-black tree with aggregates of type A.
+type AggRBTree[A any] struct {
+	root	*AggNode[A]
+	m		Monoid[A]
+}
+
+// NewAggRBTree returns new empty tree with aggregates calculated by the monoid m.
+func NewAggRBTree[A any](m Monoid[A]) *AggRBTree[A] {
+	return &AggRBTree[A]{m: m}
+}
+
+// Delete deletes the node n from the tree keeping the properties of the Red-Black tree.
+func (t *AggRBTree[A]) Delete(n *AggNode[A]) *AggNode[A] {
+	n = t.bstDelete(n)
+
+	// The subtrees of all ancestors of the removed node have changed. If n was replaced
+	// by its successor, n is one of these ancestors, so it is updated here too
+	t.updatePath(n.parent)
+
+	if t.root != nil {
+		t.fixupDel(n)
+	}
+
+	return n
+}
+
+// Insert inserts node n into the tree keeping the properties of the Red-Black tree.
+func (t *AggRBTree[A]) Insert(n *AggNode[A]) *AggNode[A] {
+	n, needFixup := t.bstInsert(n)
+	if n == nil {
+		// Already exists
+		return nil
+	}
+
+	// Add n to the aggregates of all its ancestors
+	t.updatePath(n)
+
+	if needFixup {
+		// RB insertion fixup
+		t.fixupIns(n)
+	}
+
+	return n
+}
+
+// SetValue replaces the data of the node n by data and updates the aggregates that depend on it.
+func (t *AggRBTree[A]) SetValue(n *AggNode[A], data any) {
+	n.data = data
+	t.updatePath(n)
+}
+
+// All returns the aggregate of all nodes of the tree, or the identity element if the tree is empty.
+func (t *AggRBTree[A]) All() A {
+	return t.agg(t.root)
+}
+
+// Aggregate returns the aggregate of nodes with keys in the closed range [lo, hi],
+// or the identity element if there are no such nodes.
+func (t *AggRBTree[A]) Aggregate(lo, hi KeyType) A {
+	if lo > hi {
+		return t.m.Identity()
+	}
+
+	// Find the split node - the highest node within the range, paths to lo and hi diverge on it
+	n := t.root
+	for n != nil && (n.key < lo || n.key > hi) {
+		if n.key < lo {
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+
+	if n == nil {
+		// No keys in the range
+		return t.m.Identity()
+	}
+
+	// All keys of the left subtree are less than hi, all keys of the right subtree are greater than lo
+	return t.m.Combine(t.m.Combine(t.aggFrom(n.left, lo), t.m.Measure(n)), t.aggTo(n.right, hi))
+}
+
+// aggFrom returns the aggregate of the nodes with keys >= lo in the subtree with root n
+func (t *AggRBTree[A]) aggFrom(n *AggNode[A], lo KeyType) A {
+	res := t.m.Identity()
+	for n != nil {
+		if n.key < lo {
+			// n and its left subtree are out of range
+			n = n.right
+			continue
+		}
+
+		// n and its right subtree are in the range and precede everything collected before
+		res = t.m.Combine(t.m.Combine(t.m.Measure(n), t.agg(n.right)), res)
+		n = n.left
+	}
+
+	return res
+}
+
+// aggTo returns the aggregate of the nodes with keys <= hi in the subtree with root n
+func (t *AggRBTree[A]) aggTo(n *AggNode[A], hi KeyType) A {
+	res := t.m.Identity()
+	for n != nil {
+		if n.key > hi {
+			// n and its right subtree are out of range
+			n = n.left
+			continue
+		}
+
+		// n and its left subtree are in the range and follow everything collected before
+		res = t.m.Combine(res, t.m.Combine(t.agg(n.left), t.m.Measure(n)))
+		n = n.right
+	}
+
+	return res
+}
+
+// agg returns the aggregate of the subtree with root n, the identity element for an empty subtree
+func (t *AggRBTree[A]) agg(n *AggNode[A]) A {
+	if n == nil {
+		return t.m.Identity()
+	}
+
+	return n.agg
+}
+
+// update recalculates the aggregate of n from the aggregates of its children
+func (t *AggRBTree[A]) update(n *AggNode[A]) {
+	n.agg = t.m.Combine(t.m.Combine(t.agg(n.left), t.m.Measure(n)), t.agg(n.right))
+}
+
+// updatePath recalculates aggregates of n and all its ancestors
+func (t *AggRBTree[A]) updatePath(n *AggNode[A]) {
+	for ; n != nil; n = n.parent {
+		t.update(n)
+	}
+}
+
+func (t *AggRBTree[A]) fixupIns(n *AggNode[A]) {	//nolint:varnamelen	// variable name too obvious to make it longer
+	if n.parent.color == Black {
+		// Nothing to fixup
+		return
+	}
+
+	//
+	// Red-violation - red node attached to red parent, fixup is required
+	//
+
+	//nolint:varnamelen	// variable names markings too obvious to make them longer
+	for {
+		// Get n's relatedness
+		f, u, g := determineRelatedness(n)
+
+		// Do fixup operations
+		switch {
+		// Red uncle
+		case u.Color() == Red:
+			if contFixup := t.fixupRedUncle(f, u, g); !contFixup {
+				// Stop fixup
+				return
+			}
+
+			// Do fixup again, use g as new initiator of red-violation
+			n = g
+
+		// Black uncle and n->f->g is a straight line
+		case u.Color() == Black && straightLine(n, f, g):
+			// Fixup nodes
+			t.fixupBlackUncleStraight(f, g)
+
+			// No more fixups required
+			return
+
+		// Black uncle and n->f->g is angle (not a straight line)
+		case u.Color() == Black && !straightLine(n, f, g):
+			// Fixup nodes
+			t.fixupBlackUncleAngle(n, f, g)
+
+			// No more fixups required
+			return
+
+		default:
+			panic(fmt.Sprintf("Unexpected state on nodes: n: %v f: %v g: %v u: %v", n, f, g, u))
+		}
+	}
+}
+
+// fixupRedUncle fixes tree when uncle color is red, only colors are changed, so aggregates remain valid
+func (t *AggRBTree[A]) fixupRedUncle(f, u, g *AggNode[A]) bool {
+	// Only a repaint is required
+	f.color = Black
+	u.color = Black
+
+	// Is g root?
+	if g == t.root {
+		// Root always black, stop repainting and fixup
+		return false
+	}
+
+	// Else - repaint g to red
+	g.color = Red	// this may cause new red-violation
+
+	// Return result of the check for new red-violation
+	return g.parent.color == Red
+}
+
+// fixupBlackUncleStraight fixes tree when: black uncle and n->f->g is a straight line
+func (t *AggRBTree[A]) fixupBlackUncleStraight(f, g *AggNode[A]) {
+	// Repaint nodes
+	f.color = Black
+	g.color = Red
+
+	// Now, need rotate g around f
+
+	// Define rotation direction and rotate
+	if f == g.left {
+		// f is a left child of g - need to rotate right
+		t.rotate(Right, g, f)
+	} else {
+		// f is a right child of g - need to rotate left
+		t.rotate(Left, g, f)
+	}
+}
+
+// fixupBlackUncleAngle fixes tree when: black uncle and n->f->g is angle (not a straight line)
+func (t *AggRBTree[A]) fixupBlackUncleAngle(n, f, g *AggNode[A]) {
+	// Repaint nodes
+	g.color = Red
+	n.color = Black
+
+	// Now, double rotation is required
+
+	// Define rotation direction and rotate
+	if f == g.left {
+		// f is a left child of g - need to rotate left+right
+		t.rotateDouble(LeftRight, g, f)
+	} else {
+		// f is a right child of g - need to rotate right+left
+		t.rotateDouble(RightLeft, g, f)
+	}
+}
+
+func (t *AggRBTree[A]) fixupDel(d *AggNode[A]) {	//nolint:varnamelen	// variable name too obvious to make it longer
+	//
+	// Simple fixup cases
+	//
+
+	if d.color == Red {
+		// Violations is not possible if deleted node is red
+		return
+	}
+
+	//nolint:varnamelen	// n has too common meaning to make its name longer
+	n, cleanFake := t.determChildOfDeleted(d)
+
+	// Defer cleanup of fake node if it was created
+	defer cleanFake()
+
+	// If child of deleted node is Red - only repaint required
+	if n.color == Red {
+		// Repaint to Black and return
+		n.color = Black
+
+		return
+	}
+
+	//
+	// More complex fixup, rotations in the cases #2..4 update aggregates of the rotated nodes
+	//
+
+	//nolint:varnamelen	// variable names markings too obvious to make them longer
+	for n != nil {
+		// Determine participants of fixup
+		f, b, cn, cf := determParticipants(n)
+
+		// Determine turns
+		turnCase2or4, turnCase3 := determTurns(n, f)
+
+		switch {
+			// 1. f is Red, others are Black
+			case t.fixCase1(n, f, b, cn, cf):
+				return
+
+			// 2. b is Black, cf is Red
+			case t.fixCase2(b, cf, f, turnCase2or4):
+				return
+
+			// 3. b is Black, cf is Black, cn is Red
+			case t.fixCase3(b, cn, cf, turnCase3):
+				// XXX Now situation brought to the case #2, required fixup will be done on the next iteration
+
+			// 4. b is Red
+			case t.fixCase4(f, b, turnCase2or4):
+				// XXX Now situation brought to the cases #1..3, required fixup will be done on the next iteration
+
+			// 5. All are Black
+			case t.allBlack(n, f, b, cn, cf):
+				// Update n value by result of fixup
+				n = t.fixCase5(f, b)
+
+			default:
+				panic(fmt.Sprintf("Unexpected state on nodes: D: %v N: %v F: %v B: %v Cn: %v Cf: %v",
+					d, n, f, b, cn, cf))
+		}
+	}
+}
+
+func (t *AggRBTree[A]) fixCase1(n, f, b, cn, cf *AggNode[A]) bool {
+	if !(n.color == Black &&
+			f.color  == Red &&
+			b.Color()  == Black &&
+			cn.Color() == Black &&
+			cf.Color() == Black) {
+		// Another case
+		return false
+	}
+
+	// Swap colors between f and b
+	swapColors(f, b)
+
+	// Fixed
+	return true
+}
+
+func (t *AggRBTree[A]) fixCase2(b, cf, f *AggNode[A], turn Rotate) bool {
+	if !(b.Color() == Black && cf.Color() == Red) {
+		// Another case
+		return false
+	}
+
+	// 1. Rotate f around b
+	t.rotate(turn, f, b)
+
+	// 2. Repainting cf to Black
+	cf.SetColor(Black)
+
+	// 3. Swap colors between f and b
+	swapColors(f, b)
+
+	// Fixed
+	return true
+}
+
+func (t *AggRBTree[A]) fixCase3(b, cn, cf *AggNode[A], turn Rotate) bool {
+	if !(b.Color() == Black &&
+		cn.Color() == Red &&
+		cf.Color() == Black) {
+		// Another case
+		return false
+	}
+
+	// Rotate b around cn
+	t.rotate(turn, b, cn)
+
+	// Flip colors of b and cn
+	b.Flip()
+	cn.Flip()
+
+	// Fixed
+	return true
+}
+
+func (t *AggRBTree[A]) fixCase4(f, b *AggNode[A], turn Rotate) bool {
+	if !(b.Color() ==  Red) {
+		// Another case
+		return false
+	}
+
+	// Rotate f around b
+	t.rotate(turn, f, b)
+
+	// Flip colors of f and b
+	f.color = !f.color
+	b.Flip()
+
+	// Fixed
+	return true
+}
+
+func (t *AggRBTree[A]) allBlack(nodes ...*AggNode[A]) bool {
+	for _, n := range nodes {
+		if n.Color() != Black {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *AggRBTree[A]) fixCase5(f, b *AggNode[A]) *AggNode[A] {
+	b.SetColor(Red)
+
+	// Check for f is tree root
+	if f == t.root {
+		// Fixup is done
+		return nil
+	}
+
+	// Return f to use it as next node to fixup
+	return f
+}
@@ -0,0 +1,325 @@
+package aggrbtree
+
+import (
+	"testing"
+	"math/rand"
+	"reflect"
+	"sort"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Aggregates are compared with naive sums over all keys, so the number of keys is moderate
+	keysCount	=	4096
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2037
+
+	// Run self-test after each selfTestStep modifications
+	selfTestStep	=	64
+	// Number of random ranges to check aggregates
+	rangesCount		=	4096
+)
+
+// sumMonoid calculates sum of integer values of nodes
+type sumMonoid struct{}
+func (sumMonoid) Identity() int { return 0 }
+func (sumMonoid) Combine(a, b int) int { return a + b }
+func (sumMonoid) Measure(n *AggNode[int]) int { return n.Value().(int) }
+
+// keysMonoid collects keys of nodes in the order of combining, it is not commutative
+type keysMonoid struct{}
+func (keysMonoid) Identity() []KeyType { return nil }
+func (keysMonoid) Combine(a, b []KeyType) []KeyType {
+	return append(append(make([]KeyType, 0, len(a) + len(b)), a...), b...)
+}
+func (keysMonoid) Measure(n *AggNode[[]KeyType]) []KeyType { return []KeyType{n.Key()} }
+
+// nodeValue returns the value associated with key k in the tests
+func nodeValue(k KeyType) int {
+	return int(k) % 1000 - 500
+}
+
+func newSumTree(keys []KeyType) (*AggRBTree[int], []KeyType) {
+	tree := NewAggRBTree[int](sumMonoid{})
+	for _, k := range keys {
+		tree.Insert(NewAggNode[int](k, nodeValue(k)))
+	}
+
+	sKeys := make([]KeyType, len(keys))
+	copy(sKeys, keys)
+	sort.Slice(sKeys, func(i, j int) bool { return sKeys[i] < sKeys[j] })
+
+	return tree, sKeys
+}
+
+// naiveSum returns sum of values of keys in range [lo, hi] from sorted keys
+func naiveSum(sKeys []KeyType, lo, hi KeyType) int {
+	sum := 0
+	for i := sort.Search(len(sKeys), func(i int) bool { return sKeys[i] >= lo });
+		i < len(sKeys) && sKeys[i] <= hi; i++ {
+		sum += nodeValue(sKeys[i])
+	}
+
+	return sum
+}
+
+func TestEmpty(t *testing.T) {
+	tree := NewAggRBTree[int](sumMonoid{})
+
+	if n := tree.Root(); n != nil {
+		t.Errorf("Root returned non-nil value %v (%#v) on empty tree", n, n)
+	}
+
+	if v := tree.All(); v != 0 {
+		t.Errorf("All returned %d on empty tree, want - 0", v)
+	}
+
+	if v := tree.Aggregate(0, MaxItem); v != 0 {
+		t.Errorf("Aggregate returned %d on empty tree, want - 0", v)
+	}
+
+	if h, err := tree.SelfTest(); h != 0 || err != nil {
+		t.Errorf("SelfTest returned %d, %v on empty tree, want - 0, nil", h, err)
+	}
+
+	if s, want := tree.String(), rbtree.NewRBTree().String(); s != want {
+		t.Errorf("String returned %q on empty tree, want - %q", s, want)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := NewAggRBTree[int](sumMonoid{})
+
+	sum := 0
+	for i, k := range testKeys {
+		if n := tree.Insert(NewAggNode[int](k, nodeValue(k))); n == nil {
+			t.Errorf("[%d] AggRBTree.Insert returned nil on unique key %v", i, k)
+			t.FailNow()
+		}
+		sum += nodeValue(k)
+
+		if v := tree.All(); v != sum {
+			t.Errorf("[%d] All returned %d after insertion of %v, want - %d", i, v, k, sum)
+			t.FailNow()
+		}
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%d] tree structure issue after insertion of %v: %v", i, k, err)
+			t.FailNow()
+		}
+	}
+
+	// Duplicates must not change aggregates
+	for i, k := range testKeys {
+		if n := tree.Insert(NewAggNode[int](k, 1)); n != nil {
+			t.Errorf("[%d] AggRBTree.Insert returned %v on duplicate key %v, want - nil", i, n, k)
+			t.FailNow()
+		}
+	}
+
+	if v := tree.All(); v != sum {
+		t.Errorf("All returned %d after insertion of duplicates, want - %d", v, sum)
+	}
+}
+
+func TestSameAsRBTree(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, _ := newSumTree(testKeys)
+
+	rbt := rbtree.NewRBTree()
+	for _, k := range testKeys {
+		rbt.Insert(rbtree.NewRBNode(k, nodeValue(k)))
+	}
+
+	// Insertion produces exactly the same tree
+	if s, want := tree.String(), rbt.String(); s != want {
+		t.Errorf("String of the tree differs from the red-black tree with the same keys")
+	}
+
+	h, err := tree.SelfTest()
+	if want, _ := rbt.SelfTest(); h != want || err != nil {
+		t.Errorf("SelfTest returned %d, %v, want - %d, nil", h, err, want)
+	}
+}
+
+func TestDelRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newSumTree(testKeys)
+	sum := tree.All()
+
+	for i := 0; len(sKeys) != 0; i++ {
+		// Get the random element from the sKeys
+		idx := rnd.Int() % len(sKeys)
+		k := sKeys[idx]
+		// Remove k from keys slice
+		sKeys = append(sKeys[:idx], sKeys[idx+1:]...)
+
+		tree.Delete(tree.Search(k))
+		sum -= nodeValue(k)
+
+		if v := tree.All(); v != sum {
+			t.Errorf("[%d] All returned %d after deletion of %v, want - %d", i, v, k, sum)
+			t.FailNow()
+		}
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%d] tree structure issue after deletion of %v: %v", i, k, err)
+			t.FailNow()
+		}
+
+		// Check some ranges on the changed tree
+		lo := KeyType(rnd.Int() % (MaxItem + 1))
+		hi := lo + KeyType(rnd.Int() % (MaxItem / 10))
+		if v, want := tree.Aggregate(lo, hi), naiveSum(sKeys, lo, hi); v != want {
+			t.Errorf("[%d] Aggregate(%v, %v) returned %d, want - %d", i, lo, hi, v, want)
+			t.FailNow()
+		}
+	}
+
+	if tree.Root() != nil {
+		t.Errorf("tree is not empty after deletion of all keys: %v", tree.Root())
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newSumTree(testKeys)
+
+	for i := 0; i < rangesCount; i++ {
+		lo := KeyType(rnd.Int() % (MaxItem + 1))
+		hi := KeyType(rnd.Int() % (MaxItem + 1))
+
+		want := 0
+		if lo <= hi {
+			want = naiveSum(sKeys, lo, hi)
+		}
+
+		if v := tree.Aggregate(lo, hi); v != want {
+			t.Errorf("[%d] Aggregate(%v, %v) returned %d, want - %d", i, lo, hi, v, want)
+			t.FailNow()
+		}
+	}
+
+	// Ranges bounded by existing keys
+	for i := 0; i < rangesCount; i++ {
+		l, h := rnd.Int() % len(sKeys), rnd.Int() % len(sKeys)
+		if l > h {
+			l, h = h, l
+		}
+
+		if v, want := tree.Aggregate(sKeys[l], sKeys[h]), naiveSum(sKeys, sKeys[l], sKeys[h]); v != want {
+			t.Errorf("[%d] Aggregate(%v, %v) returned %d, want - %d", i, sKeys[l], sKeys[h], v, want)
+			t.FailNow()
+		}
+	}
+}
+
+func TestAggregateOrder(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := NewAggRBTree[[]KeyType](keysMonoid{})
+	for _, k := range testKeys {
+		tree.Insert(NewAggNode[[]KeyType](k, nil))
+	}
+
+	sKeys := tree.All()
+	if !sort.SliceIsSorted(sKeys, func(i, j int) bool { return sKeys[i] < sKeys[j] }) || len(sKeys) != keysCount {
+		t.Errorf("All returned %d unsorted keys, want - %d sorted keys", len(sKeys), keysCount)
+		t.FailNow()
+	}
+
+	for i := 0; i < rangesCount; i++ {
+		l, h := rnd.Int() % len(sKeys), rnd.Int() % len(sKeys)
+		if l > h {
+			l, h = h, l
+		}
+
+		lo, hi := sKeys[l], sKeys[h]
+		if v, want := tree.Aggregate(lo, hi), sKeys[l:h+1]; !reflect.DeepEqual(v, want) {
+			t.Errorf("[%d] Aggregate(%v, %v) returned %v, want - %v", i, lo, hi, v, want)
+			t.FailNow()
+		}
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newSumTree(testKeys)
+	sum := tree.All()
+
+	for i, k := range testKeys[:keysCount/4] {
+		n := tree.Search(k)
+		tree.SetValue(n, n.Value().(int) + 1)
+		sum++
+
+		if v := tree.All(); v != sum {
+			t.Errorf("[%d] All returned %d after update of %v, want - %d", i, v, k, sum)
+			t.FailNow()
+		}
+	}
+
+	if _, err := tree.SelfTest(); err != nil {
+		t.Errorf("tree structure issue after updates of values: %v", err)
+	}
+
+	if v := tree.Aggregate(sKeys[0], sKeys[len(sKeys)-1]); v != sum {
+		t.Errorf("Aggregate over all keys returned %d, want - %d", v, sum)
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, selfTestStep, KeyType(MaxItem))
+
+	for i, test := range []struct {
+		breaker	func(tree *AggRBTree[int])
+		want	string
+	} {
+		{
+			func(tree *AggRBTree[int]) { tree.root.color = Red },
+			"v#5",
+		}, {
+			func(tree *AggRBTree[int]) { tree.Min().agg++ },
+			"v#6",
+		}, {
+			func(tree *AggRBTree[int]) { tree.Max().data = tree.Max().data.(int) + 1 },
+			"v#6",
+		},
+	} {
+		tree, _ := newSumTree(testKeys)
+		test.breaker(tree)
+
+		h, err := tree.SelfTest()
+		if err == nil || h != 0 {
+			t.Errorf("[%d] SelfTest returned %d, %v on broken tree, want - 0, %s error", i, h, err, test.want)
+			continue
+		}
+
+		if msg := err.Error(); len(msg) < len(test.want) || msg[:len(test.want)] != test.want {
+			t.Errorf("[%d] SelfTest returned error %q, want - %s error", i, msg, test.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package aggrbtree
+
+import "fmt"
+
+// maxValue calculates the maximum of integer values of nodes
+type maxValue struct{}
+
+func (maxValue) Identity() int { return -1 }
+func (maxValue) Combine(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+func (maxValue) Measure(n *AggNode[int]) int { return n.Value().(int) }
+
+//nolint:testableexamples
+func Example_treeCreation() {
+	// Create tree
+	tree := NewAggRBTree[int](maxValue{})
+
+	// Insert keys and data
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(NewAggNode[int](k, int(k) * 10))
+	}
+
+	// Print graphical representation of the tree
+	fmt.Print(tree)
+}
+
+func Example_rangeAggregate() {
+	// Tree with latencies of requests (ms) by timestamps
+	tree := NewAggRBTree[int](maxValue{})
+	for ts, latency := range map[KeyType]int{
+		100: 12, 105: 48, 110: 7, 120: 95, 125: 30, 130: 11, 140: 64, 150: 5,
+	} {
+		tree.Insert(NewAggNode[int](ts, latency))
+	}
+
+	fmt.Println("Max latency:", tree.All())
+	fmt.Println("Max latency in [100, 115]:", tree.Aggregate(100, 115))
+	fmt.Println("Max latency in [121, 150]:", tree.Aggregate(121, 150))
+
+	// Remove the worst request and update another one
+	tree.Delete(tree.Search(120))
+	tree.SetValue(tree.Search(130), 70)
+	fmt.Println("Max latency in [121, 150]:", tree.Aggregate(121, 150))
+
+	// Output:
+	// Max latency: 95
+	// Max latency in [100, 115]: 48
+	// Max latency in [121, 150]: 64
+	// Max latency in [121, 150]: 70
+}
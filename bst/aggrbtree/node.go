@@ -0,0 +1,127 @@
+package aggrbtree
+
+import (
+	"github.com/r-che/algorithms/bst/rbtree"
+)
+
+// KeyType represents the key type of a tree node, it is the same as the key type of the red-black tree
+type KeyType = rbtree.KeyType
+
+// ColorType represents the color of a tree node, it is the same as the color type of the red-black tree
+type ColorType = rbtree.ColorType
+
+const (
+	FakeNode	=	rbtree.FakeNode
+	strFakeNode	=	`<>`
+
+	Red		=	rbtree.Red
+	Black	=	rbtree.Black
+)
+
+// AggNode implements a node of the augmented red-black tree, in addition to the key and
+// the data it keeps the aggregate value of the subtree with root in this node.
+type AggNode[A any] struct {
+	key		KeyType
+	left	*AggNode[A]
+	right	*AggNode[A]
+	parent	*AggNode[A]
+
+	color	ColorType
+
+	data	any
+
+	// Aggregate of the subtree with root in this node
+	agg		A
+}
+
+func NewAggNode[A any](key KeyType, data any) *AggNode[A] {
+	return &AggNode[A]{
+		key: key,
+		data: data,
+	}
+}
+
+func (n *AggNode[A]) String() string {
+	if n == nil {
+		return Black.String() + "<nil>"
+	}
+	if n.key == FakeNode {
+		return strFakeNode
+	}
+
+	return n.color.String() + n.key.String()
+}
+
+func (n *AggNode[A]) Color() ColorType {
+	if n == nil {
+		// Leaf always black
+		return Black
+	}
+
+	return n.color
+}
+
+func (n *AggNode[A]) SetColor(color ColorType) {
+	if n != nil {
+		n.color = color
+	}
+}
+
+// Flip reverses node color: Red to Black or Black to Red.
+func (n *AggNode[A]) Flip() {
+	if n != nil {
+		n.color = !n.color
+	}
+}
+
+// Key returns the key value of the node
+func (n *AggNode[A]) Key() KeyType {
+	if n == nil {
+		return FakeNode
+	}
+	return n.key
+}
+
+// Value returns the data associated with the node
+func (n *AggNode[A]) Value() any {
+	if n == nil {
+		return nil
+	}
+
+	return n.data
+}
+
+// Aggregate returns the aggregate value of the subtree with root n,
+// or zero value of A if n is nil.
+func (n *AggNode[A]) Aggregate() A {
+	if n == nil {
+		var zero A
+		return zero
+	}
+
+	return n.agg
+}
+
+// Left returns the left child of the node
+func (n *AggNode[A]) Left() *AggNode[A] {
+	if n == nil {
+		return nil
+	}
+	return n.left
+}
+
+// Right returns the right child of the node
+func (n *AggNode[A]) Right() *AggNode[A] {
+	if n == nil {
+		return nil
+	}
+	return n.right
+}
+
+// Parent returns the parent of the node
+func (n *AggNode[A]) Parent() *AggNode[A] {
+	if n == nil {
+		return nil
+	}
+	return n.parent
+}
@@ -0,0 +1,100 @@
+package aggrbtree
+
+import (
+	"fmt"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+)
+
+// Rotate represents single rotate values, it is the same as the rotate type of the red-black tree
+type Rotate = rbtree.Rotate
+const (
+	Left	=	rbtree.Left
+	Right	=	rbtree.Right
+)
+
+// RotateDouble represents double rotate values, it is the same as the double rotate type of the red-black tree
+type RotateDouble = rbtree.RotateDouble
+const (
+	LeftRight	=	rbtree.LeftRight
+	RightLeft	=	rbtree.RightLeft
+)
+
+//
+// AggRBTree rotation operations
+//
+
+func (t *AggRBTree[A]) rotateDouble(rType RotateDouble, pivot, node *AggNode[A]) {
+	// Aggregates are updated by both single rotations
+	switch rType {
+		case LeftRight:
+			// Do left rotation using node as pivot
+			nextNode := node.right
+			t.rotate(Left, node, nextNode)
+
+			// Do right rotation around pivot, using next node as left child node of pivot
+			t.rotate(Right, pivot, nextNode)
+		case RightLeft:
+			// Do right rotation using node as pivot
+			nextNode := node.left
+			t.rotate(Right, node, nextNode)
+
+			// Do left rotation around pivot, using next node as left child node of pivot
+			t.rotate(Left, pivot, nextNode)
+		default:
+			panic(fmt.Sprintf("Unsupported rotation type: %d", rType))
+	}
+}
+
+func (t *AggRBTree[A]) rotate(rType Rotate, pivot, node *AggNode[A]) {
+	// Select rotate type
+	switch rType {
+		case Left:
+			// Attach left child of node to right of pivot
+			pivot.right = node.left
+			if node.left != nil {
+				node.left.parent = pivot
+			}
+
+			// Make pivot left child of the node
+			node.left = pivot
+
+		case Right:
+			// Attach right child of node to left of pivot
+			pivot.left = node.right
+			if node.right != nil {
+				node.right.parent = pivot
+			}
+
+			// Make pivot right child of the node
+			node.right = pivot
+
+		default:
+			panic(`Unsupported rotation type "` + rType.String() + `" in rotate(), must be only Left or Right`)
+	}
+
+	// Update parents
+	node.parent = pivot.parent
+	if parent := pivot.parent; parent != nil {
+		// Need to update pointer in the pivot's parent
+		if parent.left == pivot {
+			// Update left pointer
+			parent.left = node
+		} else {
+			// Update right pointer
+			parent.right = node
+		}
+	} else {
+		// pivot parent == nil => pivot is the root of the tree,
+		// need to update pointer to the tree root
+		t.root = node
+	}
+
+	pivot.parent = node
+
+	// Only pivot and node have changed their subtrees, pivot is the child now,
+	// so it has to be updated first. The set of keys below node is the same as
+	// it was below pivot, so aggregates of ancestors remain valid
+	t.update(pivot)
+	t.update(node)
+}
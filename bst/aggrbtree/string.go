@@ -0,0 +1,30 @@
+package aggrbtree
+
+import "github.com/r-che/algorithms/bst/rbtree"
+
+// ToRBTree converts the tree to the red-black tree with the same structure and colors of
+// nodes. Keys and associated data are copied, aggregates are dropped, t is not modified.
+func (t *AggRBTree[A]) ToRBTree() *rbtree.RBTree {
+	if t.root == nil {
+		return rbtree.NewRBTree()
+	}
+
+	return rbtree.NewRBTreeFromRoot(toRBNode(t.root))
+}
+
+// toRBNode converts the subtree with root n
+func toRBNode[A any](n *AggNode[A]) *rbtree.RBNode {
+	if n == nil {
+		return nil
+	}
+
+	rbn := rbtree.NewRBNode(n.key, n.data)
+	rbn.SetColor(n.color)
+	rbn.SetChildren(toRBNode(n.left), toRBNode(n.right))
+
+	return rbn
+}
+
+func (t *AggRBTree[A]) String() string {
+	return t.ToRBTree().String()
+}
@@ -0,0 +1,151 @@
+package aggrbtree
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SelfTest performs a self-test of the red-black tree and returns the black-height,
+// and a description of the problem if detected. Properties of the red-black tree are
+// checked by the tree of the [rbtree] package with the same structure, in addition it
+// checks that the aggregate of each node is equal to the aggregate calculated from its
+// children. If an issuse is detected, the black-height is zero.
+func (t *AggRBTree[A]) SelfTest() (int, error) {
+	bh, err := t.ToRBTree().SelfTest()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := t.testAgg(t.root); err != nil {
+		return 0, err
+	}
+
+	return bh, nil
+}
+
+// testAgg checks aggregates of all nodes of the subtree with root n
+func (t *AggRBTree[A]) testAgg(n *AggNode[A]) error {
+	// No errors on empty sub-tree
+	if n == nil {
+		return nil
+	}
+
+	if err := t.testAgg(n.left); err != nil {
+		return err
+	}
+	if err := t.testAgg(n.right); err != nil {
+		return err
+	}
+
+	// Test aggregate of the subtree, children are already checked
+	if want := t.m.Combine(t.m.Combine(t.agg(n.left), t.m.Measure(n)), t.agg(n.right));
+		!reflect.DeepEqual(n.agg, want) {
+		return fmt.Errorf("v#6: node %v has aggregate %v, want - %v", n, n.agg, want)
+	}
+
+	return nil
+}
+
+func swapColors[A any](n1, n2 *AggNode[A]) {
+	n1.color, n2.color = n2.color, n1.color
+}
+
+// straightLine returns true if child c is added to parent f on the same side that f is added as child to g
+func straightLine[A any](c, f, g *AggNode[A]) bool {
+	if c == f.left && f == g.left ||
+	   c == f.right && f == g.right {
+		// Straight line c->f->g
+		return true
+	}
+
+	// Not straight
+	return false
+}
+
+func determineRelatedness[A any](n *AggNode[A]) (f, u, g *AggNode[A]) {	//nolint:nonamedreturns
+	f = n.parent	// father of n
+	g = f.parent	// grandfather of n
+
+	// Determine uncle of n
+	if g.left == f {
+		// Uncle is right child of "grandfather"
+		u = g.right
+	} else {
+		// Uncle is left child of parent
+		u = g.left
+	}
+
+	return f, u, g
+}
+
+// determChildOfDeleted returns the child of the deleted node d, if d has no children
+// a fake node is returned with the function to remove it from the tree
+func (t *AggRBTree[A]) determChildOfDeleted(d *AggNode[A]) (*AggNode[A], func()) { //nolint:varnamelen,lll
+	// XXX Deleted node can have only 0 or 1 child
+	if n := d.left; n != nil {
+		// Return left child of deleted node
+		return n, func(){}
+	}
+
+	// Left child does not exist, check for right
+	if n := d.right; n != nil {
+		// Return left child of deleted node
+		return n, func(){}
+	}
+
+	// XXX Deleted node does not have children, return fake node. It can be rotated
+	// XXX together with its parent, so its aggregate must not affect the parent
+	fakeChild := &AggNode[A]{
+		parent:	d.parent,
+		key:	FakeNode,
+		agg:	t.m.Identity(),
+	}
+
+	// Need to assign fakeChild to correct side of d.parent
+	// XXX We can safely remove fake node from f (assign nil to child pointer)
+	// XXX when returning from the function, because there is no combination that
+	// XXX can replace the values of f pointers with some other node(s)
+	if d.parent.left == nil {
+		// d was removed from left side, assign fakeChild as left child
+		d.parent.left = fakeChild
+
+		// Return fakeChild with function to cleanup left child of deleted parent
+		return fakeChild, func() { d.parent.left = nil }
+	}
+
+	// Otherwise - d was right child of its parent
+	d.parent.right = fakeChild
+
+	// Return fakeChild with function to cleanup right child of deleted parent
+	return fakeChild, func() { d.parent.right = nil }
+}
+
+// determParticipants returns participants of fixup:
+// f - father of node, b - brother of node, cn - nearside child of b, cf - far side child of b
+func determParticipants[A any](n *AggNode[A]) (f, b, cn, cf *AggNode[A]) {	//nolint:nonamedreturns
+	f = n.parent	// "father"
+
+	if f.left == n {
+		// n left of f => New brother is right node of f
+		b = f.right
+		// Assign children to left case
+		/* n, */ cn, cf = /* n, */ b.left, b.right
+	} else {
+		// n right of f => new brother is left node of f
+		b = f.left
+		// Assign children to right case
+		cf, cn /*, n */ = b.left, b.right /*, n */
+	}
+
+	return
+}
+
+func determTurns[A any](n, f *AggNode[A]) (turnCase2or4, turnCase3 Rotate) {	//nolint:nonamedreturns
+	// If n is a left child of f
+	if f.left == n {
+		return Left, Right
+	}
+
+	// n is right child of f
+	return Right, Left
+}
@@ -0,0 +1,220 @@
+/*
+Package arenarbtree provides an example of a Red-black search tree implementation
+with nodes allocated from an arena.
+
+Nodes of the tree are stored in a single slice and refer to each other by int32
+indexes instead of pointers. The whole tree takes a few large allocations instead
+of one allocation per node, nodes are smaller and the garbage collector does not
+need to follow pointers between them, which reduces GC pressure on big trees.
+Deleted nodes are linked into a free list and recycled by subsequent insertions.
+
+Nodes are referred by handles of type NodeID, the handle of a deleted node becomes
+invalid and can be reused for a new node. Unlike the [rbtree] package, deletion
+does not move keys and data between nodes, so handles of other nodes stay valid.
+
+It supports the same tree procedures as the [rbtree] package: inserting and deleting
+nodes, finding nodes by given arbitrary key, finding the root, maximum and minimum
+nodes, finding the predecessor and successor of a node. The tree can be converted to
+the [rbtree.RBTree] with the same structure, that is used for the graphical
+representation of the tree.
+
+The API deliberately differs from [rbtree.RBTree] where the latter passes nodes by
+pointers: methods take and return NodeID handles, Insert takes the key and the data
+instead of a prepared node, and fields of nodes are read by methods of the tree - Key,
+Value, Color, Left, Right and Parent. A wrapper with the pointer API would allocate
+a node for each call and defeat the purpose of the arena. Methods that take and return
+only keys and data - DeleteKey, DeleteMin, DeleteMax and SelfTest - have the same
+signatures as in [rbtree]. Cursors, the multiset mode, DeleteRange and InsertFunc of
+the [rbtree] package are not supported.
+
+[rbtree]: https://pkg.go.dev/github.com/r-che/algorithms/bst/rbtree
+*/
+package arenarbtree
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+)
+
+// KeyType represents the key type of a tree node
+type KeyType = rbtree.KeyType
+
+// ColorType represents the color of a tree node
+type ColorType = rbtree.ColorType
+
+const (
+	Red			=	rbtree.Red
+	Black		=	rbtree.Black
+	FakeNode	=	rbtree.FakeNode
+)
+
+// NodeID is a handle of the tree node - the index of the node in the arena
+type NodeID int32
+
+const (
+	// Nil is the handle of an absent node
+	Nil	=	NodeID(0)
+
+	// MaxNodes is the maximum number of nodes in the tree
+	MaxNodes	=	math.MaxInt32 - 1
+)
+
+// Sides of children of a node
+const (
+	left	=	0
+	right	=	1
+)
+
+// node is a node of the tree stored in the arena
+type node struct {
+	key		KeyType
+	// Left and right children
+	child	[2]NodeID
+	parent	NodeID
+	color	ColorType
+	// The node is in the tree, false for the sentinel and nodes in the free list
+	used	bool
+
+	data	any
+}
+
+// ArenaRBTree implements a red-black tree with nodes allocated from an arena.
+type ArenaRBTree struct {
+	// Arena of nodes, the node with index Nil is a black sentinel used as a leaf
+	nodes	[]node
+	root	NodeID
+	// Head of the list of free nodes linked by their right children
+	free	NodeID
+	size	int
+}
+
+// NewArenaRBTree returns new empty tree with the arena preallocated for capacity nodes.
+func NewArenaRBTree(capacity int) *ArenaRBTree {
+	if capacity < 0 || capacity > MaxNodes {
+		panic(fmt.Sprintf("Invalid capacity %d, must be in range [0, %d]", capacity, MaxNodes))
+	}
+
+	return &ArenaRBTree{nodes: make([]node, 1, capacity + 1)}
+}
+
+// Len returns the number of nodes in the tree.
+func (t *ArenaRBTree) Len() int {
+	return t.size
+}
+
+// Allocated returns the number of nodes allocated in the arena including the free ones.
+func (t *ArenaRBTree) Allocated() int {
+	return len(t.nodes) - 1
+}
+
+// Clear removes all nodes from the tree, the memory of the arena is kept for new nodes.
+func (t *ArenaRBTree) Clear() {
+	// Clean up nodes to release the data associated with them
+	for i := range t.nodes {
+		t.nodes[i] = node{}
+	}
+
+	t.nodes = t.nodes[:1]
+	t.root, t.free, t.size = Nil, Nil, 0
+}
+
+// Valid returns true if n is a handle of the node in the tree.
+func (t *ArenaRBTree) Valid(n NodeID) bool {
+	return n > Nil && int(n) < len(t.nodes) && t.nodes[n].used
+}
+
+// Key returns the key value of the node n or FakeNode if n is not valid.
+func (t *ArenaRBTree) Key(n NodeID) KeyType {
+	if !t.Valid(n) {
+		return FakeNode
+	}
+
+	return t.nodes[n].key
+}
+
+// Value returns the data associated with the node n or nil if n is not valid.
+func (t *ArenaRBTree) Value(n NodeID) any {
+	if !t.Valid(n) {
+		return nil
+	}
+
+	return t.nodes[n].data
+}
+
+// SetValue associates the data with the node n. It returns false if n is not valid.
+func (t *ArenaRBTree) SetValue(n NodeID, data any) bool {
+	if !t.Valid(n) {
+		return false
+	}
+
+	t.nodes[n].data = data
+
+	return true
+}
+
+// Color returns the color of the node n, leaves and not valid nodes are black.
+func (t *ArenaRBTree) Color(n NodeID) ColorType {
+	if !t.Valid(n) {
+		return Black
+	}
+
+	return t.nodes[n].color
+}
+
+// Left returns the left child of the node n or Nil if there is no such child.
+func (t *ArenaRBTree) Left(n NodeID) NodeID {
+	if !t.Valid(n) {
+		return Nil
+	}
+
+	return t.nodes[n].child[left]
+}
+
+// Right returns the right child of the node n or Nil if there is no such child.
+func (t *ArenaRBTree) Right(n NodeID) NodeID {
+	if !t.Valid(n) {
+		return Nil
+	}
+
+	return t.nodes[n].child[right]
+}
+
+// Parent returns the parent of the node n or Nil if n is the root.
+func (t *ArenaRBTree) Parent(n NodeID) NodeID {
+	if !t.Valid(n) {
+		return Nil
+	}
+
+	return t.nodes[n].parent
+}
+
+// alloc takes a node from the free list or appends new one to the arena
+// and returns the handle of the red node with key k and the data
+func (t *ArenaRBTree) alloc(k KeyType, data any) NodeID {
+	n := t.free
+	if n != Nil {
+		// Recycle the free node
+		t.free = t.nodes[n].child[right]
+	} else {
+		if len(t.nodes) > MaxNodes {
+			panic(fmt.Sprintf("Too many nodes in the tree, maximum - %d", MaxNodes))
+		}
+
+		t.nodes = append(t.nodes, node{})
+		n = NodeID(len(t.nodes) - 1)
+	}
+
+	t.nodes[n] = node{key: k, color: Red, used: true, data: data}
+
+	return n
+}
+
+// release puts the node n to the free list
+func (t *ArenaRBTree) release(n NodeID) {
+	// Clean up the node to release the data associated with it
+	t.nodes[n] = node{}
+	t.nodes[n].child[right] = t.free
+	t.free = n
+}
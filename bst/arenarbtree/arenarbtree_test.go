@@ -0,0 +1,389 @@
+package arenarbtree
+
+import (
+	"testing"
+	"math/rand"
+	"reflect"
+	"sort"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Trees are compared with RBTree by their renders, so the number of keys is moderate
+	keysCount	=	4096
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2043
+
+	// Run self-test after each selfTestStep modifications
+	selfTestStep	=	64
+)
+
+func newTreeSortedKeys(keys []KeyType) (*ArenaRBTree, []KeyType) {
+	tree := NewArenaRBTree(len(keys))
+
+	// Insert all keys
+	for _, k := range keys {
+		tree.Insert(k, int(k))
+	}
+
+	// Make sorted copy of keys
+	sKeys := make([]KeyType, len(keys))
+	copy(sKeys, keys)
+	sort.Slice(sKeys, func(i, j int) bool { return sKeys[i] < sKeys[j] } )
+
+	return tree, sKeys
+}
+
+// treeKeys returns keys of the tree in ascending order
+func treeKeys(tree *ArenaRBTree) []KeyType {
+	keys := []KeyType{}
+	for n := tree.Min(); n != Nil; n = tree.Successor(n) {
+		keys = append(keys, tree.Key(n))
+	}
+
+	return keys
+}
+
+func TestEmpty(t *testing.T) {
+	tree := NewArenaRBTree(0)
+
+	for name, n := range map[string]NodeID{"Root": tree.Root(), "Min": tree.Min(), "Max": tree.Max(), "Search": tree.Search(1)} {
+		if n != Nil {
+			t.Errorf("%s returned non-Nil value %d on empty tree", name, n)
+		}
+	}
+
+	if h, err := tree.SelfTest(); h != 0 || err != nil {
+		t.Errorf("SelfTest returned %d, %v on empty tree, want - 0, nil", h, err)
+	}
+
+	if s, want := tree.String(), rbtree.NewRBTree().String(); s != want {
+		t.Errorf("String returned %q on empty tree, want - %q", s, want)
+	}
+}
+
+func TestInvalidNodes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, selfTestStep, KeyType(MaxItem))
+
+	tree, _ := newTreeSortedKeys(testKeys)
+
+	for _, n := range []NodeID{Nil, -1, NodeID(selfTestStep + 1), NodeID(MaxNodes)} {
+		if tree.Valid(n) {
+			t.Errorf("Valid(%d) returned true", n)
+		}
+		if k, v, c := tree.Key(n), tree.Value(n), tree.Color(n); k != FakeNode || v != nil || c != Black {
+			t.Errorf("node %d: Key, Value, Color returned %v, %v, %v, want - %v, nil, Black", n, k, v, c, FakeNode)
+		}
+		if l, r, p, s, pr := tree.Left(n), tree.Right(n), tree.Parent(n), tree.Successor(n), tree.Predecessor(n);
+			l != Nil || r != Nil || p != Nil || s != Nil || pr != Nil {
+			t.Errorf("node %d has relatives: %d, %d, %d, %d, %d", n, l, r, p, s, pr)
+		}
+		if tree.SetValue(n, 1) || tree.Delete(n) {
+			t.Errorf("SetValue or Delete returned true on not valid node %d", n)
+		}
+	}
+
+	// Deleted node becomes invalid
+	n := tree.Search(testKeys[0])
+	tree.Delete(n)
+	if tree.Valid(n) || tree.Delete(n) {
+		t.Errorf("deleted node %d is still valid", n)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := NewArenaRBTree(0)
+
+	for i, k := range testKeys {
+		n := tree.Insert(k, int(k))
+		if n == Nil || tree.Key(n) != k || tree.Value(n) != int(k) {
+			t.Errorf("[%d] Insert(%v) returned %d with key %v and value %v", i, k, n, tree.Key(n), tree.Value(n))
+			t.FailNow()
+		}
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%d] SelfTest failed after insertion of %v: %v", i, k, err)
+			t.FailNow()
+		}
+	}
+
+	if tree.Len() != len(testKeys) || tree.Allocated() != len(testKeys) {
+		t.Errorf("Len and Allocated returned %d, %d, want - %d", tree.Len(), tree.Allocated(), len(testKeys))
+	}
+
+	// Duplicates are not inserted
+	for i, k := range testKeys[:selfTestStep] {
+		if n := tree.Insert(k, nil); n != Nil {
+			t.Errorf("[%d] Insert returned %d, want - Nil, because node with key %v should be already inserted", i, n, k)
+		}
+	}
+}
+
+func TestSameAsRBTree(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, _ := newTreeSortedKeys(testKeys)
+
+	rbt := rbtree.NewRBTree()
+	for _, k := range testKeys {
+		rbt.Insert(rbtree.NewRBNode(k, int(k)))
+	}
+
+	// Insertion produces exactly the same tree
+	if s, want := tree.String(), rbt.String(); s != want {
+		t.Errorf("String of the tree differs from the red-black tree with the same keys")
+	}
+
+	h, err := tree.SelfTest()
+	if want, _ := rbt.SelfTest(); h != want || err != nil {
+		t.Errorf("SelfTest returned %d, %v, want - %d, nil", h, err, want)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newTreeSortedKeys(testKeys)
+
+	for i, k := range testKeys {
+		if n := tree.Search(k); tree.Key(n) != k {
+			t.Errorf("[%d] Search(%v) returned node %d with key %v", i, k, n, tree.Key(n))
+			t.FailNow()
+		}
+	}
+
+	if n := tree.Search(MaxItem + 1); n != Nil {
+		t.Errorf("Search of absent key returned %d", n)
+	}
+
+	// Walk in both directions
+	if keys := treeKeys(tree); !reflect.DeepEqual(keys, sKeys) {
+		t.Errorf("ascending walk returned %d keys, want - %d", len(keys), len(sKeys))
+	}
+
+	i := len(sKeys) - 1
+	for n := tree.Max(); n != Nil; n = tree.Predecessor(n) {
+		if tree.Key(n) != sKeys[i] {
+			t.Errorf("[%d] descending walk returned key %v, want - %v", i, tree.Key(n), sKeys[i])
+			t.FailNow()
+		}
+		i--
+	}
+}
+
+func TestDelRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newTreeSortedKeys(testKeys)
+
+	// Keep handles to check that they are not affected by deletion of other nodes
+	handles := map[KeyType]NodeID{}
+	for _, k := range testKeys {
+		handles[k] = tree.Search(k)
+	}
+
+	for i := 0; len(sKeys) != 0; i++ {
+		// Get the random element from the sKeys
+		idx := rnd.Int() % len(sKeys)
+		k := sKeys[idx]
+		// Remove k from keys slice
+		sKeys = append(sKeys[:idx], sKeys[idx+1:]...)
+
+		if !tree.Delete(handles[k]) {
+			t.Errorf("[%d] Delete of the key %v returned false", i, k)
+			t.FailNow()
+		}
+		delete(handles, k)
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%d] SelfTest failed after deletion of %v: %v", i, k, err)
+			t.FailNow()
+		}
+		for hk, n := range handles {
+			if tree.Key(n) != hk || tree.Value(n) != int(hk) {
+				t.Errorf("[%d] node %d has key %v and value %v, want - %v", i, n, tree.Key(n), tree.Value(n), hk)
+				t.FailNow()
+			}
+		}
+	}
+
+	// Tree now must be empty
+	if root := tree.Root(); root != Nil || tree.Len() != 0 {
+		t.Errorf("tree must be empty, but root is - %d, Len - %d", root, tree.Len())
+	}
+}
+
+func TestDeleteByKey(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newTreeSortedKeys(testKeys)
+
+	for i := 0; len(sKeys) != 0; i++ {
+		var k KeyType
+		var v any
+		var ok bool
+
+		// Delete minimal, maximal and random keys in turn
+		switch i % 3 {
+		case 0:
+			k, v, ok = tree.DeleteMin()
+			if want := sKeys[0]; !ok || k != want || v != int(want) {
+				t.Fatalf("[%d] DeleteMin returned %v, %v, %t, want - %v, %v, true", i, k, v, ok, want, want)
+			}
+			sKeys = sKeys[1:]
+		case 1:
+			k, v, ok = tree.DeleteMax()
+			if want := sKeys[len(sKeys)-1]; !ok || k != want || v != int(want) {
+				t.Fatalf("[%d] DeleteMax returned %v, %v, %t, want - %v, %v, true", i, k, v, ok, want, want)
+			}
+			sKeys = sKeys[:len(sKeys)-1]
+		default:
+			idx := rnd.Intn(len(sKeys))
+			k = sKeys[idx]
+			if !tree.DeleteKey(k) || tree.DeleteKey(k) {
+				t.Fatalf("[%d] DeleteKey(%v) did not delete the existing key exactly once", i, k)
+			}
+			sKeys = append(sKeys[:idx], sKeys[idx+1:]...)
+		}
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+		if _, err := tree.SelfTest(); err != nil {
+			t.Fatalf("[%d] SelfTest failed after deletion of %v: %v", i, k, err)
+		}
+		if keys := treeKeys(tree); !reflect.DeepEqual(keys, sKeys) {
+			t.Fatalf("[%d] tree has keys %v, want - %v", i, keys, sKeys)
+		}
+	}
+
+	// Tree now must be empty
+	if k, v, ok := tree.DeleteMin(); ok || k != FakeNode || v != nil || tree.Len() != 0 {
+		t.Errorf("DeleteMin returned %v, %v, %t on empty tree, want - %v, nil, false", k, v, ok, FakeNode)
+	}
+	if k, v, ok := tree.DeleteMax(); ok || k != FakeNode || v != nil {
+		t.Errorf("DeleteMax returned %v, %v, %t on empty tree, want - %v, nil, false", k, v, ok, FakeNode)
+	}
+}
+
+func TestRecycle(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, _ := newTreeSortedKeys(testKeys)
+
+	// Delete first half of keys and insert new keys instead of them
+	half := len(testKeys) / 2
+	for _, k := range testKeys[:half] {
+		tree.Delete(tree.Search(k))
+	}
+	for _, k := range testKeys[:half] {
+		tree.Insert(k + MaxItem + 1, nil)
+	}
+
+	if _, err := tree.SelfTest(); err != nil {
+		t.Errorf("SelfTest failed: %v", err)
+	}
+	if tree.Len() != len(testKeys) || tree.Allocated() != len(testKeys) {
+		t.Errorf("Len and Allocated returned %d, %d, want - %d", tree.Len(), tree.Allocated(), len(testKeys))
+	}
+
+	// Clear keeps the memory
+	tree.Clear()
+	if tree.Len() != 0 || tree.Root() != Nil || tree.Allocated() != 0 || cap(tree.nodes) < len(testKeys) {
+		t.Errorf("Clear returned the tree with Len %d, root %d, %d allocated nodes and capacity %d",
+			tree.Len(), tree.Root(), tree.Allocated(), cap(tree.nodes))
+	}
+
+	tree.Insert(1, nil)
+	if _, err := tree.SelfTest(); err != nil || tree.Len() != 1 {
+		t.Errorf("SelfTest failed after Clear: %v", err)
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, _ := newTreeSortedKeys(testKeys)
+
+	for n := tree.Min(); n != Nil; n = tree.Successor(n) {
+		tree.SetValue(n, -int(tree.Key(n)))
+	}
+
+	for i, k := range testKeys {
+		if v := tree.Value(tree.Search(k)); v != -int(k) {
+			t.Errorf("[%d] key %v has value %v, want - %d", i, k, v, -int(k))
+			t.FailNow()
+		}
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, selfTestStep, KeyType(MaxItem))
+
+	for i, test := range []struct {
+		breaker	func(tree *ArenaRBTree)
+		want	string
+	} {
+		{
+			func(tree *ArenaRBTree) { tree.nodes[tree.root].color = Red },
+			"v#5",
+		}, {
+			func(tree *ArenaRBTree) { tree.nodes[tree.Max()].color = !tree.nodes[tree.Max()].color },
+			"v#",
+		}, {
+			func(tree *ArenaRBTree) { tree.nodes[tree.Min()].parent = tree.Max() },
+			"v#1",
+		}, {
+			func(tree *ArenaRBTree) { tree.size++ },
+			"v#2",
+		}, {
+			func(tree *ArenaRBTree) { tree.free = tree.root },
+			"v#2",
+		}, {
+			func(tree *ArenaRBTree) {
+				// Make red-violation by repainting the red node's parent
+				for n := tree.Min(); n != Nil; n = tree.Successor(n) {
+					if p := tree.Parent(n); tree.Color(n) == Red && p != tree.root {
+						tree.nodes[p].color = Red
+						return
+					}
+				}
+				panic("No red nodes were found")
+			},
+			"v#3",
+		},
+	} {
+		tree, _ := newTreeSortedKeys(testKeys)
+		test.breaker(tree)
+
+		h, err := tree.SelfTest()
+		if err == nil || h != 0 {
+			t.Errorf("[%d] SelfTest returned %d, %v on broken tree, want - 0, %s error", i, h, err, test.want)
+			continue
+		}
+
+		if msg := err.Error(); len(msg) < len(test.want) || msg[:len(test.want)] != test.want {
+			t.Errorf("[%d] SelfTest returned error %q, want - %s error", i, msg, test.want)
+		}
+	}
+}
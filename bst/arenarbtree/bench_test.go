@@ -0,0 +1,161 @@
+package arenarbtree
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+// Number of nodes in trees used to measure the garbage collection time
+const gcNodes = 1 << 18
+
+func newRBTree(keys []KeyType) *rbtree.RBTree {
+	tree := rbtree.NewRBTree()
+	for _, k := range keys {
+		tree.Insert(rbtree.NewRBNode(k, nil))
+	}
+
+	return tree
+}
+
+func newArenaTree(keys []KeyType) *ArenaRBTree {
+	tree := NewArenaRBTree(0)
+	for _, k := range keys {
+		tree.Insert(k, nil)
+	}
+
+	return tree
+}
+
+// gcKeys returns gcNodes unique keys in random order generated by rnd
+func gcKeys(rnd *rand.Rand) []KeyType {
+	keys := make([]KeyType, gcNodes)
+	for i, k := range rnd.Perm(gcNodes) {
+		keys[i] = KeyType(k)
+	}
+
+	return keys
+}
+
+func BenchmarkInsert(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	b.Run("Arena", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			newArenaTree(testKeys)
+		}
+	})
+
+	b.Run("ArenaPrealloc", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			tree := NewArenaRBTree(len(testKeys))
+			for _, k := range testKeys {
+				tree.Insert(k, nil)
+			}
+		}
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			newRBTree(testKeys)
+		}
+	})
+}
+
+func BenchmarkSearch(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	b.Run("Arena", func(b *testing.B) {
+		tree := newArenaTree(testKeys)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			for _, k := range testKeys {
+				tree.Search(k)
+			}
+		}
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		tree := newRBTree(testKeys)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			for _, k := range testKeys {
+				tree.Search(k)
+			}
+		}
+	})
+}
+
+// BenchmarkChurn deletes and inserts again the same keys, the arena recycles deleted nodes
+func BenchmarkChurn(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	b.Run("Arena", func(b *testing.B) {
+		tree := newArenaTree(testKeys)
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			k := testKeys[n % len(testKeys)]
+			tree.Delete(tree.Search(k))
+			tree.Insert(k, nil)
+		}
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		tree := newRBTree(testKeys)
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			k := testKeys[n % len(testKeys)]
+			tree.Delete(tree.Search(k))
+			tree.Insert(rbtree.NewRBNode(k, nil))
+		}
+	})
+}
+
+// BenchmarkGC measures the time of the full garbage collection with the live tree of gcNodes nodes
+func BenchmarkGC(b *testing.B) {
+	keys := gcKeys(rand.New(rand.NewSource(testSeed)))	//nolint:gosec // Reproducible sequence is required
+
+	run := func(b *testing.B, tree any) {
+		b.Helper()
+
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			runtime.GC()
+		}
+
+		b.StopTimer()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.PauseTotalNs - before.PauseTotalNs) / float64(b.N), "pause-ns/op")
+		b.ReportMetric(float64(after.HeapObjects), "heap-objects")
+
+		// The tree must be alive during the collection
+		runtime.KeepAlive(tree)
+	}
+
+	b.Run("Arena", func(b *testing.B) {
+		run(b, newArenaTree(keys))
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		run(b, newRBTree(keys))
+	})
+}
@@ -0,0 +1,136 @@
+package arenarbtree
+
+// Search returns a tree node with key k or Nil if there is no such node.
+func (t *ArenaRBTree) Search(k KeyType) NodeID {
+	n, _ := t.SearchWithParent(k)
+
+	return n
+}
+
+// SearchWithParent returns as the first value a node with k if found or Nil if not found,
+// as the second - parent of the found node even if the node was not found.
+func (t *ArenaRBTree) SearchWithParent(k KeyType) (NodeID, NodeID) {
+	n, p := t.root, Nil
+	for n != Nil && t.nodes[n].key != k {
+		p = n
+		if k < t.nodes[n].key {
+			n = t.nodes[n].child[left]
+		} else {
+			n = t.nodes[n].child[right]
+		}
+	}
+
+	return n, p
+}
+
+// Root returns the root node of the tree, or Nil if the tree is empty.
+func (t *ArenaRBTree) Root() NodeID {
+	return t.root
+}
+
+// Min returns the tree node with the minimum key value.
+func (t *ArenaRBTree) Min() NodeID {
+	return t.edge(t.root, left)
+}
+
+// Max returns the tree node with the maximum key value.
+func (t *ArenaRBTree) Max() NodeID {
+	return t.edge(t.root, right)
+}
+
+// Successor returns the tree node following node n in a linear ordering of
+// tree nodes in ascending order of their keys. If there is none, i.e. n has a
+// maximal key value or n is not valid, then Nil is returned.
+func (t *ArenaRBTree) Successor(n NodeID) NodeID {
+	return t.next(n, right)
+}
+
+// Predecessor returns the tree node following node n in a linear ordering of
+// tree nodes in descending order of their keys. If there is none, i.e. n has a
+// minimum key value or n is not valid, then Nil is returned.
+func (t *ArenaRBTree) Predecessor(n NodeID) NodeID {
+	return t.next(n, left)
+}
+
+// edge returns the last node of the subtree n going to the side d, i.e.
+// the minimum of the subtree if d is left and the maximum if d is right
+func (t *ArenaRBTree) edge(n NodeID, d int) NodeID {
+	if n == Nil {
+		return Nil
+	}
+
+	for t.nodes[n].child[d] != Nil {
+		n = t.nodes[n].child[d]
+	}
+
+	return n
+}
+
+// next returns the successor of n if d is right or the predecessor if d is left
+func (t *ArenaRBTree) next(n NodeID, d int) NodeID {
+	if !t.Valid(n) {
+		return Nil
+	}
+
+	// If node has sub-tree on the side d, need to return its opposite edge
+	if c := t.nodes[n].child[d]; c != Nil {
+		return t.edge(c, 1 - d)
+	}
+
+	// Need to go up until find parent for which n is the child on the opposite side
+	p := t.nodes[n].parent
+	for p != Nil && n == t.nodes[p].child[d] {
+		n = p
+		p = t.nodes[p].parent
+	}
+
+	return p
+}
+
+// side returns the side of the node n in its parent, n must not be the root
+func (t *ArenaRBTree) side(n NodeID) int {
+	if t.nodes[t.nodes[n].parent].child[left] == n {
+		return left
+	}
+
+	return right
+}
+
+// transplant replaces the subtree with root u by the subtree with root v
+func (t *ArenaRBTree) transplant(u, v NodeID) {
+	p := t.nodes[u].parent
+	if p == Nil {
+		t.root = v
+	} else {
+		t.nodes[p].child[t.side(u)] = v
+	}
+
+	// Parent is assigned to the sentinel too, it is used by the deletion fixup
+	t.nodes[v].parent = p
+}
+
+// rotate rotates the subtree with root x to the side d, the child of x on
+// the opposite side becomes the root of the subtree
+func (t *ArenaRBTree) rotate(x NodeID, d int) {
+	nodes := t.nodes
+	y := nodes[x].child[1 - d]
+
+	// Attach the inner child of y to x
+	nodes[x].child[1 - d] = nodes[y].child[d]
+	if c := nodes[y].child[d]; c != Nil {
+		nodes[c].parent = x
+	}
+
+	// Replace x by y in the parent of x
+	p := nodes[x].parent
+	if p == Nil {
+		t.root = y
+	} else {
+		nodes[p].child[t.side(x)] = y
+	}
+	nodes[y].parent = p
+
+	// Make x the child of y
+	nodes[y].child[d] = x
+	nodes[x].parent = y
+}
@@ -0,0 +1,52 @@
+package arenarbtree
+
+import "fmt"
+
+//nolint:testableexamples
+func Example_treeCreation() {
+	// Create tree with the arena for 15 nodes
+	tree := NewArenaRBTree(15)
+
+	// Insert keys and data
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	// Print graphical representation of the tree
+	fmt.Print(tree)
+}
+
+func Example_treeRecycling() {
+	// Tree creation
+	tree := NewArenaRBTree(0)
+	keys := []KeyType{20, 10, 30, 5, 15, 25, 35}
+	handles := map[KeyType]NodeID{}
+	for _, k := range keys {
+		handles[k] = tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	// Delete some nodes, handles of other nodes stay valid
+	tree.Delete(handles[10])
+	tree.Delete(tree.Search(30))
+	fmt.Println("Value of 25:", tree.Value(handles[25]))
+	fmt.Println("Nodes:", tree.Len(), "allocated:", tree.Allocated())
+
+	// New nodes reuse the nodes deleted before
+	tree.Insert(40, nil)
+	tree.Insert(50, nil)
+	tree.Insert(60, nil)
+	fmt.Println("Nodes:", tree.Len(), "allocated:", tree.Allocated())
+
+	// Walk the tree
+	fmt.Print("Keys:")
+	for n := tree.Min(); n != Nil; n = tree.Successor(n) {
+		fmt.Print(" ", tree.Key(n))
+	}
+	fmt.Println()
+
+	// Output:
+	// Value of 25: Value for key 25
+	// Nodes: 5 allocated: 7
+	// Nodes: 8 allocated: 8
+	// Keys: 5 15 20 25 35 40 50 60
+}
@@ -0,0 +1,214 @@
+package arenarbtree
+
+// Insert inserts a node with key k and associated data into the tree keeping the properties
+// of the Red-Black tree. It returns the handle of the inserted node or Nil if the node with
+// key k already exists.
+func (t *ArenaRBTree) Insert(k KeyType, data any) NodeID {
+	// Search node with key k in the tree
+	N, p := t.SearchWithParent(k)
+	if N != Nil {
+		// Already exists, no insertion or fixup required
+		return Nil
+	}
+
+	// The arena can be reallocated here, so no references to nodes are kept
+	n := t.alloc(k, data)
+	t.size++
+
+	// Assign correct parent of the new node
+	t.nodes[n].parent = p
+	switch {
+	case p == Nil:
+		// Make the node a root of the tree
+		t.root = n
+	case k < t.nodes[p].key:
+		// Assign new node as left child
+		t.nodes[p].child[left] = n
+	default:
+		// Assign new node as right child
+		t.nodes[p].child[right] = n
+	}
+
+	t.fixupIns(n)
+
+	return n
+}
+
+// Delete deletes the node n from the tree keeping the properties of the Red-Black tree.
+// It returns false if n is not valid. The handle n becomes invalid and can be reused.
+func (t *ArenaRBTree) Delete(n NodeID) bool {
+	if !t.Valid(n) {
+		return false
+	}
+
+	nodes := t.nodes
+
+	// y - node that is removed from its position, x - node that takes the position of y
+	y, yColor := n, nodes[n].color
+	var x NodeID
+
+	switch {
+	// Node has no left child - replace it by the right child
+	case nodes[n].child[left] == Nil:
+		x = nodes[n].child[right]
+		t.transplant(n, x)
+
+	// Node has no right child - replace it by the left child
+	case nodes[n].child[right] == Nil:
+		x = nodes[n].child[left]
+		t.transplant(n, x)
+
+	// Node has TWO children - replace it by the successor
+	default:
+		y = t.edge(nodes[n].child[right], left)
+		yColor = nodes[y].color
+		x = nodes[y].child[right]
+
+		if nodes[y].parent == n {
+			// Parent is assigned to the sentinel too, it is used by the fixup
+			nodes[x].parent = y
+		} else {
+			// Extract the successor, it does not have left child
+			t.transplant(y, x)
+			nodes[y].child[right] = nodes[n].child[right]
+			nodes[nodes[y].child[right]].parent = y
+		}
+
+		// Put the successor to the place of n
+		t.transplant(n, y)
+		nodes[y].child[left] = nodes[n].child[left]
+		nodes[nodes[y].child[left]].parent = y
+		nodes[y].color = nodes[n].color
+	}
+
+	if yColor == Black {
+		// Black-height of the subtree of x was decreased
+		t.fixupDel(x)
+	}
+
+	// Clean up the parent of the sentinel
+	nodes[Nil].parent = Nil
+
+	t.release(n)
+	t.size--
+
+	return true
+}
+
+// DeleteKey deletes the node with key k, it returns false if there is no such node.
+func (t *ArenaRBTree) DeleteKey(k KeyType) bool {
+	return t.Delete(t.Search(k))
+}
+
+// DeleteMin deletes the node with the minimal key and returns its key, data and true,
+// or FakeNode, nil and false if the tree is empty.
+func (t *ArenaRBTree) DeleteMin() (KeyType, any, bool) {
+	return t.deleteNode(t.Min())
+}
+
+// DeleteMax deletes the node with the maximal key and returns its key, data and true,
+// or FakeNode, nil and false if the tree is empty.
+func (t *ArenaRBTree) DeleteMax() (KeyType, any, bool) {
+	return t.deleteNode(t.Max())
+}
+
+// deleteNode deletes the node n if it is valid and returns its key and data
+func (t *ArenaRBTree) deleteNode(n NodeID) (KeyType, any, bool) {
+	if !t.Valid(n) {
+		return FakeNode, nil, false
+	}
+
+	// Save the content, the node is released by the deletion
+	k, data := t.nodes[n].key, t.nodes[n].data
+	t.Delete(n)
+
+	return k, data, true
+}
+
+func (t *ArenaRBTree) fixupIns(n NodeID) {	//nolint:varnamelen	// variable name too obvious to make it longer
+	nodes := t.nodes
+
+	//nolint:varnamelen	// variable names markings too obvious to make them longer
+	// Repeat while there is a red-violation - red node attached to red parent
+	for n != t.root && nodes[nodes[n].parent].color == Red {
+		// Get n's relatedness - father, grandfather and uncle
+		f := nodes[n].parent
+		g := nodes[f].parent
+		d := t.side(f)
+		u := nodes[g].child[1 - d]
+
+		// Red uncle - only a repaint is required
+		if nodes[u].color == Red {
+			nodes[f].color, nodes[u].color, nodes[g].color = Black, Black, Red
+
+			// Do fixup again, use g as new initiator of red-violation
+			n = g
+
+			continue
+		}
+
+		// Black uncle and n->f->g is angle - rotate f to make a straight line
+		if n == nodes[f].child[1 - d] {
+			n, f = f, n
+			t.rotate(n, d)
+		}
+
+		// Black uncle and n->f->g is a straight line - rotate g around f
+		nodes[f].color, nodes[g].color = Black, Red
+		t.rotate(g, 1 - d)
+
+		// No more fixups required
+		break
+	}
+
+	// Root always black
+	nodes[t.root].color = Black
+}
+
+func (t *ArenaRBTree) fixupDel(x NodeID) {	//nolint:varnamelen	// variable name too obvious to make it longer
+	nodes := t.nodes
+
+	//nolint:varnamelen	// variable names markings too obvious to make them longer
+	// x carries an extra black, repeat while it cannot be absorbed by repainting x to black
+	for x != t.root && nodes[x].color == Black {
+		// Get x's participants - father and brother, brother is never a leaf here
+		f := nodes[x].parent
+		d := left
+		if nodes[f].child[left] != x {
+			d = right
+		}
+		b := nodes[f].child[1 - d]
+
+		// Red brother - rotate f to get a black brother
+		if nodes[b].color == Red {
+			nodes[b].color, nodes[f].color = Black, Red
+			t.rotate(f, d)
+			b = nodes[f].child[1 - d]
+		}
+
+		// Black brother with black children - repaint the brother and move the extra black up
+		cn, cf := nodes[b].child[d], nodes[b].child[1 - d]	// nearside and far side children of b
+		if nodes[cn].color == Black && nodes[cf].color == Black {
+			nodes[b].color = Red
+			x = f
+
+			continue
+		}
+
+		// Black brother with black far side child - rotate b to get a red far side child
+		if nodes[cf].color == Black {
+			nodes[cn].color, nodes[b].color = Black, Red
+			t.rotate(b, 1 - d)
+			b = nodes[f].child[1 - d]
+		}
+
+		// Black brother with red far side child - rotate f, the extra black is absorbed
+		nodes[b].color, nodes[f].color = nodes[f].color, Black
+		nodes[nodes[b].child[1 - d]].color = Black
+		t.rotate(f, d)
+
+		x = t.root
+	}
+
+	nodes[x].color = Black
+}
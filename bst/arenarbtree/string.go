@@ -0,0 +1,31 @@
+package arenarbtree
+
+import "github.com/r-che/algorithms/bst/rbtree"
+
+// ToRBTree converts the tree to the red-black tree with the same structure and colors of
+// nodes. Keys and associated data are copied, t is not modified.
+func (t *ArenaRBTree) ToRBTree() *rbtree.RBTree {
+	if t.root == Nil {
+		return rbtree.NewRBTree()
+	}
+
+	return rbtree.NewRBTreeFromRoot(t.toRBNode(t.root))
+}
+
+// toRBNode converts the subtree with root n
+func (t *ArenaRBTree) toRBNode(n NodeID) *rbtree.RBNode {
+	if n == Nil {
+		return nil
+	}
+
+	nd := &t.nodes[n]
+	rbn := rbtree.NewRBNode(nd.key, nd.data)
+	rbn.SetColor(nd.color)
+	rbn.SetChildren(t.toRBNode(nd.child[left]), t.toRBNode(nd.child[right]))
+
+	return rbn
+}
+
+func (t *ArenaRBTree) String() string {
+	return t.ToRBTree().String()
+}
@@ -0,0 +1,91 @@
+package arenarbtree
+
+import "fmt"
+
+// SelfTest performs a self-test of the red-black tree and returns the black-height,
+// and a description of the problem if detected. If an issuse is detected, the
+// black-height is zero.
+func (t *ArenaRBTree) SelfTest() (int, error) {
+	if t.Color(t.root) != Black {
+		return 0, fmt.Errorf("v#5: tree root (%d) is NOT black", t.root)
+	}
+
+	if t.root != Nil && t.nodes[t.root].parent != Nil {
+		return 0, fmt.Errorf("v#1: tree root (%d) has parent %d", t.root, t.nodes[t.root].parent)
+	}
+
+	bh, count, err := t.test(t.root)
+	if err != nil {
+		return 0, err
+	}
+
+	// Count free nodes
+	free := 0
+	for n := t.free; n != Nil; n = t.nodes[n].child[right] {
+		if t.nodes[n].used {
+			return 0, fmt.Errorf("v#2: node %d in the free list is used", n)
+		}
+		if free++; free > t.Allocated() {
+			return 0, fmt.Errorf("v#2: free list contains a cycle")
+		}
+	}
+
+	if count != t.size || count + free != t.Allocated() {
+		return 0, fmt.Errorf("v#2: tree contains %d nodes, %d free nodes, want - %d nodes (Len), %d allocated nodes",
+			count, free, t.size, t.Allocated())
+	}
+
+	return bh, nil
+}
+
+// test checks the subtree with root n and returns its black-height and the number of nodes
+func (t *ArenaRBTree) test(n NodeID) (int, int, error) {
+	// No errors on empty sub-tree
+	if n == Nil {
+		return 0, 0, nil
+	}
+
+	if !t.Valid(n) {
+		return 0, 0, fmt.Errorf("v#1: node %d is not valid", n)
+	}
+
+	nd := &t.nodes[n]
+
+	// Check links of children to n
+	for _, c := range nd.child {
+		if c != Nil && int(c) < len(t.nodes) && t.nodes[c].parent != n {
+			return 0, 0, fmt.Errorf("v#1: child %d of node %d has parent %d", c, n, t.nodes[c].parent)
+		}
+	}
+
+	bhl, cl, err := t.test(nd.child[left])	// bhl - black height left
+	if err != nil {
+		return 0, 0, err
+	}
+
+	bhr, cr, err := t.test(nd.child[right])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Test inequality of black heights of subtrees
+	if bhl != bhr {
+		return 0, 0, fmt.Errorf(
+			"v#4: node %d - black-height left (%d) is not equal black height-right (%d)",
+			n, bhl, bhr)
+	}
+
+	// Test current node color
+	if nd.color == Black {
+		bhl++
+	} else
+	// Red node, need to check children colors - both must be Black
+	if t.Color(nd.child[left]) != Black || t.Color(nd.child[right]) != Black {
+		return 0, 0, fmt.Errorf(
+			"v#3: Red node (%d) has non-Black child (left: %d, right: %d)",
+			n, nd.child[left], nd.child[right])
+	}
+
+	// OK
+	return bhl, cl + cr + 1, nil
+}
@@ -0,0 +1,204 @@
+/*
+Package scenario provides the replay of scenarios for tests of trees of the parent
+directory bst.
+
+Scenarios are shared by all trees and are kept in the Dir, golden outputs of each tree
+are kept in the GoldenDir of the package of the tree.
+
+A scenario is a text file with one operation per line, empty lines and lines starting
+with # are ignored. An operation can be prefixed by the name of a tree followed by a
+colon, e.g. "rb:" or "nb:", to apply it only to the red-black or only to the non-balanced
+tree. Operations:
+
+	insert k [k ...]		insert keys, keys must be absent
+	delete k [k ...]		delete keys, keys must exist
+	keys [k ...]			the tree must contain exactly these keys
+	check				check invariants of the tree
+	stats field=value [...]		fields of statistics of the tree must have these values,
+					e.g. nodes, height, min-leaf-depth, black-height
+	render				add the render of the tree to the golden output
+
+Operations except render are applied by the tree specific function, the golden output
+of the scenario is compared with the file from GoldenDir with the name of the scenario
+and the .golden extension, run tests with -update to rewrite it.
+*/
+package scenario
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const (
+	// Dir is the directory of scenarios relative to the directory of a tree package
+	Dir			=	"../testdata/scenarios"
+	// GoldenDir is the directory of golden outputs in the directory of a tree package
+	GoldenDir	=	"testdata/scenarios"
+
+	scenarioExt	=	".scn"
+	goldenExt	=	".golden"
+)
+
+//nolint:gochecknoglobals // Flags of the test
+var update = flag.Bool("update", false, "update golden outputs of scenarios")
+
+// Op is an operation of the scenario
+type Op struct {
+	// Line number in the scenario file
+	Line	int
+	Name	string
+	Args	[]string
+}
+
+// Tree applies operations to the tree
+type Tree interface {
+	// Step applies the operation op except render to the tree
+	Step(op Op) error
+	// String returns the render of the tree
+	String() string
+}
+
+// Load reads operations of the tree with the name from the scenario file, operations
+// prefixed by names of other trees are skipped
+func Load(file, name string) ([]Op, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ops []Op
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		// Check the tree prefix of the operation
+		if prefix, op, ok := strings.Cut(fields[0], ":"); ok {
+			if prefix != name {
+				// Operation of another tree
+				continue
+			}
+			if fields[0] = op; op == "" {
+				// Prefix is separated from the operation
+				fields = fields[1:]
+			}
+		}
+
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("line %d: no operation after the prefix", line)
+		}
+
+		ops = append(ops, Op{Line: line, Name: fields[0], Args: fields[1:]})
+	}
+
+	return ops, scanner.Err()
+}
+
+// Keys converts arguments of the operation to keys
+func Keys[K ~int](args []string) ([]K, error) {
+	keys := make([]K, 0, len(args))
+	for _, arg := range args {
+		k, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q", arg)
+		}
+		keys = append(keys, K(k))
+	}
+
+	return keys, nil
+}
+
+// CheckStats compares statistics of the tree - values of fields by names, with values from
+// arguments of the stats operation
+func CheckStats(fields map[string]int, args []string) error {
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		got, known := fields[name]
+		if !ok || !known {
+			return fmt.Errorf("invalid field %q", arg)
+		}
+
+		want, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid value of the field %q", arg)
+		}
+
+		if got != want {
+			return fmt.Errorf("%s is %d, want - %d", name, got, want)
+		}
+	}
+
+	return nil
+}
+
+// Replay applies operations to the tree and returns the golden output
+func Replay(tree Tree, ops []Op) (string, error) {
+	out := strings.Builder{}
+
+	for _, op := range ops {
+		if op.Name == "render" {
+			fmt.Fprintf(&out, "# %d: render\n%s\n", op.Line, tree)
+			continue
+		}
+
+		if err := tree.Step(op); err != nil {
+			return "", fmt.Errorf("line %d: %s: %w", op.Line, op.Name, err)
+		}
+	}
+
+	return out.String(), nil
+}
+
+// Run replays all scenarios as subtests of t for new trees of the tree with the name created by
+// newTree, and compares outputs with golden files, golden files are rewritten if -update is set
+func Run(t *testing.T, name string, newTree func() Tree) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(Dir, "*" + scenarioExt))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no scenarios found in %s: %v", Dir, err)
+	}
+
+	for _, file := range files {
+		scn := strings.TrimSuffix(filepath.Base(file), scenarioExt)
+		golden := filepath.Join(GoldenDir, scn + goldenExt)
+
+		t.Run(scn, func(t *testing.T) {
+			ops, err := Load(file, name)
+			if err != nil {
+				t.Fatalf("cannot load scenario: %v", err)
+			}
+
+			out, err := Replay(newTree(), ops)
+			if err != nil {
+				t.Fatalf("%s:%v", file, err)
+			}
+
+			if *update {
+				if err := os.WriteFile(golden, []byte(out), 0o644); err != nil {	//nolint:gosec // Not a secret
+					t.Fatalf("cannot update golden output: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("cannot read golden output, use -update to create it: %v", err)
+			}
+
+			if out != string(want) {
+				t.Errorf("scenario output differs from %s:\n---\n%s\n---\nWant:\n---\n%s\n---\n",
+					golden, out, want)
+			}
+		})
+	}
+}
@@ -6,6 +6,18 @@ It supports standard tree procedures, such as: inserting and deleting nodes,
 finding nodes by given arbitrary key, finding the root, maximum and minimum
 nodes, finding the predecessor and successor of a node.
 
+The tree can work in the multiset mode created by NewMultiBSTree, in this mode
+several nodes with the same key are allowed. Nodes with equal keys are kept in
+order of their insertion, they can be counted, listed and deleted all at once or
+by the number of the occurrence.
+
+A Cursor created by BSTree.NewCursor walks the tree in both directions and allows
+changing or deleting the current node during the walk.
+
+BSTree.Stats returns the shape of the tree - the height, depths of nodes and the
+imbalance ratio, that can be used to detect degeneration of the tree, e.g. caused
+by insertion of sorted keys.
+
 It supports colored output of graphical representation of the tree using ASCII
 graphics. For example, a tree with the keys 20, 10, 30, 5, 15, 25, 35, 8, 17,
 37, 33, 13, 2, 23, 27 added sequentially will look like this:
@@ -27,6 +39,8 @@ package nbtree
 // BSTree implements a binary search tree.
 type BSTree struct {
 	root	*BSTNode
+	// Multiset mode - duplicate keys are allowed
+	multi	bool
 }
 
 // NewBSTree returns new empty binary search tree.
@@ -34,8 +48,14 @@ func NewBSTree() *BSTree {
 	return &BSTree{}
 }
 
-// Search returns a tree node with key k or nil if there is no such node.
+// Search returns a tree node with key k or nil if there is no such node. In the multiset
+// mode the first inserted node with key k is returned.
 func (t *BSTree) Search(k KeyType) *BSTNode {
+	if t.multi {
+		// Return the first occurrence of the key
+		return t.first(k)
+	}
+
 	n := t.root
 	for n != nil && n.key != k {
 		if k < n.key {
@@ -151,12 +171,19 @@ func (t *BSTree) Insert(n *BSTNode) *BSTNode { //nolint:varnamelen // n is too o
 		return n
 	}
 
-	// Search node with key k in the tree
-	N, p := t.SearchWithParent(n.key)
-	if N != nil {
-		// Already exists
-		// fmt.Printf("Node %d already exists in the tree\n", n.key)
-		return nil
+	// Search the parent of the new node
+	var p *BSTNode
+	if t.multi {
+		// Duplicates are allowed, the new node goes after all nodes with the same key
+		p = t.insertParent(n.key)
+	} else {
+		// Search node with key k in the tree
+		var N *BSTNode
+		if N, p = t.SearchWithParent(n.key); N != nil {
+			// Already exists
+			// fmt.Printf("Node %d already exists in the tree\n", n.key)
+			return nil
+		}
 	}
 
 	// Assign correct parent of the new node
@@ -0,0 +1,108 @@
+package nbtree
+
+// Cursor is a stateful pointer to a node of the tree that allows moving in both directions and
+// modifying the tree during iteration. Insertion of new nodes does not affect the cursor, but
+// deletion moves keys and data between nodes, so nodes must not be deleted bypassing the cursor,
+// including by other cursors, while it is in use.
+type Cursor struct {
+	tree	*BSTree
+	// Current node, nil if the cursor is not positioned
+	node	*BSTNode
+}
+
+// NewCursor returns new cursor positioned on the node with the minimum key, the cursor is
+// not valid if the tree is empty.
+func (t *BSTree) NewCursor() *Cursor {
+	return &Cursor{tree: t, node: t.Min()}
+}
+
+// Valid returns true if the cursor is positioned on a node of the tree.
+func (c *Cursor) Valid() bool {
+	return c.node != nil
+}
+
+// First moves the cursor to the node with the minimum key. It returns false if the tree is empty.
+func (c *Cursor) First() bool {
+	c.node = c.tree.Min()
+
+	return c.Valid()
+}
+
+// Last moves the cursor to the node with the maximum key. It returns false if the tree is empty.
+func (c *Cursor) Last() bool {
+	c.node = c.tree.Max()
+
+	return c.Valid()
+}
+
+// Seek moves the cursor to the first node with the key not less than k. It returns false and
+// the cursor becomes not valid if there is no such node.
+func (c *Cursor) Seek(k KeyType) bool {
+	c.node = c.tree.lowerBound(k)
+
+	return c.Valid()
+}
+
+// Next moves the cursor to the next node in ascending order of keys. It returns false and the
+// cursor becomes not valid if the current node has the maximum key or the cursor is not valid.
+func (c *Cursor) Next() bool {
+	if c.node != nil {
+		c.node = c.tree.Successor(c.node)
+	}
+
+	return c.Valid()
+}
+
+// Prev moves the cursor to the previous node in ascending order of keys. It returns false and the
+// cursor becomes not valid if the current node has the minimum key or the cursor is not valid.
+func (c *Cursor) Prev() bool {
+	if c.node != nil {
+		c.node = c.tree.Predecessor(c.node)
+	}
+
+	return c.Valid()
+}
+
+// Key returns the key of the current node or FakeNode if the cursor is not valid.
+func (c *Cursor) Key() KeyType {
+	return c.node.Key()
+}
+
+// Value returns the data associated with the current node or nil if the cursor is not valid.
+func (c *Cursor) Value() any {
+	return c.node.Value()
+}
+
+// SetValue associates the data with the current node. It returns false if the cursor is not valid.
+func (c *Cursor) SetValue(data any) bool {
+	if c.node == nil {
+		return false
+	}
+
+	c.node.data = data
+
+	return true
+}
+
+// Delete deletes the current node from the tree and moves the cursor to the next node in
+// ascending order of keys. It returns false if the cursor is not valid. The cursor becomes
+// not valid if the deleted node had the maximum key.
+func (c *Cursor) Delete() bool {
+	if c.node == nil {
+		return false
+	}
+
+	n := c.node
+	next := c.tree.Successor(n)
+
+	// If n has two children, its content is replaced by the content of the
+	// successor and the successor node is removed from the tree instead
+	if c.tree.Delete(n) != n {
+		// The next element is now stored in n
+		next = n
+	}
+
+	c.node = next
+
+	return true
+}
@@ -0,0 +1,117 @@
+package nbtree
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// Operations decoded from the fuzzer input
+const (
+	fuzzInsert	=	iota
+	fuzzDelete
+	fuzzSearch
+	fuzzWalk
+	fuzzOpsCount
+
+	// Number of keys in the seed corpus
+	fuzzSeedKeys	=	64
+)
+
+// fuzzModel is a reference model of the tree - sorted slice of keys
+type fuzzModel []KeyType
+
+// find returns the position of k in the model and true if k is in the model
+func (m fuzzModel) find(k KeyType) (int, bool) {
+	i := sort.Search(len(m), func(i int) bool { return m[i] >= k })
+
+	return i, i < len(m) && m[i] == k
+}
+
+// fuzzStep applies the operation op with key k to the tree and to the model and checks the result
+func fuzzStep(tree *BSTree, model fuzzModel, op byte, k KeyType) (fuzzModel, error) {
+	i, found := model.find(k)
+
+	switch op % fuzzOpsCount {
+	case fuzzInsert:
+		n := NewBSTNode(k, int(k))
+		if ins := tree.Insert(n); found && ins != nil || !found && ins != n {
+			return nil, fmt.Errorf("Insert(%v) returned %v, key exists in the model: %t", k, ins, found)
+		}
+		if !found {
+			model = append(model[:i], append(fuzzModel{k}, model[i:]...)...)
+		}
+
+	case fuzzDelete:
+		n := tree.Search(k)
+		if (n != nil) != found {
+			return nil, fmt.Errorf("Search(%v) before deletion returned %v, key exists in the model: %t", k, n, found)
+		}
+		if found {
+			if err := delWithChecks(tree, n); err != nil {
+				return nil, err
+			}
+			model = append(model[:i], model[i+1:]...)
+		}
+
+	case fuzzSearch:
+		if n := tree.Search(k); found && (n.Key() != k || n.Value() != int(k)) || !found && n != nil {
+			return nil, fmt.Errorf("Search(%v) returned %v, key exists in the model: %t", k, n, found)
+		}
+
+	case fuzzWalk:
+		// Walk in descending order, the ascending order is checked after each step
+		j := len(model)
+		for n := tree.Max(); n != nil; n = tree.Predecessor(n) {
+			if j--; j < 0 || n.Key() != model[j] {
+				return nil, fmt.Errorf("descending walk returned unexpected key %v", n.Key())
+			}
+		}
+		if j != 0 {
+			return nil, fmt.Errorf("descending walk missed %d keys", j)
+		}
+	}
+
+	// Compare the tree with the model
+	j := 0
+	for n := tree.Min(); n != nil; n = tree.Successor(n) {
+		if j >= len(model) || n.Key() != model[j] {
+			return nil, fmt.Errorf("ascending walk returned key %v, want - %v", n.Key(), model)
+		}
+		j++
+	}
+	if j != len(model) {
+		return nil, fmt.Errorf("tree contains %d keys, model - %d", j, len(model))
+	}
+
+	return model, nil
+}
+
+// FuzzTreeOps decodes the input into the sequence of operations, each operation
+// takes two bytes - the operation code and the key
+func FuzzTreeOps(f *testing.F) {
+	// Insertions in ascending and descending orders followed by deletions
+	var asc, desc []byte
+	for k := byte(0); k < fuzzSeedKeys; k++ {
+		asc = append(asc, fuzzInsert, k)
+		desc = append(desc, fuzzInsert, fuzzSeedKeys - k)
+	}
+	for k := byte(0); k < fuzzSeedKeys; k += 3 {
+		asc = append(asc, fuzzDelete, k, fuzzWalk, 0)
+		desc = append(desc, fuzzDelete, fuzzSeedKeys - k, fuzzSearch, k)
+	}
+	f.Add(asc)
+	f.Add(desc)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tree := NewBSTree()
+		model := fuzzModel{}
+
+		for i := 0; i + 1 < len(data); i += 2 {
+			var err error
+			if model, err = fuzzStep(tree, model, data[i], KeyType(data[i+1])); err != nil {
+				t.Fatalf("[%d] operation %d with key %d: %v", i / 2, data[i] % fuzzOpsCount, data[i+1], err)
+			}
+		}
+	})
+}
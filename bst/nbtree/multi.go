@@ -0,0 +1,112 @@
+package nbtree
+
+// NewMultiBSTree returns new empty binary search tree in the multiset mode. In this mode the
+// tree allows several nodes with the same key, nodes with equal keys are kept in order
+// of their insertion.
+func NewMultiBSTree() *BSTree {
+	return &BSTree{multi: true}
+}
+
+// Multi returns true if the tree works in the multiset mode.
+func (t *BSTree) Multi() bool {
+	return t.multi
+}
+
+// Count returns the number of nodes with key k.
+func (t *BSTree) Count(k KeyType) int {
+	c := 0
+	for n := t.first(k); n != nil && n.key == k; n = t.Successor(n) {
+		c++
+	}
+
+	return c
+}
+
+// EqualRange returns all nodes with key k in order of their insertion, or nil if there
+// is no such node. Deletion may move keys and data between nodes, so the returned nodes
+// should not be used after the tree is modified.
+func (t *BSTree) EqualRange(k KeyType) []*BSTNode {
+	var nodes []*BSTNode
+	for n := t.first(k); n != nil && n.key == k; n = t.Successor(n) {
+		nodes = append(nodes, n)
+	}
+
+	return nodes
+}
+
+// DeleteOccurrence deletes the i-th (starting from 0) inserted node with key k. It returns
+// the data associated with the deleted node and true, or nil and false if there is no such node.
+func (t *BSTree) DeleteOccurrence(k KeyType, i int) (any, bool) {
+	if i < 0 {
+		return nil, false
+	}
+
+	// Skip i occurrences
+	n := t.first(k)
+	for ; i > 0 && n != nil && n.key == k; i-- {
+		n = t.Successor(n)
+	}
+
+	if n == nil || n.key != k {
+		// No such occurrence
+		return nil, false
+	}
+
+	data := n.data
+	t.Delete(n)
+
+	return data, true
+}
+
+// DeleteAll deletes all nodes with key k and returns the number of deleted nodes.
+func (t *BSTree) DeleteAll(k KeyType) int {
+	c := 0
+	// Deletion can move the content of the successor to the deleted node,
+	// so the search of the first occurrence is repeated every time
+	for n := t.first(k); n != nil; n = t.first(k) {
+		t.Delete(n)
+		c++
+	}
+
+	return c
+}
+
+// first returns the first node in ascending order with key k or nil if there is no such node
+func (t *BSTree) first(k KeyType) *BSTNode {
+	if lb := t.lowerBound(k); lb != nil && lb.key == k {
+		return lb
+	}
+
+	return nil
+}
+
+// lowerBound returns the first node in ascending order with key not less than k or nil if there is no such node
+func (t *BSTree) lowerBound(k KeyType) *BSTNode {
+	var lb *BSTNode
+	for n := t.root; n != nil; {
+		if k <= n.key {
+			lb = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return lb
+}
+
+// insertParent returns the parent for the new node with key k, the new node should be
+// placed after all nodes with the same key to keep the order of insertion
+func (t *BSTree) insertParent(k KeyType) *BSTNode {
+	var p *BSTNode
+	for n := t.root; n != nil; {
+		p = n
+		if k < n.key {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return p
+}
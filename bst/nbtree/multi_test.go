@@ -0,0 +1,146 @@
+package nbtree
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+const (
+	multiKeysCount	=	4096
+	multiMaxItem	=	255
+	multiCheckStep	=	64
+)
+
+// newMultiTree returns a multiset tree filled by random keys with many duplicates and
+// the model - sequence numbers of inserted nodes for each key in order of insertion
+func newMultiTree() (*BSTree, map[KeyType][]int) {
+	tree := NewMultiBSTree()
+	model := map[KeyType][]int{}
+
+	for i := 0; i < multiKeysCount; i++ {
+		k := KeyType(rand.Int() % (multiMaxItem + 1))	//nolint:gosec
+		tree.Insert(NewBSTNode(k, i))
+		model[k] = append(model[k], i)
+	}
+
+	return tree, model
+}
+
+// checkMultiTree compares the content of the tree with the model
+func checkMultiTree(t *testing.T, tree *BSTree, model map[KeyType][]int) {
+	t.Helper()
+
+	for k := KeyType(0); k <= multiMaxItem; k++ {
+		if c := tree.Count(k); c != len(model[k]) {
+			t.Errorf("Count(%v) returned %d, want - %d", k, c, len(model[k]))
+			t.FailNow()
+		}
+
+		var got []int
+		for _, n := range tree.EqualRange(k) {
+			got = append(got, n.Value().(int))	//nolint:forcetypeassert
+		}
+		if len(got) != len(model[k]) || len(got) != 0 && !reflect.DeepEqual(got, model[k]) {
+			t.Errorf("EqualRange(%v) returned nodes with data %v, want - %v", k, got, model[k])
+			t.FailNow()
+		}
+
+		if n := tree.Search(k); len(model[k]) != 0 && (n == nil || n.Value() != model[k][0]) {
+			t.Errorf("Search(%v) returned %v, want - the first occurrence with data %d", k, n, model[k][0])
+			t.FailNow()
+		}
+	}
+}
+
+func TestMultiInsert(t *testing.T) {
+	tree, model := newMultiTree()
+
+	if !tree.Multi() {
+		t.Errorf("Multi returned false on the multiset tree")
+	}
+
+	checkMultiTree(t, tree, model)
+}
+
+func TestMultiDeleteOccurrence(t *testing.T) {
+	tree, model := newMultiTree()
+
+	for i := 0; i < multiKeysCount / 2; i++ {
+		k := KeyType(rand.Int() % (multiMaxItem + 1))	//nolint:gosec
+		if len(model[k]) == 0 {
+			if data, ok := tree.DeleteOccurrence(k, 0); ok {
+				t.Errorf("[%d] DeleteOccurrence(%v, 0) deleted node with data %v from the tree without the key", i, k, data)
+				t.FailNow()
+			}
+			continue
+		}
+
+		// Delete random occurrence
+		idx := rand.Int() % len(model[k])	//nolint:gosec
+		data, ok := tree.DeleteOccurrence(k, idx)
+		if !ok || data != model[k][idx] {
+			t.Errorf("[%d] DeleteOccurrence(%v, %d) returned %v, %t, want - %d, true", i, k, idx, data, ok, model[k][idx])
+			t.FailNow()
+		}
+		model[k] = append(model[k][:idx], model[k][idx+1:]...)
+
+		if i % multiCheckStep == 0 {
+			checkMultiTree(t, tree, model)
+		}
+	}
+
+	checkMultiTree(t, tree, model)
+
+	// Out of range occurrences
+	for k, seq := range model {
+		if _, ok := tree.DeleteOccurrence(k, len(seq)); ok {
+			t.Errorf("DeleteOccurrence(%v, %d) returned true for the key with %d occurrences", k, len(seq), len(seq))
+		}
+		if _, ok := tree.DeleteOccurrence(k, -1); ok {
+			t.Errorf("DeleteOccurrence(%v, -1) returned true", k)
+		}
+	}
+}
+
+func TestMultiDeleteAll(t *testing.T) {
+	tree, model := newMultiTree()
+
+	for k := KeyType(0); k <= multiMaxItem; k += 2 {
+		if c := tree.DeleteAll(k); c != len(model[k]) {
+			t.Errorf("DeleteAll(%v) returned %d, want - %d", k, c, len(model[k]))
+			t.FailNow()
+		}
+		delete(model, k)
+	}
+
+	checkMultiTree(t, tree, model)
+
+	// Delete the rest
+	for k := KeyType(1); k <= multiMaxItem; k += 2 {
+		tree.DeleteAll(k)
+	}
+	if root := tree.Root(); root != nil {
+		t.Errorf("tree must be empty (root == nil), but root is - %v", root)
+	}
+}
+
+func TestMultiUniqueMode(t *testing.T) {
+	tree, _ := newTreeSortedKeys(testKeys, skipKeys)
+
+	if tree.Multi() {
+		t.Errorf("Multi returned true on the tree with unique keys")
+	}
+
+	for i, k := range testKeys[:multiCheckStep] {
+		if c := tree.Count(k); c != 1 {
+			t.Errorf("[%d] Count(%v) returned %d, want - 1", i, k, c)
+		}
+		if c := tree.DeleteAll(k); c != 1 {
+			t.Errorf("[%d] DeleteAll(%v) returned %d, want - 1", i, k, c)
+		}
+		if c := tree.Count(k); c != 0 {
+			t.Errorf("[%d] Count(%v) returned %d after DeleteAll, want - 0", i, k, c)
+		}
+	}
+}
@@ -61,3 +61,27 @@ func (n *BSTNode) Value() any {
 
 	return n.data
 }
+
+// Left returns the left child of the node
+func (n *BSTNode) Left() *BSTNode {
+	if n == nil {
+		return nil
+	}
+	return n.left
+}
+
+// Right returns the right child of the node
+func (n *BSTNode) Right() *BSTNode {
+	if n == nil {
+		return nil
+	}
+	return n.right
+}
+
+// Parent returns the parent of the node
+func (n *BSTNode) Parent() *BSTNode {
+	if n == nil {
+		return nil
+	}
+	return n.parent
+}
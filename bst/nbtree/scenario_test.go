@@ -0,0 +1,83 @@
+package nbtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/r-che/algorithms/bst/internal/scenario"
+)
+
+// scenarioTree applies operations of scenarios to the non-balanced tree
+type scenarioTree struct {
+	*BSTree
+}
+
+// Step applies the operation op to the tree
+func (t scenarioTree) Step(op scenario.Op) error {
+	if op.Name == "stats" {
+		st := t.Stats()
+
+		return scenario.CheckStats(map[string]int{
+			"nodes":		st.Nodes,
+			"height":		st.Height,
+			"min-leaf-depth":	st.MinLeafDepth,
+		}, op.Args)
+	}
+
+	keys, err := scenario.Keys[KeyType](op.Args)
+	if err != nil {
+		return err
+	}
+
+	switch op.Name {
+	case "insert":
+		for _, k := range keys {
+			if t.Insert(NewBSTNode(k, nil)) == nil {
+				return fmt.Errorf("key %v already exists", k)
+			}
+		}
+	case "delete":
+		for _, k := range keys {
+			n := t.Search(k)
+			if n == nil {
+				return fmt.Errorf("key %v not found", k)
+			}
+			t.Delete(n)
+		}
+	case "keys":
+		var got []KeyType
+		for n := t.Min(); n != nil; n = t.Successor(n) {
+			got = append(got, n.Key())
+		}
+		if fmt.Sprint(got) != fmt.Sprint(keys) {
+			return fmt.Errorf("tree contains keys %v, want - %v", got, keys)
+		}
+	case "check":
+		return t.check()
+	default:
+		return fmt.Errorf("unknown operation")
+	}
+
+	return nil
+}
+
+// check checks links between nodes and the order of keys, the tree has no self-test
+func (t scenarioTree) check() error {
+	for n := t.Min(); n != nil; n = t.Successor(n) {
+		for _, c := range [...]*BSTNode{n.left, n.right} {
+			if c != nil && c.parent != n {
+				return fmt.Errorf("node %v has child %v with the wrong parent %v", n, c, c.parent)
+			}
+		}
+
+		if s := t.Successor(n); s != nil && s.key <= n.key {
+			return fmt.Errorf("key %v is followed by %v", n.key, s.key)
+		}
+	}
+
+	return nil
+}
+
+func TestScenarios(t *testing.T) {
+	scenario.Run(t, "nb", func() scenario.Tree { return scenarioTree{NewBSTree()} })
+}
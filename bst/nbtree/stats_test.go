@@ -0,0 +1,104 @@
+package nbtree
+
+import (
+	"reflect"
+	"testing"
+)
+
+// shape returns the height, the minimal depth of leafs and the sum of depths of
+// nodes of the subtree n located at the depth d, calculated recursively
+func (n *BSTNode) shape(d int) (int, int, int) {
+	if n.left == nil && n.right == nil {
+		return d, d, d
+	}
+
+	height, minLeaf, sum := 0, 0, d
+	for _, c := range [...]*BSTNode{n.left, n.right} {
+		if c == nil {
+			continue
+		}
+
+		h, ml, s := c.shape(d + 1)
+		if h > height {
+			height = h
+		}
+		if minLeaf == 0 || ml < minLeaf {
+			minLeaf = ml
+		}
+		sum += s
+	}
+
+	return height, minLeaf, sum
+}
+
+func TestStatsEmpty(t *testing.T) {
+	if st := NewBSTree().Stats(); !reflect.DeepEqual(st, Stats{}) {
+		t.Errorf("Stats of the empty tree returned %#v, want - zero statistics", st)
+	}
+}
+
+func TestStatsPerfect(t *testing.T) {
+	tree := NewBSTree()
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(NewBSTNode(k, nil))
+	}
+
+	want := Stats{
+		Nodes:		15,
+		Height:		4,
+		MinLeafDepth:	4,
+		AvgDepth:	float64(1*1 + 2*2 + 3*4 + 4*8) / 15,
+		Levels:		[]int{1, 2, 4, 8},
+		Imbalance:	1,
+	}
+	if st := tree.Stats(); !reflect.DeepEqual(st, want) {
+		t.Errorf("Stats returned %#v, want - %#v", st, want)
+	}
+}
+
+func TestStatsDegenerated(t *testing.T) {
+	const nKeys = 100
+
+	tree := NewBSTree()
+	for k := KeyType(0); k < nKeys; k++ {
+		tree.Insert(NewBSTNode(k, nil))
+	}
+
+	st := tree.Stats()
+	if st.Nodes != nKeys || st.Height != nKeys || st.MinLeafDepth != nKeys {
+		t.Errorf("Stats returned %d nodes, height %d, min leaf depth %d, want - %d for all",
+			st.Nodes, st.Height, st.MinLeafDepth, nKeys)
+	}
+
+	// The perfectly balanced tree with 100 nodes has the height 7
+	if want := float64(nKeys) / 7; st.Imbalance != want {
+		t.Errorf("Stats returned imbalance %f, want - %f", st.Imbalance, want)
+	}
+}
+
+func TestStatsRandom(t *testing.T) {
+	tree, _ := newTreeSortedKeys(testKeys, false)
+	st := tree.Stats()
+
+	if st.Nodes != len(testKeys) {
+		t.Errorf("Stats returned %d nodes, want - %d", st.Nodes, len(testKeys))
+	}
+
+	nodes := 0
+	for _, c := range st.Levels {
+		nodes += c
+	}
+	if nodes != st.Nodes || len(st.Levels) != st.Height {
+		t.Errorf("Stats returned levels %v inconsistent with %d nodes and height %d",
+			st.Levels, st.Nodes, st.Height)
+	}
+
+	height, minLeaf, sum := tree.root.shape(1)
+	if st.Height != height || st.MinLeafDepth != minLeaf {
+		t.Errorf("Stats returned height %d, min leaf depth %d, want - %d, %d",
+			st.Height, st.MinLeafDepth, height, minLeaf)
+	}
+	if avg := float64(sum) / float64(st.Nodes); st.AvgDepth != avg {
+		t.Errorf("Stats returned average depth %f, want - %f", st.AvgDepth, avg)
+	}
+}
@@ -19,7 +19,7 @@ func (t *BSTree) String() string {
 	}
 
 	// Get a map with nodes separated by levels and
-	// a map with positions of keys in a linear ordering of keys
+	// a map with positions of nodes in a linear ordering of keys
 	levels, positions := stringPrepareData(t)
 
 	// Tree width
@@ -53,7 +53,7 @@ func (t *BSTree) String() string {
 		oMatrix[oLine+2] = make([]string, width)
 		for _, node := range levels[level] {
 			// Write node key to the output matrix
-			oMatrix[oLine][positions[node.key]] = " " + fmt.Sprintf(nFmt, node.key) + " "
+			oMatrix[oLine][positions[node]] = " " + fmt.Sprintf(nFmt, node.key) + " "
 
 			// Write the initial fragment of the branch from the children to its parent
 			stringInitBranchFrag(oMatrix[oLine+1], positions, node, stub)
@@ -67,17 +67,17 @@ func (t *BSTree) String() string {
 			// Determine direction of drawing
 			var step int
 			// Get the number of cells between parent and child
-			if nc := positions[node.key] - positions[node.parent.key]; nc < 0 {
+			if nc := positions[node] - positions[node.parent]; nc < 0 {
 				// Node - LEFT child of its parent, need to draw branch to the right toward the parent
-				oMatrix[oLine-1][positions[node.key]] = ` ` + stub + `/`
+				oMatrix[oLine-1][positions[node]] = ` ` + stub + `/`
 				step = 1
 			} else {
 				// Node - RIGHT child of its parent, need to draw branch to the left toward the parent
-				oMatrix[oLine-1][positions[node.key]] = `\` + stub + ` `
+				oMatrix[oLine-1][positions[node]] = `\` + stub + ` `
 				step = -1
 			}
 
-			for ni := positions[node.key] + step; ni != positions[node.parent.key]; ni += step {
+			for ni := positions[node] + step; ni != positions[node.parent]; ni += step {
 				oMatrix[oLine-2][ni] = branchFrag
 			}
 		}
@@ -89,31 +89,31 @@ func (t *BSTree) String() string {
 // stringPrepareData source data to create string representation of the tree. It returns:
 // levels -  map containing a set of levels (starting from the root - 0), each of that level
 //           contains list of corresponding nodes in ascending order
-// positions - map of key<=>position, when position is the position of corresponding key
-//             in the flat ordered list of tree's keys
-func stringPrepareData(t *BSTree ) (map[int][]*BSTNode, map[KeyType]int) {
+// positions - map of node<=>position, when position is the position of corresponding node
+//             in the flat ordered list of tree's nodes
+func stringPrepareData(t *BSTree ) (map[int][]*BSTNode, map[*BSTNode]int) {
 	// Collect all nodes into the matrix
 	levels := map[int][]*BSTNode{0: []*BSTNode{t.root}}
 	t.root.childKeys(1, levels)
 
 	// Map keys<=>position
-	positions := map[KeyType]int{}
+	positions := map[*BSTNode]int{}
 	for n, pos := t.Min(), 0; n != nil; n, pos = t.Successor(n), pos+1 {
-		positions[n.key] = pos
+		positions[n] = pos
 	}
 
 	return levels, positions
 }
 
 // stringInitBranchFrag writes the initial fragment of branches to chilldren, if any
-func stringInitBranchFrag(row []string, positions map[KeyType]int, node *BSTNode, stub string) {
+func stringInitBranchFrag(row []string, positions map[*BSTNode]int, node *BSTNode, stub string) {
 	switch {
 	case node.left != nil && node.right != nil:
-		row[positions[node.key]] = `/` + stub + `\`
+		row[positions[node]] = `/` + stub + `\`
 	case node.left != nil:
-		row[positions[node.key]] = `/` + stub + ` `
+		row[positions[node]] = `/` + stub + ` `
 	case node.right != nil:
-		row[positions[node.key]] = ` ` + stub + `\`
+		row[positions[node]] = ` ` + stub + `\`
 	}
 }
 
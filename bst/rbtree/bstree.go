@@ -1,7 +1,13 @@
 package rbtree
 
-// Search returns a tree node with key k or nil if there is no such node.
+// Search returns a tree node with key k or nil if there is no such node. In the multiset
+// mode the first inserted node with key k is returned.
 func (t *RBTree) Search(k KeyType) *RBNode {
+	if t.multi {
+		// Return the first occurrence of the key
+		return t.first(k)
+	}
+
 	n := t.root
 	for n != nil && n.key != k {
 		if k < n.key {
@@ -125,11 +131,18 @@ func (t *RBTree) bstInsert(n *RBNode) (*RBNode, bool) { //nolint:varnamelen // n
 		return n, false
 	}
 
-	// Search node with key k in the tree
-	N, p := t.SearchWithParent(n.key)
-	if N != nil {
-		// Already exists, no insertion or fixup required
-		return nil, false
+	// Search the parent of the new node
+	var p *RBNode
+	if t.multi {
+		// Duplicates are allowed, the new node goes after all nodes with the same key
+		p = t.insertParent(n.key)
+	} else {
+		// Search node with key k in the tree
+		var N *RBNode
+		if N, p = t.SearchWithParent(n.key); N != nil {
+			// Already exists, no insertion or fixup required
+			return nil, false
+		}
 	}
 
 	// Assign correct parent of the new node
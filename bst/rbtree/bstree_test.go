@@ -2,6 +2,7 @@ package rbtree
 
 import (
 	"fmt"
+	"math/bits"
 	"testing"
 	"math/rand"
 	"sort"
@@ -228,6 +229,36 @@ func TestInsertDupes(t *testing.T) {
 	}
 }
 
+func TestInsertFunc(t *testing.T) {
+	tree := NewRBTree()
+
+	// Order values by tens in descending order, values with the same tens keep the order of insertion
+	tens := func(n *RBNode) int { return int(testKeys[n.Value().(int)]) / 10 }
+	for i := range testKeys {
+		n := NewRBNode(FakeNode, i)
+		if tree.InsertFunc(n, func(node *RBNode) bool { return tens(n) > tens(node) }) != n {
+			t.Fatalf("[%d] RBTree.InsertFunc did not return the inserted node", i)
+		}
+	}
+
+	c := 0
+	for n, next := tree.Min(), tree.Min(); n != nil; n, c = next, c + 1 {
+		next = tree.Successor(n)
+		if next != nil && (tens(n) < tens(next) || tens(n) == tens(next) && n.Value().(int) > next.Value().(int)) {
+			t.Fatalf("node of value %v is followed by node of value %v",
+				testKeys[n.Value().(int)], testKeys[next.Value().(int)])
+		}
+	}
+	if c != len(testKeys) {
+		t.Errorf("tree contains %d nodes, want - %d", c, len(testKeys))
+	}
+
+	// The tree is balanced
+	if st := tree.Stats(); st.Height > 2 * bits.Len(uint(st.Nodes)) {
+		t.Errorf("tree with %d nodes has height %d", st.Nodes, st.Height)
+	}
+}
+
 func TestSearch(t *testing.T) {
 	tree, _ := newTreeSortedKeys(testKeys, skipKeys)
 
@@ -476,3 +507,34 @@ func TestRotateDoubleString(t *testing.T) {
 		}
 	}
 }
+
+func TestNewRBTreeFromRoot(t *testing.T) {
+	// Assemble tree with the predefined structure
+	root, left, right := NewRBNode(20, nil), NewRBNode(10, nil), NewRBNode(30, nil)
+	left.SetColor(Red)
+	right.SetColor(Red)
+	root.SetChildren(left, right)
+
+	tree := NewRBTreeFromRoot(root)
+
+	if tree.Root() != root || root.Left() != left || root.Right() != right {
+		t.Errorf("invalid structure of assembled tree:\n%v", tree)
+	}
+
+	if left.Parent() != root || right.Parent() != root || root.Parent() != nil {
+		t.Errorf("invalid parents of assembled tree nodes: %v, %v, %v", root.Parent(), left.Parent(), right.Parent())
+	}
+
+	if bh, err := tree.SelfTest(); err != nil || bh != 1 {
+		t.Errorf("SelfTest on assembled tree returned %d, %v, want - 1, nil", bh, err)
+	}
+
+	// Check for the successors walking
+	var keys []KeyType
+	for n := tree.Min(); n != nil; n = tree.Successor(n) {
+		keys = append(keys, n.Key())
+	}
+	if fmt.Sprint(keys) != "[10 20 30]" {
+		t.Errorf("walking through assembled tree returned %v, want - [10 20 30]", keys)
+	}
+}
@@ -0,0 +1,141 @@
+package rbtree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCursorEmpty(t *testing.T) {
+	c := NewRBTree().NewCursor()
+
+	if c.Valid() || c.First() || c.Last() || c.Seek(0) || c.Next() || c.Prev() || c.Delete() || c.SetValue(1) {
+		t.Errorf("cursor on the empty tree is valid")
+	}
+	if k, v := c.Key(), c.Value(); k != FakeNode || v != nil {
+		t.Errorf("not valid cursor returned key %v and value %v, want - %v and nil", k, v, FakeNode)
+	}
+}
+
+func TestCursorWalk(t *testing.T) {
+	tree, sKeys := newTreeSortedKeys(testKeys, makeKeys)
+
+	// Ascending order
+	c := tree.NewCursor()
+	for i, k := range sKeys {
+		if !c.Valid() || c.Key() != k {
+			t.Errorf("[%d] cursor is on the key %v (valid: %t), want - %v", i, c.Key(), c.Valid(), k)
+			t.FailNow()
+		}
+		c.Next()
+	}
+	if c.Valid() {
+		t.Errorf("cursor is valid after the maximum key, current key - %v", c.Key())
+	}
+
+	// Descending order
+	c.Last()
+	for i := len(sKeys) - 1; i >= 0; i-- {
+		if !c.Valid() || c.Key() != sKeys[i] {
+			t.Errorf("[%d] cursor is on the key %v (valid: %t), want - %v", i, c.Key(), c.Valid(), sKeys[i])
+			t.FailNow()
+		}
+		c.Prev()
+	}
+	if c.Valid() {
+		t.Errorf("cursor is valid before the minimum key, current key - %v", c.Key())
+	}
+}
+
+func TestCursorSeek(t *testing.T) {
+	tree, sKeys := newTreeSortedKeys(testKeys, makeKeys)
+	c := tree.NewCursor()
+
+	for i, k := range sKeys {
+		// Seek existing key
+		if !c.Seek(k) || c.Key() != k {
+			t.Errorf("[%d] Seek(%v) moved cursor to %v (valid: %t)", i, k, c.Key(), c.Valid())
+			t.FailNow()
+		}
+
+		// Seek the key between existing keys
+		if i == 0 || sKeys[i-1] == k - 1 {
+			continue
+		}
+		if !c.Seek(k - 1) || c.Key() != k {
+			t.Errorf("[%d] Seek(%v) moved cursor to %v (valid: %t), want - %v", i, k - 1, c.Key(), c.Valid(), k)
+			t.FailNow()
+		}
+	}
+
+	if c.Seek(sKeys[len(sKeys)-1] + 1) {
+		t.Errorf("Seek after the maximum key moved cursor to %v", c.Key())
+	}
+}
+
+func TestCursorSetValue(t *testing.T) {
+	tree, sKeys := newTreeSortedKeys(testKeys, makeKeys)
+
+	for c := tree.NewCursor(); c.Valid(); c.Next() {
+		c.SetValue(int(c.Key()) * 2)
+	}
+
+	for i, k := range sKeys {
+		if v := tree.Search(k).Value(); v != int(k) * 2 {
+			t.Errorf("[%d] key %v has value %v, want - %d", i, k, v, int(k) * 2)
+			t.FailNow()
+		}
+	}
+}
+
+func TestCursorDeleteEveryOther(t *testing.T) {
+	tree, sKeys := newTreeSortedKeys(testKeys, makeKeys)
+
+	// Delete every other element during the scan
+	c := tree.NewCursor()
+	var kept []KeyType
+	for i := 0; c.Valid(); i++ {
+		if c.Key() != sKeys[i] {
+			t.Errorf("[%d] cursor is on the key %v, want - %v", i, c.Key(), sKeys[i])
+			t.FailNow()
+		}
+
+		if i % 2 != 0 {
+			kept = append(kept, c.Key())
+			c.Next()
+			continue
+		}
+
+		if !c.Delete() {
+			t.Errorf("[%d] Delete returned false on the valid cursor", i)
+			t.FailNow()
+		}
+
+		// Cursor must be on the next element
+		if i + 1 < len(sKeys) && c.Key() != sKeys[i+1] {
+			t.Errorf("[%d] cursor is on the key %v after Delete, want - %v", i, c.Key(), sKeys[i+1])
+			t.FailNow()
+		}
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%d] SelfTest failed after deletion of %v: %v", i, sKeys[i], err)
+			t.FailNow()
+		}
+	}
+
+	// Check the rest of the tree
+	var rest []KeyType
+	for n := tree.Min(); n != nil; n = tree.Successor(n) {
+		rest = append(rest, n.Key())
+	}
+	if !reflect.DeepEqual(rest, kept) {
+		t.Errorf("tree contains %d keys after deletion, want - %d", len(rest), len(kept))
+	}
+
+	// Delete the rest
+	for c.First(); c.Valid(); {
+		c.Delete()
+	}
+	if root := tree.Root(); root != nil {
+		t.Errorf("tree must be empty (root == nil), but root is - %v", root)
+	}
+}
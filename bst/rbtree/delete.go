@@ -0,0 +1,73 @@
+package rbtree
+
+// DeleteKey deletes the node with key k and returns true, or returns false if there is no
+// such node. In the multiset mode the first inserted node with key k is deleted.
+func (t *RBTree) DeleteKey(k KeyType) bool {
+	n := t.Search(k)
+	if n == nil {
+		return false
+	}
+
+	t.Delete(n)
+
+	return true
+}
+
+// DeleteMin deletes the node with the minimal key and returns its key, data and true,
+// or FakeNode, nil and false if the tree is empty.
+func (t *RBTree) DeleteMin() (KeyType, any, bool) {
+	return t.deleteNode(t.Min())
+}
+
+// DeleteMax deletes the node with the maximal key and returns its key, data and true,
+// or FakeNode, nil and false if the tree is empty.
+func (t *RBTree) DeleteMax() (KeyType, any, bool) {
+	return t.deleteNode(t.Max())
+}
+
+// DeleteRange deletes all nodes with keys in the range [lo, hi] and returns the number
+// of deleted nodes. The tree is split into nodes with keys less than lo, nodes of the range
+// and nodes with keys greater than hi, then the outer parts are joined back using one
+// node of the range that is deleted after the join. It takes O(log n + m) time, where m
+// is the number of deleted nodes, the nodes of the range are only counted.
+func (t *RBTree) DeleteRange(lo, hi KeyType) int {
+	if n := t.lowerBound(lo); n == nil || n.key > hi {
+		// Nothing to delete
+		return 0
+	}
+
+	less, rest := split(detach(t.root, blackHeight(t.root)), func(k KeyType) bool { return k < lo })
+	middle, greater := split(rest, func(k KeyType) bool { return k <= hi })
+
+	// Count nodes of the range
+	c := 0
+	for stack := []*RBNode{middle.root}; len(stack) != 0; {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if n != nil {
+			c++
+			stack = append(stack, n.left, n.right)
+		}
+	}
+
+	// The root of the range is used to join the outer parts and deleted then
+	pivot := middle.root
+	t.root = join(less, pivot, greater).root
+	t.Delete(pivot)
+
+	return c
+}
+
+// deleteNode deletes the node n if it is not nil and returns its key and data
+func (t *RBTree) deleteNode(n *RBNode) (KeyType, any, bool) {
+	if n == nil {
+		return FakeNode, nil, false
+	}
+
+	// Save the content, it can be replaced if n has two children
+	k, data := n.key, n.data
+	t.Delete(n)
+
+	return k, data, true
+}
@@ -0,0 +1,249 @@
+package rbtree
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Run self-test after each deleteCheckStep deletions
+const deleteCheckStep = 64
+
+// treeKeys returns keys of the tree in ascending order
+func treeKeys(tree *RBTree) []KeyType {
+	keys := []KeyType{}
+	for n := tree.Min(); n != nil; n = tree.Successor(n) {
+		keys = append(keys, n.Key())
+	}
+
+	return keys
+}
+
+// checkTreeKeys checks properties of the tree and compares its keys with the sorted keys want
+func checkTreeKeys(t *testing.T, tree *RBTree, want []KeyType) {
+	t.Helper()
+
+	if _, err := tree.SelfTest(); err != nil {
+		t.Errorf("self-test failed: %v", err)
+		t.FailNow()
+	}
+
+	if got := treeKeys(tree); !reflect.DeepEqual(got, want) {
+		t.Errorf("tree contains %d keys, want - %d, keys differ", len(got), len(want))
+		t.FailNow()
+	}
+}
+
+func TestDeleteKey(t *testing.T) {
+	tree, sKeys := newTreeSortedKeys(testKeys, makeKeys)
+
+	if tree.DeleteKey(MaxItem + 1) {
+		t.Errorf("DeleteKey(%v) returned true for the absent key", MaxItem + 1)
+	}
+
+	// Delete keys in random order
+	for i, k := range rand.Perm(len(sKeys)) {
+		if !tree.DeleteKey(sKeys[k]) {
+			t.Errorf("DeleteKey(%v) returned false for the existing key", sKeys[k])
+			t.FailNow()
+		}
+
+		if tree.Search(sKeys[k]) != nil {
+			t.Errorf("key %v found after DeleteKey", sKeys[k])
+			t.FailNow()
+		}
+
+		if i % deleteCheckStep == 0 {
+			if _, err := tree.SelfTest(); err != nil {
+				t.Errorf("self-test failed after %d deletions: %v", i + 1, err)
+				t.FailNow()
+			}
+		}
+	}
+
+	if root := tree.Root(); root != nil {
+		t.Errorf("tree must be empty (root == nil), but root is - %v", root)
+	}
+}
+
+func TestDeleteMinMax(t *testing.T) {
+	tree := NewRBTree()
+	for _, k := range testKeys {
+		tree.Insert(NewRBNode(k, int(k)))
+	}
+	_, sKeys := newTreeSortedKeys(testKeys, makeKeys)
+
+	// Delete minimal and maximal keys alternately
+	for lo, hi := 0, len(sKeys) - 1; lo <= hi; {
+		k, data, ok := tree.DeleteMin()
+		if !ok || k != sKeys[lo] || data != int(sKeys[lo]) {
+			t.Errorf("DeleteMin returned %v, %v, %t, want - %v, %v, true", k, data, ok, sKeys[lo], sKeys[lo])
+			t.FailNow()
+		}
+		lo++
+
+		if lo > hi {
+			break
+		}
+
+		k, data, ok = tree.DeleteMax()
+		if !ok || k != sKeys[hi] || data != int(sKeys[hi]) {
+			t.Errorf("DeleteMax returned %v, %v, %t, want - %v, %v, true", k, data, ok, sKeys[hi], sKeys[hi])
+			t.FailNow()
+		}
+		hi--
+	}
+
+	checkTreeKeys(t, tree, []KeyType{})
+
+	// Empty tree
+	if k, data, ok := tree.DeleteMin(); ok || k != FakeNode || data != nil {
+		t.Errorf("DeleteMin on the empty tree returned %v, %v, %t, want - %v, nil, false", k, data, ok, FakeNode)
+	}
+	if k, data, ok := tree.DeleteMax(); ok || k != FakeNode || data != nil {
+		t.Errorf("DeleteMax on the empty tree returned %v, %v, %t, want - %v, nil, false", k, data, ok, FakeNode)
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	tests := []struct {
+		lo, hi	KeyType
+	}{
+		{ 40000, 50000 },		// about 10% of keys
+		{ 0, 99 },			// near the minimum
+		{ MaxItem - 99, MaxItem },	// near the maximum
+		{ 12345, 12345 },		// single key or nothing
+		{ 50000, 40000 },		// empty range
+		{ -100, -1 },			// out of the keys range
+		{ 0, MaxItem },			// all keys
+	}
+
+	for _, test := range tests {
+		tree, sKeys := newTreeSortedKeys(testKeys, makeKeys)
+
+		// Expected keys - keys out of the range
+		want := []KeyType{}
+		for _, k := range sKeys {
+			if k < test.lo || k > test.hi {
+				want = append(want, k)
+			}
+		}
+
+		if c := tree.DeleteRange(test.lo, test.hi); c != len(sKeys) - len(want) {
+			t.Errorf("DeleteRange(%v, %v) returned %d, want - %d", test.lo, test.hi, c, len(sKeys) - len(want))
+		}
+
+		checkTreeKeys(t, tree, want)
+	}
+}
+
+func TestDeleteRangeMulti(t *testing.T) {
+	tree, model := newMultiTree()
+	const lo, hi = multiMaxItem / 4, multiMaxItem / 2
+
+	want := []KeyType{}
+	deleted := 0
+	for k, seq := range model {
+		if k < lo || k > hi {
+			for range seq {
+				want = append(want, k)
+			}
+		} else {
+			deleted += len(seq)
+		}
+	}
+	sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+
+	if c := tree.DeleteRange(lo, hi); c != deleted {
+		t.Errorf("DeleteRange(%v, %v) returned %d, want - %d", lo, hi, c, deleted)
+	}
+
+	checkTreeKeys(t, tree, want)
+
+	// The first occurrence of a key is deleted by DeleteKey
+	k := KeyType(hi + 1)
+	if !tree.DeleteKey(k) {
+		t.Errorf("DeleteKey(%v) returned false for the existing key", k)
+		t.FailNow()
+	}
+	if c := tree.Count(k); c != len(model[k]) - 1 {
+		t.Errorf("Count(%v) after DeleteKey returned %d, want - %d", k, c, len(model[k]) - 1)
+	}
+	if n := tree.Search(k); n != nil && n.Value() != model[k][1] {
+		t.Errorf("the first occurrence of %v is %v after DeleteKey, want - %v", k, n.Value(), model[k][1])
+	}
+}
+
+func TestDeleteRangeRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2022))	//nolint:gosec // Reproducible sequence is required
+
+	tree, sKeys := newTreeSortedKeys(testKeys, makeKeys)
+	model := map[KeyType]bool{}
+	for _, k := range sKeys {
+		model[k] = true
+	}
+
+	// Delete short ranges at random positions, split and join go through all levels of the tree
+	for i := 0; i < 200 && len(model) != 0; i++ {
+		lo := KeyType(rnd.Intn(MaxItem))
+		hi := lo + KeyType(rnd.Intn(MaxItem / 100))
+
+		want := 0
+		for k := range model {
+			if k >= lo && k <= hi {
+				delete(model, k)
+				want++
+			}
+		}
+
+		if c := tree.DeleteRange(lo, hi); c != want {
+			t.Errorf("DeleteRange(%v, %v) returned %d, want - %d", lo, hi, c, want)
+			t.FailNow()
+		}
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("self-test failed after DeleteRange(%v, %v): %v", lo, hi, err)
+			t.FailNow()
+		}
+	}
+
+	want := make([]KeyType, 0, len(model))
+	for k := range model {
+		want = append(want, k)
+	}
+	sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+
+	checkTreeKeys(t, tree, want)
+}
+
+// BenchmarkDeleteRange deletes ranges of the same length from trees of different sizes,
+// the time per operation grows with the logarithm of the tree size
+func BenchmarkDeleteRange(b *testing.B) {
+	const rangeLen = 16
+
+	for _, size := range []int{1 << 10, 1 << 14, 1 << 18} {
+		tree := NewRBTree()
+		for k := 0; k < size; k++ {
+			tree.Insert(NewRBNode(KeyType(k), nil))
+		}
+
+		b.Run(fmt.Sprintf("size-%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				lo := KeyType(i * rangeLen % (size - rangeLen))
+
+				if c := tree.DeleteRange(lo, lo + rangeLen - 1); c != rangeLen {
+					b.Fatalf("DeleteRange(%v, %v) returned %d, want - %d", lo, lo + rangeLen - 1, c, rangeLen)
+				}
+
+				// Restore deleted keys
+				b.StopTimer()
+				for k := lo; k < lo + rangeLen; k++ {
+					tree.Insert(NewRBNode(k, nil))
+				}
+				b.StartTimer()
+			}
+		})
+	}
+}
@@ -108,3 +108,98 @@ func Example_treeDelete() {
 		fmt.Println(tree)
 	}
 }
+
+func Example_multiset() {
+	// Create tree that allows duplicate keys
+	tree := NewMultiRBTree()
+
+	// Insert events, some of them share the same timestamp
+	events := []struct{ ts KeyType; name string }{
+		{10, "start"}, {20, "load"}, {10, "init"}, {30, "stop"}, {20, "save"}, {10, "log"},
+	}
+	for _, e := range events {
+		tree.Insert(NewRBNode(e.ts, e.name))
+	}
+
+	// Events with equal timestamps are kept in order of insertion
+	fmt.Print("Events at 10 (", tree.Count(10), "):")
+	for _, n := range tree.EqualRange(10) {
+		fmt.Print(" ", n.Value())
+	}
+	fmt.Println()
+
+	// Delete the second event at 10 and all events at 20
+	data, _ := tree.DeleteOccurrence(10, 1)
+	fmt.Println("Deleted:", data, "and", tree.DeleteAll(20), "events at 20")
+
+	// Walk the tree
+	fmt.Print("Rest:")
+	for n := tree.Min(); n != nil; n = tree.Successor(n) {
+		fmt.Print(" ", n.Key(), ":", n.Value())
+	}
+	fmt.Println()
+
+	// Output:
+	// Events at 10 (3): start init log
+	// Deleted: init and 2 events at 20
+	// Rest: 10:start 10:log 30:stop
+}
+
+func Example_cursor() {
+	// Tree creation
+	tree := NewRBTree()
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(NewRBNode(k, nil))
+	}
+
+	// Delete all keys between 10 and 30 divisible by 5
+	c := tree.NewCursor()
+	for c.Seek(10); c.Valid() && c.Key() <= 30; {
+		if c.Key() % 5 == 0 {
+			c.Delete()
+		} else {
+			c.Next()
+		}
+	}
+
+	// Walk the tree backward
+	fmt.Print("Rest:")
+	for c.Last(); c.Valid(); c.Prev() {
+		fmt.Print(" ", c.Key())
+	}
+	fmt.Println()
+
+	// Output:
+	// Rest: 37 35 33 27 23 17 13 8 5 2
+}
+
+func Example_deleteRange() {
+	// Tree creation
+	tree := NewRBTree()
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(NewRBNode(k, fmt.Sprintf("v%v", k)))
+	}
+
+	// Delete keys without searching nodes
+	fmt.Println("Deleted 17:", tree.DeleteKey(17), "deleted 18:", tree.DeleteKey(18))
+	fmt.Println("Deleted from 10 to 30:", tree.DeleteRange(10, 30), "keys")
+
+	k, data, _ := tree.DeleteMin()
+	fmt.Println("Deleted minimum:", k, data)
+	k, data, _ = tree.DeleteMax()
+	fmt.Println("Deleted maximum:", k, data)
+
+	// Walk the tree
+	fmt.Print("Rest:")
+	for n := tree.Min(); n != nil; n = tree.Successor(n) {
+		fmt.Print(" ", n.Key())
+	}
+	fmt.Println()
+
+	// Output:
+	// Deleted 17: true deleted 18: false
+	// Deleted from 10 to 30: 8 keys
+	// Deleted minimum: 2 v2
+	// Deleted maximum: 37 v37
+	// Rest: 5 8 33 35
+}
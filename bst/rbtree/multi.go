@@ -0,0 +1,112 @@
+package rbtree
+
+// NewMultiRBTree returns new empty red-black tree in the multiset mode. In this mode the
+// tree allows several nodes with the same key, nodes with equal keys are kept in order
+// of their insertion.
+func NewMultiRBTree() *RBTree {
+	return &RBTree{multi: true}
+}
+
+// Multi returns true if the tree works in the multiset mode.
+func (t *RBTree) Multi() bool {
+	return t.multi
+}
+
+// Count returns the number of nodes with key k.
+func (t *RBTree) Count(k KeyType) int {
+	c := 0
+	for n := t.first(k); n != nil && n.key == k; n = t.Successor(n) {
+		c++
+	}
+
+	return c
+}
+
+// EqualRange returns all nodes with key k in order of their insertion, or nil if there
+// is no such node. Deletion may move keys and data between nodes, so the returned nodes
+// should not be used after the tree is modified.
+func (t *RBTree) EqualRange(k KeyType) []*RBNode {
+	var nodes []*RBNode
+	for n := t.first(k); n != nil && n.key == k; n = t.Successor(n) {
+		nodes = append(nodes, n)
+	}
+
+	return nodes
+}
+
+// DeleteOccurrence deletes the i-th (starting from 0) inserted node with key k. It returns
+// the data associated with the deleted node and true, or nil and false if there is no such node.
+func (t *RBTree) DeleteOccurrence(k KeyType, i int) (any, bool) {
+	if i < 0 {
+		return nil, false
+	}
+
+	// Skip i occurrences
+	n := t.first(k)
+	for ; i > 0 && n != nil && n.key == k; i-- {
+		n = t.Successor(n)
+	}
+
+	if n == nil || n.key != k {
+		// No such occurrence
+		return nil, false
+	}
+
+	data := n.data
+	t.Delete(n)
+
+	return data, true
+}
+
+// DeleteAll deletes all nodes with key k and returns the number of deleted nodes.
+func (t *RBTree) DeleteAll(k KeyType) int {
+	c := 0
+	// Deletion can move the content of the successor to the deleted node,
+	// so the search of the first occurrence is repeated every time
+	for n := t.first(k); n != nil; n = t.first(k) {
+		t.Delete(n)
+		c++
+	}
+
+	return c
+}
+
+// first returns the first node in ascending order with key k or nil if there is no such node
+func (t *RBTree) first(k KeyType) *RBNode {
+	if lb := t.lowerBound(k); lb != nil && lb.key == k {
+		return lb
+	}
+
+	return nil
+}
+
+// lowerBound returns the first node in ascending order with key not less than k or nil if there is no such node
+func (t *RBTree) lowerBound(k KeyType) *RBNode {
+	var lb *RBNode
+	for n := t.root; n != nil; {
+		if k <= n.key {
+			lb = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return lb
+}
+
+// insertParent returns the parent for the new node with key k, the new node should be
+// placed after all nodes with the same key to keep the order of insertion
+func (t *RBTree) insertParent(k KeyType) *RBNode {
+	var p *RBNode
+	for n := t.root; n != nil; {
+		p = n
+		if k < n.key {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return p
+}
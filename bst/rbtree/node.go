@@ -94,3 +94,41 @@ func (n *RBNode) Value() any {
 
 	return n.data
 }
+
+// Left returns the left child of the node
+func (n *RBNode) Left() *RBNode {
+	if n == nil {
+		return nil
+	}
+	return n.left
+}
+
+// Right returns the right child of the node
+func (n *RBNode) Right() *RBNode {
+	if n == nil {
+		return nil
+	}
+	return n.right
+}
+
+// Parent returns the parent of the node
+func (n *RBNode) Parent() *RBNode {
+	if n == nil {
+		return nil
+	}
+	return n.parent
+}
+
+// SetChildren assigns left and right as children of the node. It does not check
+// the properties of the Red-Black tree, so it can be used to assemble a tree with
+// a predefined structure, use RBTree.SelfTest to check the result.
+func (n *RBNode) SetChildren(left, right *RBNode) {
+	n.left, n.right = left, right
+
+	if left != nil {
+		left.parent = n
+	}
+	if right != nil {
+		right.parent = n
+	}
+}
@@ -5,6 +5,23 @@ It supports standard tree procedures, such as: inserting and deleting nodes,
 finding nodes by given arbitrary key, finding the root, maximum and minimum
 nodes, finding the predecessor and successor of a node.
 
+The tree can work in the multiset mode created by NewMultiRBTree, in this mode
+several nodes with the same key are allowed. Nodes with equal keys are kept in
+order of their insertion, they can be counted, listed and deleted all at once or
+by the number of the occurrence.
+
+Nodes can be deleted by key without a prior search using RBTree.DeleteKey, the
+minimal and the maximal nodes are deleted by RBTree.DeleteMin and RBTree.DeleteMax,
+and RBTree.DeleteRange deletes all nodes in a range of keys in O(log n + m) time.
+
+RBTree.InsertFunc inserts nodes ordered by a comparison function instead of keys.
+
+A Cursor created by RBTree.NewCursor walks the tree in both directions and allows
+changing or deleting the current node during the walk.
+
+RBTree.Stats returns the shape of the tree - the height, depths of nodes, the
+imbalance ratio, the numbers of red and black nodes and the black-height.
+
 It supports colored output of graphical representation of the tree using ASCII
 graphics. For example, a tree with the keys 20, 10, 30, 5, 15, 25, 35, 8, 17,
 37, 33, 13, 2, 23, 27 added sequentially will create tree [like this].
@@ -18,11 +35,23 @@ import "fmt"
 
 type RBTree struct {
 	root	*RBNode
+	// Multiset mode - duplicate keys are allowed
+	multi	bool
 }
 func NewRBTree() *RBTree {
 	return &RBTree{}
 }
 
+// NewRBTreeFromRoot returns a tree with the root node root. The nodes should be linked
+// by RBNode.SetChildren beforehand, RBTree.SelfTest can be used to check the result.
+func NewRBTreeFromRoot(root *RBNode) *RBTree {
+	if root != nil {
+		root.parent = nil
+	}
+
+	return &RBTree{root: root}
+}
+
 // Delete deletes the node n from the tree keeping the properties of the Red-Black tree.
 func (t *RBTree) Delete(n *RBNode) *RBNode {
 	n = t.bstDelete(n)
@@ -46,6 +75,46 @@ func (t *RBTree) Insert(n *RBNode) *RBNode {
 	return n
 }
 
+// InsertFunc inserts node n into the tree ordered by the function before instead of
+// keys, before reports whether n should be placed before the node passed to it. Nodes
+// for which before returns false for each other are kept in order of insertion. The
+// tree filled by InsertFunc should be walked only by Min, Max, Successor and Predecessor,
+// because its nodes are not ordered by keys.
+func (t *RBTree) InsertFunc(n *RBNode, before func(node *RBNode) bool) *RBNode {
+	n.left, n.right, n.parent = nil, nil, nil
+	n.color = Red
+
+	if t.root == nil {
+		// The root is always black
+		n.color = Black
+		t.root = n
+
+		return n
+	}
+
+	// Search the parent of the new node, each node on the path is passed to before once
+	var p *RBNode
+	left := false
+	for c := t.root; c != nil; {
+		if p, left = c, before(c); left {
+			c = c.left
+		} else {
+			c = c.right
+		}
+	}
+
+	n.parent = p
+	if left {
+		p.left = n
+	} else {
+		p.right = n
+	}
+
+	t.fixupIns(n)
+
+	return n
+}
+
 func (t *RBTree) fixupIns(n *RBNode) {	//nolint:varnamelen	// variable name too obvious to make it longer
 	if n.parent.color == Black {
 		// Nothing to fixup
@@ -0,0 +1,69 @@
+package rbtree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/r-che/algorithms/bst/internal/scenario"
+)
+
+// scenarioTree applies operations of scenarios to the red-black tree
+type scenarioTree struct {
+	*RBTree
+}
+
+// Step applies the operation op to the tree
+func (t scenarioTree) Step(op scenario.Op) error {
+	if op.Name == "stats" {
+		st := t.Stats()
+
+		return scenario.CheckStats(map[string]int{
+			"nodes":		st.Nodes,
+			"height":		st.Height,
+			"min-leaf-depth":	st.MinLeafDepth,
+			"black-height":		st.BlackHeight,
+			"red":			st.Red,
+			"black":		st.Black,
+		}, op.Args)
+	}
+
+	keys, err := scenario.Keys[KeyType](op.Args)
+	if err != nil {
+		return err
+	}
+
+	switch op.Name {
+	case "insert":
+		for _, k := range keys {
+			if t.Insert(NewRBNode(k, nil)) == nil {
+				return fmt.Errorf("key %v already exists", k)
+			}
+		}
+	case "delete":
+		for _, k := range keys {
+			if !t.DeleteKey(k) {
+				return fmt.Errorf("key %v not found", k)
+			}
+		}
+	case "keys":
+		var got []KeyType
+		for n := t.Min(); n != nil; n = t.Successor(n) {
+			got = append(got, n.Key())
+		}
+		if fmt.Sprint(got) != fmt.Sprint(keys) {
+			return fmt.Errorf("tree contains keys %v, want - %v", got, keys)
+		}
+	case "check":
+		if _, err := t.SelfTest(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown operation")
+	}
+
+	return nil
+}
+
+func TestScenarios(t *testing.T) {
+	scenario.Run(t, "rb", func() scenario.Tree { return scenarioTree{NewRBTree()} })
+}
@@ -0,0 +1,148 @@
+package rbtree
+
+// subtree is a detached valid red-black subtree with the black root
+type subtree struct {
+	root	*RBNode
+	// Number of black nodes on each path from the root to leaves, including the root
+	bh	int
+}
+
+// blackHeight returns the number of black nodes on the path from n to the leftmost leaf
+func blackHeight(n *RBNode) int {
+	bh := 0
+	for ; n != nil; n = n.left {
+		if n.color == Black {
+			bh++
+		}
+	}
+
+	return bh
+}
+
+// detach makes the node n with the black-height bh the root of the separate subtree
+func detach(n *RBNode, bh int) subtree {
+	if n == nil {
+		return subtree{}
+	}
+
+	n.parent = nil
+
+	// The root of the subtree is always black
+	if n.color == Red {
+		n.color = Black
+		bh++
+	}
+
+	return subtree{root: n, bh: bh}
+}
+
+// split splits the subtree s into two subtrees: the first contains nodes whose keys
+// satisfy left, the second contains the rest of nodes. The function left must be true
+// for a prefix of keys in the ascending order. It takes O(log n) time, because the
+// costs of joins along the path from the root telescope to the height of s.
+func split(s subtree, left func(KeyType) bool) (subtree, subtree) {
+	n := s.root
+	if n == nil {
+		return subtree{}, subtree{}
+	}
+
+	// Children of the black root have the black-height less by one
+	l, r := detach(n.left, s.bh - 1), detach(n.right, s.bh - 1)
+
+	if left(n.key) {
+		rl, rr := split(r, left)
+		return join(l, n, rl), rr
+	}
+
+	ll, lr := split(l, left)
+
+	return ll, join(lr, n, r)
+}
+
+// join joins the subtree a, the node k and the subtree b, all keys of a must not be
+// greater than k and all keys of b must not be less than k. It takes O(|a.bh - b.bh| + 1)
+// time: k is attached to the spine of the higher subtree at the level of the lower one.
+func join(a subtree, k *RBNode, b subtree) subtree {
+	k.parent = nil
+
+	if a.bh == b.bh {
+		k.color = Black
+		k.left, k.right = a.root, b.root
+		setParent(a.root, k)
+		setParent(b.root, k)
+
+		return subtree{root: k, bh: a.bh + 1}
+	}
+
+	// Find the black node c with the black-height of the lower subtree on the right
+	// spine of a or on the left spine of b, p is its parent
+	high, low, right := a, b, true
+	if a.bh < b.bh {
+		high, low, right = b, a, false
+	}
+
+	var p *RBNode
+	c, bh := high.root, high.bh
+	for c != nil && (c.color == Red || bh != low.bh) {
+		if c.color == Black {
+			bh--
+		}
+
+		p = c
+		if right {
+			c = c.right
+		} else {
+			c = c.left
+		}
+	}
+
+	// Replace c by red k with children c and the lower subtree
+	k.color = Red
+	k.parent = p
+	if right {
+		k.left, k.right = c, low.root
+		p.right = k
+	} else {
+		k.left, k.right = low.root, c
+		p.left = k
+	}
+	setParent(c, k)
+	setParent(low.root, k)
+
+	// Fix the red-violation, if any, as after the insertion
+	t := &RBTree{root: high.root}
+	for n := k; n.parent != nil && n.parent.color == Red; {
+		f, u, g := determineRelatedness(n)
+
+		if u.Color() == Red {
+			// Only a repaint is required, continue from g
+			f.color, u.color, g.color = Black, Black, Red
+			n = g
+
+			continue
+		}
+
+		if straightLine(n, f, g) {
+			t.fixupBlackUncleStraight(f, g)
+		} else {
+			t.fixupBlackUncleAngle(n, f, g)
+		}
+
+		break
+	}
+
+	// The root was repainted to red - repaint it back, the black-height grows
+	if t.root.color == Red {
+		t.root.color = Black
+		high.bh++
+	}
+
+	return subtree{root: t.root, bh: high.bh}
+}
+
+// setParent sets p as the parent of n if n is not nil
+func setParent(n, p *RBNode) {
+	if n != nil {
+		n.parent = p
+	}
+}
@@ -0,0 +1,80 @@
+package rbtree
+
+import "math/bits"
+
+// Stats describes the shape of the tree. Depths are counted in nodes, the root has
+// the depth 1, so the height of the tree is the greatest depth of its nodes.
+type Stats struct {
+	// Number of nodes
+	Nodes		int
+	// Number of nodes on the longest path from the root to a leaf
+	Height		int
+	// Number of nodes on the shortest path from the root to a leaf
+	MinLeafDepth	int
+	// Average depth of nodes - the average number of nodes visited by a search
+	AvgDepth	float64
+	// Number of nodes on each level, Levels[0] is 1 - the root
+	Levels		[]int
+	// Ratio of the height to the height of the perfectly balanced tree with the
+	// same number of nodes, 1 means the best possible shape, for the red-black
+	// tree it does not exceed 2
+	Imbalance	float64
+	// Numbers of red and black nodes
+	Red, Black	int
+	// Number of black nodes on each path from the root to a leaf
+	BlackHeight	int
+}
+
+// Stats walks the tree level by level and returns its statistics. It takes O(n) time,
+// the empty tree has zero statistics. The black-height is the same as returned by
+// SelfTest if the tree is valid, the height does not exceed the doubled black-height.
+func (t *RBTree) Stats() Stats {
+	var st Stats
+	if t.root == nil {
+		return st
+	}
+
+	depthSum := 0
+	for level := []*RBNode{t.root}; len(level) != 0; {
+		st.Levels = append(st.Levels, len(level))
+		depth := len(st.Levels)
+
+		var next []*RBNode
+		for _, n := range level {
+			if n.color == Red {
+				st.Red++
+			} else {
+				st.Black++
+			}
+
+			if n.left == nil && n.right == nil && st.MinLeafDepth == 0 {
+				// The first leaf found is the nearest to the root
+				st.MinLeafDepth = depth
+			}
+
+			for _, c := range [...]*RBNode{n.left, n.right} {
+				if c != nil {
+					next = append(next, c)
+				}
+			}
+		}
+
+		st.Nodes += len(level)
+		depthSum += depth * len(level)
+		level = next
+	}
+
+	st.Height = len(st.Levels)
+	st.AvgDepth = float64(depthSum) / float64(st.Nodes)
+	// Perfectly balanced tree with n nodes has the height ceil(log2(n+1))
+	st.Imbalance = float64(st.Height) / float64(bits.Len(uint(st.Nodes)))
+
+	// All paths contain the same number of black nodes, so any of them can be used
+	for n := t.root; n != nil; n = n.left {
+		if n.color == Black {
+			st.BlackHeight++
+		}
+	}
+
+	return st
+}
@@ -0,0 +1,100 @@
+package rbtree
+
+import (
+	"reflect"
+	"testing"
+)
+
+// shape returns the height, the minimal depth of leafs and the sum of depths of
+// nodes of the subtree n located at the depth d, calculated recursively
+func (n *RBNode) shape(d int) (int, int, int) {
+	if n.left == nil && n.right == nil {
+		return d, d, d
+	}
+
+	height, minLeaf, sum := 0, 0, d
+	for _, c := range [...]*RBNode{n.left, n.right} {
+		if c == nil {
+			continue
+		}
+
+		h, ml, s := c.shape(d + 1)
+		if h > height {
+			height = h
+		}
+		if minLeaf == 0 || ml < minLeaf {
+			minLeaf = ml
+		}
+		sum += s
+	}
+
+	return height, minLeaf, sum
+}
+
+func TestStatsEmpty(t *testing.T) {
+	if st := NewRBTree().Stats(); !reflect.DeepEqual(st, Stats{}) {
+		t.Errorf("Stats of the empty tree returned %#v, want - zero statistics", st)
+	}
+}
+
+func TestStatsRedBlack(t *testing.T) {
+	for _, keys := range [][]KeyType{testKeys, sortedKeys()} {
+		tree, _ := newTreeSortedKeys(keys, false)
+		st := tree.Stats()
+
+		bh, err := tree.SelfTest()
+		if err != nil {
+			t.Errorf("self-test failed: %v", err)
+			t.FailNow()
+		}
+
+		if st.BlackHeight != bh {
+			t.Errorf("Stats returned black-height %d, SelfTest returned - %d", st.BlackHeight, bh)
+		}
+		if st.Red + st.Black != st.Nodes {
+			t.Errorf("Stats returned %d red and %d black nodes, total must be %d",
+				st.Red, st.Black, st.Nodes)
+		}
+		if st.Height > 2 * st.BlackHeight || st.Imbalance > 2 {
+			t.Errorf("Stats returned height %d, imbalance %f for the black-height %d",
+				st.Height, st.Imbalance, st.BlackHeight)
+		}
+	}
+}
+
+// sortedKeys returns keys from 0 to len(testKeys) in ascending order
+func sortedKeys() []KeyType {
+	keys := make([]KeyType, len(testKeys))
+	for i := range keys {
+		keys[i] = KeyType(i)
+	}
+
+	return keys
+}
+
+func TestStatsRandom(t *testing.T) {
+	tree, _ := newTreeSortedKeys(testKeys, false)
+	st := tree.Stats()
+
+	if st.Nodes != len(testKeys) {
+		t.Errorf("Stats returned %d nodes, want - %d", st.Nodes, len(testKeys))
+	}
+
+	nodes := 0
+	for _, c := range st.Levels {
+		nodes += c
+	}
+	if nodes != st.Nodes || len(st.Levels) != st.Height {
+		t.Errorf("Stats returned levels %v inconsistent with %d nodes and height %d",
+			st.Levels, st.Nodes, st.Height)
+	}
+
+	height, minLeaf, sum := tree.root.shape(1)
+	if st.Height != height || st.MinLeafDepth != minLeaf {
+		t.Errorf("Stats returned height %d, min leaf depth %d, want - %d, %d",
+			st.Height, st.MinLeafDepth, height, minLeaf)
+	}
+	if avg := float64(sum) / float64(st.Nodes); st.AvgDepth != avg {
+		t.Errorf("Stats returned average depth %f, want - %f", st.AvgDepth, avg)
+	}
+}
@@ -0,0 +1,138 @@
+package tdrbtree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+func newRBTree(keys []KeyType) *rbtree.RBTree {
+	tree := rbtree.NewRBTree()
+	for _, k := range keys {
+		tree.Insert(rbtree.NewRBNode(k, nil))
+	}
+
+	return tree
+}
+
+func newTDRBTree(keys []KeyType) *TDRBTree {
+	tree := NewTDRBTree()
+	for _, k := range keys {
+		tree.Insert(NewTDNode(k, nil))
+	}
+
+	return tree
+}
+
+func BenchmarkInsert(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	b.Run("TopDown", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			newTDRBTree(testKeys)
+		}
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			newRBTree(testKeys)
+		}
+	})
+}
+
+func BenchmarkSearch(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	b.Run("TopDown", func(b *testing.B) {
+		tree := newTDRBTree(testKeys)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			for _, k := range testKeys {
+				tree.Search(k)
+			}
+		}
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		tree := newRBTree(testKeys)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			for _, k := range testKeys {
+				tree.Search(k)
+			}
+		}
+	})
+}
+
+func BenchmarkDelete(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	b.Run("TopDown", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			tree := newTDRBTree(testKeys)
+			b.StartTimer()
+
+			for _, k := range testKeys {
+				tree.Delete(k)
+			}
+		}
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			tree := newRBTree(testKeys)
+			b.StartTimer()
+
+			for _, k := range testKeys {
+				tree.Delete(tree.Search(k))
+			}
+		}
+	})
+}
+
+func BenchmarkWalk(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	b.Run("TopDownAscend", func(b *testing.B) {
+		tree := newTDRBTree(testKeys)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			tree.Ascend(func(*TDNode) bool { return true })
+		}
+	})
+
+	b.Run("TopDownSuccessor", func(b *testing.B) {
+		tree := newTDRBTree(testKeys)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			for nd := tree.Min(); nd != nil; nd = tree.Successor(nd) {
+				// Just walk through all nodes
+			}
+		}
+	})
+
+	b.Run("RBTreeSuccessor", func(b *testing.B) {
+		tree := newRBTree(testKeys)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			for nd := tree.Min(); nd != nil; nd = tree.Successor(nd) {
+				// Just walk through all nodes
+			}
+		}
+	})
+}
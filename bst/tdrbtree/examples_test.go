@@ -0,0 +1,44 @@
+package tdrbtree
+
+import "fmt"
+
+//nolint:testableexamples
+func Example_treeCreation() {
+	// Create tree
+	tree := NewTDRBTree()
+
+	// Insert keys and data
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(NewTDNode(k, fmt.Sprintf("Value for key %v", k)))
+	}
+
+	// Print graphical representation of the tree
+	fmt.Print(tree)
+}
+
+func Example_treeWalking() {
+	// Tree creation
+	tree := NewTDRBTree()
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(NewTDNode(k, fmt.Sprintf("Value for key %v", k)))
+	}
+
+	// Delete some keys
+	for _, k := range []KeyType{20, 5, 37} {
+		fmt.Println("Deleted:", tree.Delete(k).Value())
+	}
+
+	// Walk the tree in ascending order
+	fmt.Print("Keys:")
+	tree.Ascend(func(n *TDNode) bool {
+		fmt.Print(" ", n.Key())
+		return true
+	})
+	fmt.Println()
+
+	// Output:
+	// Deleted: Value for key 20
+	// Deleted: Value for key 5
+	// Deleted: Value for key 37
+	// Keys: 2 8 10 13 15 17 23 25 27 30 33 35
+}
@@ -0,0 +1,112 @@
+package tdrbtree
+
+import "github.com/r-che/algorithms/bst/rbtree"
+
+// KeyType represents the key type of a tree node, it is the same as the key type of the red-black tree
+type KeyType = rbtree.KeyType
+
+// ColorType represents the color of a tree node
+type ColorType = rbtree.ColorType
+
+const (
+	Red			=	rbtree.Red
+	Black		=	rbtree.Black
+	FakeNode	=	rbtree.FakeNode
+)
+
+// Sides of children of a node
+const (
+	left	=	0
+	right	=	1
+)
+
+// TDNode implements a red-black tree node without the pointer to the parent
+type TDNode struct {
+	key		KeyType
+	// Left and right children
+	link	[2]*TDNode
+	color	ColorType
+
+	data	any
+}
+
+// NewTDNode creates a tree node with key k and associates the data with it
+func NewTDNode(k KeyType, data any) *TDNode {
+	return &TDNode{key: k, data: data}
+}
+
+func (n *TDNode) String() string {
+	if n == nil {
+		return Black.String() + "<nil>"
+	}
+
+	return n.color.String() + n.key.String()
+}
+
+// Key returns the key value of the node
+func (n *TDNode) Key() KeyType {
+	if n == nil {
+		return FakeNode
+	}
+	return n.key
+}
+
+// Value returns the data associated with the node
+func (n *TDNode) Value() any {
+	if n == nil {
+		return nil
+	}
+
+	return n.data
+}
+
+// Color returns the color of the node, leaves are always black
+func (n *TDNode) Color() ColorType {
+	if n == nil {
+		return Black
+	}
+
+	return n.color
+}
+
+// Left returns the left child of the node
+func (n *TDNode) Left() *TDNode {
+	if n == nil {
+		return nil
+	}
+	return n.link[left]
+}
+
+// Right returns the right child of the node
+func (n *TDNode) Right() *TDNode {
+	if n == nil {
+		return nil
+	}
+	return n.link[right]
+}
+
+// isRed returns true if the node is red, leaves are black
+func isRed(n *TDNode) bool {
+	return n != nil && n.color == Red
+}
+
+// rotate rotates the subtree with root n to the side d and returns the new root of the
+// subtree - the child of n on the opposite side. The old root becomes red, the new - black.
+func rotate(n *TDNode, d int) *TDNode {
+	s := n.link[1 - d]
+	n.link[1 - d] = s.link[d]
+	s.link[d] = n
+
+	n.color = Red
+	s.color = Black
+
+	return s
+}
+
+// rotateDouble rotates the child of n on the opposite side to the side opposite
+// to d and then rotates n to the side d, it returns the new root of the subtree
+func rotateDouble(n *TDNode, d int) *TDNode {
+	n.link[1 - d] = rotate(n.link[1 - d], 1 - d)
+
+	return rotate(n, d)
+}
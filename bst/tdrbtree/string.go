@@ -0,0 +1,30 @@
+package tdrbtree
+
+import "github.com/r-che/algorithms/bst/rbtree"
+
+// ToRBTree converts the tree to the red-black tree with the same structure and colors of
+// nodes. Keys and associated data are copied, t is not modified.
+func (t *TDRBTree) ToRBTree() *rbtree.RBTree {
+	if t.root == nil {
+		return rbtree.NewRBTree()
+	}
+
+	return rbtree.NewRBTreeFromRoot(t.root.toRBNode())
+}
+
+// toRBNode converts the subtree with root n
+func (n *TDNode) toRBNode() *rbtree.RBNode {
+	if n == nil {
+		return nil
+	}
+
+	rbn := rbtree.NewRBNode(n.key, n.data)
+	rbn.SetColor(n.color)
+	rbn.SetChildren(n.link[left].toRBNode(), n.link[right].toRBNode())
+
+	return rbn
+}
+
+func (t *TDRBTree) String() string {
+	return t.ToRBTree().String()
+}
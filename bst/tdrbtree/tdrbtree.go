@@ -0,0 +1,322 @@
+/*
+Package tdrbtree provides an example of a Red-black search tree implementation
+without parent pointers, using top-down insertion and deletion.
+
+Nodes of the tree keep only the key, the data, the color and two children. Both
+insertion and deletion make a single pass from the root to the leaves: insertion
+splits 4-nodes (nodes with two red children) on the way down and deletion pushes
+a red node down ahead of itself, so rebalancing never needs to go back up to the
+parent. Nodes are smaller than nodes of the [rbtree] package, and the absence of
+parent pointers makes it possible to share subtrees, e.g. to build persistent
+versions of the tree.
+
+Without parent pointers the successor and the predecessor of a node are found by
+a search from the root in O(log n), walking through all nodes is done by Ascend
+and Descend in O(n).
+
+It supports standard tree procedures, such as: inserting and deleting nodes,
+finding nodes by given arbitrary key, finding the root, maximum and minimum
+nodes, finding the predecessor and successor of a node. The tree can be converted
+to the [rbtree.RBTree] with the same structure, that is used for the graphical
+representation of the tree.
+
+[rbtree]: https://pkg.go.dev/github.com/r-che/algorithms/bst/rbtree
+*/
+package tdrbtree
+
+// TDRBTree implements a red-black tree with top-down insertion and deletion.
+type TDRBTree struct {
+	root	*TDNode
+}
+
+// NewTDRBTree returns new empty red-black tree.
+func NewTDRBTree() *TDRBTree {
+	return &TDRBTree{}
+}
+
+// Root returns the root node of the tree, or nil if the tree is empty.
+func (t *TDRBTree) Root() *TDNode {
+	return t.root
+}
+
+// Search returns a tree node with key k or nil if there is no such node.
+func (t *TDRBTree) Search(k KeyType) *TDNode {
+	n := t.root
+	for n != nil && n.key != k {
+		if k < n.key {
+			n = n.link[left]
+		} else {
+			n = n.link[right]
+		}
+	}
+
+	return n
+}
+
+// Min returns the tree node with the minimum key value.
+func (t *TDRBTree) Min() *TDNode {
+	return edge(t.root, left)
+}
+
+// Max returns the tree node with the maximum key value.
+func (t *TDRBTree) Max() *TDNode {
+	return edge(t.root, right)
+}
+
+// Successor returns the tree node following node n in a linear ordering of
+// tree nodes in ascending order of their keys. If there is none, i.e. n has a
+// maximal key value, then nil is returned. It takes O(log n) time.
+func (t *TDRBTree) Successor(n *TDNode) *TDNode {
+	return t.next(n.key, right)
+}
+
+// Predecessor returns the tree node following node n in a linear ordering of
+// tree nodes in descending order of their keys. If there is none, i.e. n has a
+// minimum key value, then nil is returned. It takes O(log n) time.
+func (t *TDRBTree) Predecessor(n *TDNode) *TDNode {
+	return t.next(n.key, left)
+}
+
+// Ascend calls f for each node of the tree in ascending order of keys until f returns false.
+func (t *TDRBTree) Ascend(f func(n *TDNode) bool) {
+	t.root.walk(right, f)
+}
+
+// Descend calls f for each node of the tree in descending order of keys until f returns false.
+func (t *TDRBTree) Descend(f func(n *TDNode) bool) {
+	t.root.walk(left, f)
+}
+
+// Insert inserts node n into the tree keeping the properties of the Red-Black tree. It returns
+// n or nil if the node with the same key already exists, in this case only colors of nodes
+// and the shape of the tree may be changed.
+func (t *TDRBTree) Insert(n *TDNode) *TDNode {	//nolint:varnamelen	// n is too obvious to make it longer
+	n.link = [2]*TDNode{}
+	n.color = Red
+
+	// Check for empty tree
+	if t.root == nil {
+		// Make the node a black root of the tree
+		t.root = n
+		n.color = Black
+
+		return n
+	}
+
+	// False root of the tree, the real root is its right child
+	head := TDNode{}
+	head.link[right] = t.root
+
+	//nolint:varnamelen	// variable names markings too obvious to make them longer
+	// gg - great-grandfather, g - grandfather, f - father, q - current node
+	gg, q := &head, t.root
+	var g, f *TDNode
+	d, last := left, left
+	inserted := false
+
+	for {
+		if q == nil {
+			// Insert the new node at the bottom
+			q = n
+			f.link[d] = q
+			inserted = true
+		} else if isRed(q.link[left]) && isRed(q.link[right]) {
+			// Split the 4-node by the color flip
+			q.color = Red
+			q.link[left].color, q.link[right].color = Black, Black
+		}
+
+		// Red-violation - red node attached to red parent, rotate g around f
+		if isRed(q) && isRed(f) {
+			gd := right
+			if gg.link[left] == g {
+				gd = left
+			}
+
+			if q == f.link[last] {
+				// q->f->g is a straight line
+				gg.link[gd] = rotate(g, 1 - last)
+			} else {
+				// q->f->g is angle
+				gg.link[gd] = rotateDouble(g, 1 - last)
+			}
+		}
+
+		if q.key == n.key {
+			// Inserted or already exists
+			break
+		}
+
+		// Go down
+		last, d = d, left
+		if q.key < n.key {
+			d = right
+		}
+
+		if g != nil {
+			gg = g
+		}
+		g, f, q = f, q, q.link[d]
+	}
+
+	// Update the root, it is always black
+	t.root = head.link[right]
+	t.root.color = Black
+
+	if !inserted {
+		return nil
+	}
+
+	return n
+}
+
+// Delete deletes the node with key k from the tree keeping the properties of the Red-Black
+// tree. It returns the deleted node or nil if there is no such node. If the deleted node
+// has two children, it is replaced by its predecessor, so the key and the data of the
+// predecessor node are moved to the node that had the key k.
+func (t *TDRBTree) Delete(k KeyType) *TDNode {
+	if t.root == nil {
+		return nil
+	}
+
+	// False root of the tree, the real root is its right child
+	head := TDNode{}
+	head.link[right] = t.root
+
+	//nolint:varnamelen	// variable names markings too obvious to make them longer
+	// g - grandfather, f - father, q - current node, found - node with key k
+	var g, f, found *TDNode
+	q := &head
+	d := right
+
+	// Go down to the predecessor of the found node or to the found node itself if it has
+	// no left child, keeping the current node red, so that it can be removed without fixup
+	for q.link[d] != nil {
+		last := d
+
+		g, f, q = f, q, q.link[d]
+		d = left
+		if q.key < k {
+			d = right
+		}
+
+		if q.key == k {
+			found = q
+		}
+
+		// Push the red node down
+		if isRed(q) || isRed(q.link[d]) {
+			continue
+		}
+
+		if isRed(q.link[1 - d]) {
+			// Red sibling of the next node - rotate q to make the next node's father red
+			f.link[last] = rotate(q, d)
+			f = f.link[last]
+
+			continue
+		}
+
+		// Brother of q
+		b := f.link[1 - last]
+		if b == nil {
+			continue
+		}
+
+		if !isRed(b.link[left]) && !isRed(b.link[right]) {
+			// Black brother with black children - color flip
+			f.color, b.color, q.color = Black, Red, Red
+
+			continue
+		}
+
+		// Black brother with a red child - rotate f and repaint
+		fd := right
+		if g.link[left] == f {
+			fd = left
+		}
+
+		if isRed(b.link[last]) {
+			// Nearside child of the brother is red
+			g.link[fd] = rotateDouble(f, last)
+		} else {
+			// Far side child of the brother is red
+			g.link[fd] = rotate(f, last)
+		}
+
+		// Ensure correct coloring
+		q.color, g.link[fd].color = Red, Red
+		g.link[fd].link[left].color, g.link[fd].link[right].color = Black, Black
+	}
+
+	if found != nil {
+		// Replace the found node by q and remove q, it has at most one child
+		found.key, q.key = q.key, found.key
+		found.data, q.data = q.data, found.data
+
+		child := q.link[left]
+		if child == nil {
+			child = q.link[right]
+		}
+
+		if f.link[right] == q {
+			f.link[right] = child
+		} else {
+			f.link[left] = child
+		}
+
+		q.link = [2]*TDNode{}
+	}
+
+	// Update the root, it is always black
+	t.root = head.link[right]
+	if t.root != nil {
+		t.root.color = Black
+	}
+
+	if found == nil {
+		return nil
+	}
+
+	return q
+}
+
+// next returns the node with the least key greater than k if d is right,
+// or the node with the greatest key less than k if d is left
+func (t *TDRBTree) next(k KeyType, d int) *TDNode {
+	var nx *TDNode
+	for n := t.root; n != nil; {
+		if d == right && k < n.key || d == left && k > n.key {
+			// n is a candidate, try to find closer one on the opposite side
+			nx = n
+			n = n.link[1 - d]
+		} else {
+			n = n.link[d]
+		}
+	}
+
+	return nx
+}
+
+// edge returns the last node of the subtree n going to the side d, i.e.
+// the minimum of the subtree if d is left and the maximum if d is right
+func edge(n *TDNode, d int) *TDNode {
+	if n == nil {
+		return nil
+	}
+
+	for n.link[d] != nil {
+		n = n.link[d]
+	}
+
+	return n
+}
+
+// walk calls f for each node of the subtree n in the order of the direction d until f returns false
+func (n *TDNode) walk(d int, f func(n *TDNode) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	return n.link[1 - d].walk(d, f) && f(n) && n.link[d].walk(d, f)
+}
@@ -0,0 +1,253 @@
+package tdrbtree
+
+import (
+	"testing"
+	"math/rand"
+	"reflect"
+	"sort"
+	"unsafe"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Trees are checked by the full self-test many times, so the number of keys is moderate
+	keysCount	=	4096
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2044
+
+	// Run self-test after each selfTestStep modifications
+	selfTestStep	=	64
+)
+
+func newTreeSortedKeys(keys []KeyType) (*TDRBTree, []KeyType) {
+	tree := NewTDRBTree()
+
+	// Insert all keys
+	for _, k := range keys {
+		tree.Insert(NewTDNode(k, int(k)))
+	}
+
+	// Make sorted copy of keys
+	sKeys := make([]KeyType, len(keys))
+	copy(sKeys, keys)
+	sort.Slice(sKeys, func(i, j int) bool { return sKeys[i] < sKeys[j] } )
+
+	return tree, sKeys
+}
+
+func TestEmpty(t *testing.T) {
+	tree := NewTDRBTree()
+
+	for name, n := range map[string]*TDNode{"Root": tree.Root(), "Min": tree.Min(), "Max": tree.Max(),
+			"Search": tree.Search(1), "Delete": tree.Delete(1)} {
+		if n != nil {
+			t.Errorf("%s returned non-nil value %v on empty tree", name, n)
+		}
+	}
+
+	if h, err := tree.SelfTest(); h != 0 || err != nil {
+		t.Errorf("SelfTest returned %d, %v on empty tree, want - 0, nil", h, err)
+	}
+
+	if s, want := tree.String(), rbtree.NewRBTree().String(); s != want {
+		t.Errorf("String returned %q on empty tree, want - %q", s, want)
+	}
+}
+
+func TestNodeSize(t *testing.T) {
+	if size, rbSize := unsafe.Sizeof(TDNode{}), unsafe.Sizeof(rbtree.RBNode{}); size >= rbSize {
+		t.Errorf("size of the node is %d bytes, want less than the size of the RBNode - %d bytes", size, rbSize)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := NewTDRBTree()
+
+	for i, k := range testKeys {
+		n := NewTDNode(k, int(k))
+		if ins := tree.Insert(n); ins != n {
+			t.Errorf("[%d] Insert returned %v, want - %v", i, ins, n)
+			t.FailNow()
+		}
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%d] SelfTest failed after insertion of %v: %v", i, k, err)
+			t.FailNow()
+		}
+	}
+
+	// Duplicates are not inserted
+	for i, k := range testKeys {
+		if ins := tree.Insert(NewTDNode(k, nil)); ins != nil {
+			t.Errorf("[%d] Insert returned %v, want - nil, because node with key %v should be already inserted", i, ins, k)
+			t.FailNow()
+		}
+	}
+
+	if _, err := tree.SelfTest(); err != nil {
+		t.Errorf("SelfTest failed after insertion of duplicates: %v", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newTreeSortedKeys(testKeys)
+
+	for i, k := range testKeys {
+		if n := tree.Search(k); n.Key() != k || n.Value() != int(k) {
+			t.Errorf("[%d] Search(%v) returned node %v with value %v", i, k, n, n.Value())
+			t.FailNow()
+		}
+	}
+
+	if n := tree.Search(MaxItem + 1); n != nil {
+		t.Errorf("Search of absent key returned %v", n)
+	}
+
+	if min, max := tree.Min().Key(), tree.Max().Key(); min != sKeys[0] || max != sKeys[len(sKeys)-1] {
+		t.Errorf("Min and Max returned %v, %v, want - %v, %v", min, max, sKeys[0], sKeys[len(sKeys)-1])
+	}
+}
+
+func TestWalk(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newTreeSortedKeys(testKeys)
+
+	// Successor and Predecessor
+	i := 0
+	for n := tree.Min(); n != nil; n = tree.Successor(n) {
+		if n.Key() != sKeys[i] {
+			t.Errorf("[%d] Successor returned %v, want - %v", i, n.Key(), sKeys[i])
+			t.FailNow()
+		}
+		i++
+	}
+
+	for n := tree.Max(); n != nil; n = tree.Predecessor(n) {
+		i--
+		if n.Key() != sKeys[i] {
+			t.Errorf("[%d] Predecessor returned %v, want - %v", i, n.Key(), sKeys[i])
+			t.FailNow()
+		}
+	}
+
+	// Ascend and Descend
+	var asc, desc []KeyType
+	tree.Ascend(func(n *TDNode) bool { asc = append(asc, n.Key()); return true })
+	tree.Descend(func(n *TDNode) bool { desc = append([]KeyType{n.Key()}, desc...); return len(desc) < selfTestStep })
+	if !reflect.DeepEqual(asc, sKeys) {
+		t.Errorf("Ascend returned %d keys, want - %d", len(asc), len(sKeys))
+	}
+	if !reflect.DeepEqual(desc, sKeys[len(sKeys) - selfTestStep:]) {
+		t.Errorf("Descend returned keys %v, want - %v", desc, sKeys[len(sKeys) - selfTestStep:])
+	}
+}
+
+func TestDelRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newTreeSortedKeys(testKeys)
+
+	for i := 0; len(sKeys) != 0; i++ {
+		// Get the random element from the sKeys
+		idx := rnd.Int() % len(sKeys)
+		k := sKeys[idx]
+		// Remove k from keys slice
+		sKeys = append(sKeys[:idx], sKeys[idx+1:]...)
+
+		if del := tree.Delete(k); del.Key() != k || del.Value() != int(k) {
+			t.Errorf("[%d] Delete(%v) returned node %v with value %v", i, k, del, del.Value())
+			t.FailNow()
+		}
+
+		if del := tree.Delete(k); del != nil {
+			t.Errorf("[%d] Delete(%v) of already deleted key returned %v", i, k, del)
+			t.FailNow()
+		}
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%d] SelfTest failed after deletion of %v: %v", i, k, err)
+			t.FailNow()
+		}
+		for _, sk := range sKeys {
+			if n := tree.Search(sk); n.Value() != int(sk) {
+				t.Errorf("[%d] key %v has value %v after deletion of %v", i, sk, n.Value(), k)
+				t.FailNow()
+			}
+		}
+	}
+
+	// Tree now must be empty
+	if root := tree.Root(); root != nil {
+		t.Errorf("tree must be empty (root == nil), but root is - %v", root)
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, selfTestStep, KeyType(MaxItem))
+
+	for i, test := range []struct {
+		breaker	func(tree *TDRBTree)
+		want	string
+	} {
+		{
+			func(tree *TDRBTree) { tree.root.color = Red },
+			"v#5",
+		}, {
+			func(tree *TDRBTree) { tree.Min().key = MaxItem + 1 },
+			"v#1",
+		}, {
+			func(tree *TDRBTree) {
+				// Repaint black leaf to red or red leaf to black
+				n := tree.Max()
+				n.color = !n.color
+			},
+			"v#",
+		}, {
+			func(tree *TDRBTree) {
+				// Make red-violation by adding red child to red node
+				var red *TDNode
+				tree.Ascend(func(n *TDNode) bool {
+					if n.color == Red && n.link[left] == nil {
+						red = n
+						return false
+					}
+					return true
+				})
+				red.link[left] = &TDNode{key: red.key - 1, color: Red}
+			},
+			"v#3",
+		},
+	} {
+		tree, _ := newTreeSortedKeys(testKeys)
+		test.breaker(tree)
+
+		h, err := tree.SelfTest()
+		if err == nil || h != 0 {
+			t.Errorf("[%d] SelfTest returned %d, %v on broken tree, want - 0, %s error", i, h, err, test.want)
+			continue
+		}
+
+		if msg := err.Error(); len(msg) < len(test.want) || msg[:len(test.want)] != test.want {
+			t.Errorf("[%d] SelfTest returned error %q, want - %s error", i, msg, test.want)
+		}
+	}
+}
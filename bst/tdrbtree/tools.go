@@ -0,0 +1,57 @@
+package tdrbtree
+
+import "fmt"
+
+// SelfTest performs a self-test of the red-black tree and returns the black-height,
+// and a description of the problem if detected. If an issuse is detected, the
+// black-height is zero.
+func (t *TDRBTree) SelfTest() (int, error) {
+	if t.root.Color() != Black {
+		return 0, fmt.Errorf("v#5: tree root (%v) is NOT black", t.root)
+	}
+
+	return t.root.test(nil, nil)
+}
+
+// test checks the subtree with root n, all keys of which must be in the range (lo, hi)
+func (n *TDNode) test(lo, hi *KeyType) (int, error) {
+	// No errors on empty sub-tree
+	if n == nil {
+		return 0, nil
+	}
+
+	if lo != nil && n.key <= *lo || hi != nil && n.key >= *hi {
+		return 0, fmt.Errorf("v#1: node %v violates the order of keys of its ancestors", n)
+	}
+
+	bhl, err := n.link[left].test(lo, &n.key)	// bhl - black height left
+	if err != nil {
+		return 0, err
+	}
+
+	bhr, err := n.link[right].test(&n.key, hi)
+	if err != nil {
+		return 0, err
+	}
+
+	// Test inequality of black heights of subtrees
+	if bhl != bhr {
+		return 0, fmt.Errorf(
+			"v#4: node %v - black-height left (%d) is not equal black height-right (%d)",
+			n, bhl, bhr)
+	}
+
+	// Test current node color
+	if n.color == Black {
+		bhl++
+	} else
+	// Red node, need to check children colors - both must be Black
+	if isRed(n.link[left]) || isRed(n.link[right]) {
+		return 0, fmt.Errorf(
+			"v#3: Red node (%v) has non-Black child (left: %v, right: %v)",
+			n, n.link[left], n.link[right])
+	}
+
+	// OK
+	return bhl, nil
+}
@@ -0,0 +1,57 @@
+package wbtree
+
+import "fmt"
+
+//nolint:testableexamples
+func Example_treeCreation() {
+	// Create tree
+	tree := NewWBTree()
+
+	// Insert keys and data
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	// Print graphical representation of the tree
+	fmt.Print(tree)
+}
+
+func Example_rankSelect() {
+	// Tree creation
+	tree := NewWBTree()
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	// Number of keys less than 24
+	fmt.Println("Rank of 24:", tree.Rank(24))
+
+	// Median key
+	fmt.Println("Median:", tree.Select(tree.Len() / 2).Key())
+
+	// Output:
+	// Rank of 24: 9
+	// Median: 20
+}
+
+func Example_setOperations() {
+	// Create two sets of IDs
+	evens, triples := NewWBTree(), NewWBTree()
+	for id := KeyType(0); id <= 20; id++ {
+		if id % 2 == 0 {
+			evens.Insert(id, nil)
+		}
+		if id % 3 == 0 {
+			triples.Insert(id, nil)
+		}
+	}
+
+	fmt.Println("Union:       ", Union(evens, triples).Keys())
+	fmt.Println("Intersection:", Intersection(evens, triples).Keys())
+	fmt.Println("Difference:  ", Difference(evens, triples).Keys())
+
+	// Output:
+	// Union:        [0 2 3 4 6 8 9 10 12 14 15 16 18 20]
+	// Intersection: [0 6 12 18]
+	// Difference:   [2 4 8 10 14 16 20]
+}
@@ -0,0 +1,281 @@
+package wbtree
+
+// Insert inserts key k with associated data into the tree keeping the properties
+// of the weight-balanced tree. It returns false if the key already exists in
+// the tree, in this case the tree is not modified.
+func (t *WBTree) Insert(k KeyType, data any) bool {
+	root, inserted := insert(t.root, k, data)
+	if !inserted {
+		// Already exists
+		return false
+	}
+
+	t.root = root
+
+	return true
+}
+
+// Delete deletes the node with key k from the tree keeping the properties of the
+// weight-balanced tree. It returns the deleted node or nil if there is no such node.
+func (t *WBTree) Delete(k KeyType) *WBNode {
+	root, del := remove(t.root, k)
+	if del == nil {
+		// Nothing to delete
+		return nil
+	}
+
+	t.root = root
+
+	return del
+}
+
+// Split splits the tree into two trees - with keys less than k and with keys greater
+// than k. It also returns the node with key k or nil if there is no such key.
+// The tree t is not modified.
+func (t *WBTree) Split(k KeyType) (*WBTree, *WBNode, *WBTree) {
+	l, n, r := split(t.root, k)
+
+	return &WBTree{root: l}, n, &WBTree{root: r}
+}
+
+// Union returns a new tree that contains keys from both trees t1 and t2. If a key
+// exists in both trees, the data associated with the key in t1 is used.
+// Source trees are not modified.
+func Union(t1, t2 *WBTree) *WBTree {
+	return &WBTree{root: union(t1.root, t2.root)}
+}
+
+// Intersection returns a new tree that contains keys existing in both trees t1 and t2
+// with data associated with the keys in t1. Source trees are not modified.
+func Intersection(t1, t2 *WBTree) *WBTree {
+	return &WBTree{root: intersection(t1.root, t2.root)}
+}
+
+// Difference returns a new tree that contains keys of t1 that do not exist in t2.
+// Source trees are not modified.
+func Difference(t1, t2 *WBTree) *WBTree {
+	return &WBTree{root: difference(t1.root, t2.root)}
+}
+
+//
+// Join-based algorithms
+//
+
+// balanced returns true if subtrees with weights wl and wr can be children of the same node
+func balanced(wl, wr int) bool {
+	w := Alpha * float64(wl + wr)
+	return float64(wl) >= w && float64(wr) >= w
+}
+
+// join returns the tree that contains all nodes of l, the key k and all nodes
+// of r. All keys of l must be less than k and all keys of r greater than k.
+func join(l *WBNode, k KeyType, data any, r *WBNode) *WBNode {
+	switch wl, wr := l.weight(), r.weight(); {
+	case balanced(wl, wr):
+		return newWBNode(l, k, data, r)
+	case wl > wr:
+		return joinRight(l, k, data, r)
+	default:
+		return joinLeft(l, k, data, r)
+	}
+}
+
+// joinRight joins the heavy tree l with the light tree r, going down the right spine of l
+func joinRight(l *WBNode, k KeyType, data any, r *WBNode) *WBNode {
+	if balanced(l.weight(), r.weight()) {
+		return newWBNode(l, k, data, r)
+	}
+
+	// Join r with the right subtree of l
+	c := joinRight(l.right, k, data, r)
+
+	switch {
+	// No rebalancing required
+	case balanced(l.left.weight(), c.weight()):
+		return newWBNode(l.left, l.key, l.data, c)
+
+	// Single rotation is enough
+	case balanced(l.left.weight(), c.left.weight()) &&
+			balanced(l.left.weight() + c.left.weight(), c.right.weight()):
+		return rotateLeft(l.left, l.key, l.data, c)
+
+	// Double rotation is required
+	default:
+		return rotateLeft(l.left, l.key, l.data, rotateRight(c.left, c.key, c.data, c.right))
+	}
+}
+
+// joinLeft joins the heavy tree r with the light tree l, going down the left spine of r
+func joinLeft(l *WBNode, k KeyType, data any, r *WBNode) *WBNode {
+	if balanced(l.weight(), r.weight()) {
+		return newWBNode(l, k, data, r)
+	}
+
+	// Join l with the left subtree of r
+	c := joinLeft(l, k, data, r.left)
+
+	switch {
+	// No rebalancing required
+	case balanced(c.weight(), r.right.weight()):
+		return newWBNode(c, r.key, r.data, r.right)
+
+	// Single rotation is enough
+	case balanced(c.right.weight(), r.right.weight()) &&
+			balanced(c.left.weight(), c.right.weight() + r.right.weight()):
+		return rotateRight(c, r.key, r.data, r.right)
+
+	// Double rotation is required
+	default:
+		return rotateRight(rotateLeft(c.left, c.key, c.data, c.right), r.key, r.data, r.right)
+	}
+}
+
+// rotateLeft returns the result of the left rotation of the node with children l and r and key k,
+// r becomes the root of the subtree
+func rotateLeft(l *WBNode, k KeyType, data any, r *WBNode) *WBNode {
+	return newWBNode(newWBNode(l, k, data, r.left), r.key, r.data, r.right)
+}
+
+// rotateRight returns the result of the right rotation of the node with children l and r and key k,
+// l becomes the root of the subtree
+func rotateRight(l *WBNode, k KeyType, data any, r *WBNode) *WBNode {
+	return newWBNode(l.left, l.key, l.data, newWBNode(l.right, k, data, r))
+}
+
+// split returns the tree with keys of n less than k, the node with key k
+// (or nil if there is no such key) and the tree with keys greater than k
+func split(n *WBNode, k KeyType) (*WBNode, *WBNode, *WBNode) {
+	if n == nil {
+		return nil, nil, nil
+	}
+
+	switch {
+	case k < n.key:
+		l, found, r := split(n.left, k)
+		return l, found, join(r, n.key, n.data, n.right)
+	case k > n.key:
+		l, found, r := split(n.right, k)
+		return join(n.left, n.key, n.data, l), found, r
+	default:
+		return n.left, n, n.right
+	}
+}
+
+// splitLast returns the tree n without the node with the maximum key and this node
+func splitLast(n *WBNode) (*WBNode, *WBNode) {
+	if n.right == nil {
+		return n.left, n
+	}
+
+	r, last := splitLast(n.right)
+
+	return join(n.left, n.key, n.data, r), last
+}
+
+// join2 returns the tree that contains all nodes of l and r, all keys of l must be less than keys of r
+func join2(l, r *WBNode) *WBNode {
+	if l == nil {
+		return r
+	}
+
+	l, last := splitLast(l)
+
+	return join(l, last.key, last.data, r)
+}
+
+// insert returns the tree n with inserted key k and true, or n and false if k already exists
+func insert(n *WBNode, k KeyType, data any) (*WBNode, bool) {
+	if n == nil {
+		return newWBNode(nil, k, data, nil), true
+	}
+
+	switch {
+	case k < n.key:
+		l, inserted := insert(n.left, k, data)
+		if !inserted {
+			return n, false
+		}
+		return join(l, n.key, n.data, n.right), true
+	case k > n.key:
+		r, inserted := insert(n.right, k, data)
+		if !inserted {
+			return n, false
+		}
+		return join(n.left, n.key, n.data, r), true
+	default:
+		// Already exists
+		return n, false
+	}
+}
+
+// remove returns the tree n without key k and the deleted node, or n and nil if there is no such key
+func remove(n *WBNode, k KeyType) (*WBNode, *WBNode) {
+	if n == nil {
+		return nil, nil
+	}
+
+	switch {
+	case k < n.key:
+		l, del := remove(n.left, k)
+		if del == nil {
+			return n, nil
+		}
+		return join(l, n.key, n.data, n.right), del
+	case k > n.key:
+		r, del := remove(n.right, k)
+		if del == nil {
+			return n, nil
+		}
+		return join(n.left, n.key, n.data, r), del
+	default:
+		return join2(n.left, n.right), n
+	}
+}
+
+func union(n1, n2 *WBNode) *WBNode {
+	switch {
+	case n1 == nil:
+		return n2
+	case n2 == nil:
+		return n1
+	}
+
+	l1, found, r1 := split(n1, n2.key)
+
+	// Data from the first tree takes precedence
+	data := n2.data
+	if found != nil {
+		data = found.data
+	}
+
+	return join(union(l1, n2.left), n2.key, data, union(r1, n2.right))
+}
+
+func intersection(n1, n2 *WBNode) *WBNode {
+	if n1 == nil || n2 == nil {
+		return nil
+	}
+
+	l1, found, r1 := split(n1, n2.key)
+	l, r := intersection(l1, n2.left), intersection(r1, n2.right)
+
+	if found == nil {
+		// The key exists only in the second tree
+		return join2(l, r)
+	}
+
+	return join(l, found.key, found.data, r)
+}
+
+func difference(n1, n2 *WBNode) *WBNode {
+	switch {
+	case n1 == nil:
+		return nil
+	case n2 == nil:
+		return n1
+	}
+
+	l1, _, r1 := split(n1, n2.key)
+
+	return join2(difference(l1, n2.left), difference(r1, n2.right))
+}
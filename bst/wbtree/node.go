@@ -0,0 +1,70 @@
+package wbtree
+
+import (
+	"github.com/r-che/algorithms/bst/rbtree"
+)
+
+// KeyType represents the key type of a weight-balanced tree node, it is
+// the same as the key type of the red-black tree
+type KeyType = rbtree.KeyType
+
+// WBNode implements a weight-balanced tree node. Nodes are never modified after
+// creation, so they can be safely shared between several trees.
+type WBNode struct {
+	key		KeyType
+	left	*WBNode
+	right	*WBNode
+
+	// Number of nodes in the subtree with root in this node
+	size	int
+
+	data	any
+}
+
+// newWBNode creates a node with children l and r, key k and data
+func newWBNode(l *WBNode, k KeyType, data any, r *WBNode) *WBNode {
+	return &WBNode{
+		key:	k,
+		left:	l,
+		right:	r,
+		size:	l.Size() + r.Size() + 1,
+		data:	data,
+	}
+}
+
+func (n *WBNode) String() string {
+	if n == nil {
+		return "<nil>"
+	}
+	return n.key.String()
+}
+
+// Key returns the key value of the node
+func (n *WBNode) Key() KeyType {
+	if n == nil {
+		return rbtree.FakeNode
+	}
+	return n.key
+}
+
+// Value returns the data associated with the node
+func (n *WBNode) Value() any {
+	if n == nil {
+		return nil
+	}
+
+	return n.data
+}
+
+// Size returns the number of nodes in the subtree with root n
+func (n *WBNode) Size() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// weight returns the weight of the subtree with root n - number of nodes + 1
+func (n *WBNode) weight() int {
+	return n.Size() + 1
+}
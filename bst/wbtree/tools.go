@@ -0,0 +1,49 @@
+package wbtree
+
+import "fmt"
+
+// SelfTest performs a self-test of the weight-balanced tree and returns the height
+// of the tree, and a description of the problem if detected. If an issue is
+// detected, the height is zero.
+func (t *WBTree) SelfTest() (int, error) {
+	return t.root.test(nil, nil)
+}
+
+// test checks the subtree with root n, all keys of which must be in the range (lo, hi)
+func (n *WBNode) test(lo, hi *KeyType) (int, error) {
+	// No errors on empty sub-tree
+	if n == nil {
+		return 0, nil
+	}
+
+	if lo != nil && n.key <= *lo || hi != nil && n.key >= *hi {
+		return 0, fmt.Errorf("v#1: node %v violates the order of keys of its ancestors", n)
+	}
+
+	hl, err := n.left.test(lo, &n.key)	// hl - height left
+	if err != nil {
+		return 0, err
+	}
+
+	hr, err := n.right.test(&n.key, hi)
+	if err != nil {
+		return 0, err
+	}
+
+	// Check for correct size of the subtree
+	if size := n.left.Size() + n.right.Size() + 1; n.size != size {
+		return 0, fmt.Errorf("v#2: node %v has size %d, want - %d", n, n.size, size)
+	}
+
+	// Check for weight balance
+	if !balanced(n.left.weight(), n.right.weight()) {
+		return 0, fmt.Errorf("v#3: node %v is not weight-balanced: weight left - %d, weight right - %d",
+			n, n.left.weight(), n.right.weight())
+	}
+
+	// OK
+	if hl > hr {
+		return hl + 1, nil
+	}
+	return hr + 1, nil
+}
@@ -0,0 +1,159 @@
+/*
+Package wbtree provides an example of a weight-balanced (BB[α]) search tree implementation.
+
+Each node of the tree keeps the size of its subtree, the tree is balanced
+when the weight (size + 1) of each subtree is at least α of the weight of its
+parent. Sizes of subtrees make it possible to find the rank of a key and to
+select a key by its rank in O(log n).
+
+All modifications of the tree are implemented by the join operation, that
+gives efficient set algebra: Union, Intersection and Difference of trees with
+sizes m <= n take O(m log(n/m + 1)) time. Nodes are never modified after
+creation, so trees produced by set operations share nodes with their sources
+and source trees are left intact.
+
+It supports standard tree procedures, such as: inserting and deleting keys,
+finding nodes by given arbitrary key, finding the root, maximum and minimum
+nodes, splitting the tree by a key.
+
+It supports colored output of graphical representation of the tree using ASCII
+graphics. For example, a tree with the keys 20, 10, 30, 5, 15, 25, 35, 8, 17,
+37, 33, 13, 2, 23, 27 added sequentially will look like this:
+
+                             20
+                ____________/  \____________
+               /                            \
+             10                              30
+        ____/  \____                    ____/  \____
+       /            \                  /            \
+     5               15              25              35
+    /  \            /  \            /  \            /  \
+   /    \          /    \          /    \          /    \
+ 2       8       13      17      23      27      33      37
+
+*/
+package wbtree
+
+// Alpha is the balance parameter of the tree: the weight of each subtree is
+// at least Alpha of the weight of its parent. Join-based algorithms require
+// Alpha <= 1 - 1/sqrt(2).
+const Alpha = 0.29
+
+// WBTree implements a weight-balanced search tree.
+type WBTree struct {
+	root	*WBNode
+}
+
+// NewWBTree returns new empty weight-balanced tree.
+func NewWBTree() *WBTree {
+	return &WBTree{}
+}
+
+// Root returns the root node of the tree, or nil if the tree is empty.
+func (t *WBTree) Root() *WBNode {
+	return t.root
+}
+
+// Len returns the number of keys in the tree.
+func (t *WBTree) Len() int {
+	return t.root.Size()
+}
+
+// Search returns a tree node with key k or nil if there is no such node.
+func (t *WBTree) Search(k KeyType) *WBNode {
+	n := t.root
+	for n != nil && n.key != k {
+		if k < n.key {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	return n
+}
+
+// Min returns the tree node with the minimum key value.
+func (t *WBTree) Min() *WBNode {
+	if t.root == nil {
+		return nil
+	}
+	n := t.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// Max returns the tree node with the maximum key value.
+func (t *WBTree) Max() *WBNode {
+	if t.root == nil {
+		return nil
+	}
+	n := t.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// Rank returns the number of keys in the tree that are less than k.
+func (t *WBTree) Rank(k KeyType) int {
+	rank := 0
+	for n := t.root; n != nil; {
+		if k <= n.key {
+			n = n.left
+		} else {
+			// All keys of the left subtree and the node itself are less than k
+			rank += n.left.Size() + 1
+			n = n.right
+		}
+	}
+
+	return rank
+}
+
+// Select returns the node with the i-th smallest key (counting from 0)
+// or nil if i is out of range [0, Len()).
+func (t *WBTree) Select(i int) *WBNode {
+	if i < 0 || i >= t.Len() {
+		return nil
+	}
+
+	n := t.root
+	for {
+		switch ls := n.left.Size(); {
+		case i < ls:
+			n = n.left
+		case i == ls:
+			return n
+		default:
+			i -= ls + 1
+			n = n.right
+		}
+	}
+}
+
+// Ascend calls f for each node of the tree in ascending order of keys until f returns false.
+func (t *WBTree) Ascend(f func(n *WBNode) bool) {
+	t.root.ascend(f)
+}
+
+// Keys returns all keys of the tree in ascending order.
+func (t *WBTree) Keys() []KeyType {
+	keys := make([]KeyType, 0, t.Len())
+	t.Ascend(func(n *WBNode) bool {
+		keys = append(keys, n.key)
+		return true
+	})
+
+	return keys
+}
+
+func (n *WBNode) ascend(f func(n *WBNode) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	return n.left.ascend(f) && f(n) && n.right.ascend(f)
+}
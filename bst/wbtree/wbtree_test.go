@@ -0,0 +1,416 @@
+package wbtree
+
+import (
+	"fmt"
+	"testing"
+	"math/rand"
+	"sort"
+
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Set operations are tested on overlapping parts of thousands of keys
+	keysCount	=	8192
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2028
+
+	// Self-test checks weights of all nodes, so it is run once per selfTestStep modifications
+	selfTestStep	=	64
+)
+
+func TestEmpty(t *testing.T) {
+	tree := NewWBTree()
+
+	if n := tree.Root(); n != nil {
+		t.Errorf("Root returned non-nil value %v (%#v) on empty tree", n, n)
+	}
+
+	if n := tree.Min(); n != nil {
+		t.Errorf("Min returned non-nil value %v (%#v) on empty tree", n, n)
+	}
+
+	if n := tree.Max(); n != nil {
+		t.Errorf("Max returned non-nil value %v (%#v) on empty tree", n, n)
+	}
+
+	if n := tree.Select(0); n != nil {
+		t.Errorf("Select returned non-nil value %v (%#v) on empty tree", n, n)
+	}
+
+	if n := tree.Delete(1); n != nil {
+		t.Errorf("Delete returned non-nil value %v (%#v) on empty tree", n, n)
+	}
+
+	if h, err := tree.SelfTest(); h != 0 || err != nil {
+		t.Errorf("SelfTest returned %d, %v on empty tree, want - 0, nil", h, err)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := NewWBTree()
+
+	for i, k := range testKeys {
+		if !tree.Insert(k, nil) {
+			t.Errorf("[%d] WBTree.Insert returned false on unique key %v", i, k)
+			t.FailNow()
+		}
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%d] weight-balanced tree structure issue after insertion of %v: %v", i, k, err)
+			t.FailNow()
+		}
+	}
+
+	if tree.Len() != len(testKeys) {
+		t.Errorf("WBTree.Len returned %d, want - %d", tree.Len(), len(testKeys))
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+	// Sorted keys are the worst case for non-balanced trees
+	tree := NewWBTree()
+	for k := KeyType(0); k < keysCount; k++ {
+		tree.Insert(k, nil)
+	}
+
+	h, err := tree.SelfTest()
+	if err != nil {
+		t.Errorf("weight-balanced tree structure issue: %v", err)
+	}
+
+	// Height of the weight-balanced tree is bounded by log(n)/log(1/(1-Alpha)) ~ 2.9 * log2(n)
+	if maxHeight := 3 * 14; h > maxHeight {
+		t.Errorf("height of the tree with %d sorted keys is %d, want - no more than %d", keysCount, h, maxHeight)
+	}
+}
+
+func TestInsertDupes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, _ := newTreeSortedKeys(testKeys)
+
+	for i, k := range testKeys {
+		if tree.Insert(k, nil) {
+			t.Errorf("[%d] WBTree.Insert returned true, want - false," +
+				" because node with key %v should be already inserted", i, k)
+			t.FailNow()
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, _ := newTreeSortedKeys(testKeys)
+
+	for _, k := range testKeys {
+		n := tree.Search(k)
+		if n == nil {
+			t.Errorf("key %s was added but not found in the tree", k)
+			t.FailNow()
+		}
+
+		if n.Value() != k*2 {
+			t.Errorf("node %v has value %v, want - %v", n, n.Value(), k*2)
+			t.FailNow()
+		}
+	}
+}
+
+func TestRankSelect(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newTreeSortedKeys(testKeys)
+
+	for i, k := range sKeys {
+		if r := tree.Rank(k); r != i {
+			t.Errorf("Rank(%v) returned %d, want - %d", k, r, i)
+			t.FailNow()
+		}
+
+		// Rank of the absent key
+		if r := tree.Rank(k+1); (i == len(sKeys)-1 || sKeys[i+1] != k+1) && r != i+1 {
+			t.Errorf("Rank(%v) returned %d, want - %d", k+1, r, i+1)
+			t.FailNow()
+		}
+
+		if n := tree.Select(i); n.Key() != k {
+			t.Errorf("Select(%d) returned %v, want - %v", i, n, k)
+			t.FailNow()
+		}
+	}
+
+	for _, i := range []int{-1, len(sKeys)} {
+		if n := tree.Select(i); n != nil {
+			t.Errorf("Select(%d) returned %v, want - nil", i, n)
+		}
+	}
+
+	if tree.Min().Key() != sKeys[0] || tree.Max().Key() != sKeys[len(sKeys)-1] {
+		t.Errorf("Min/Max returned %v/%v, want - %v/%v", tree.Min(), tree.Max(), sKeys[0], sKeys[len(sKeys)-1])
+	}
+}
+
+func TestDelRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newTreeSortedKeys(testKeys)
+
+	for i := 0; len(sKeys) != 0; i++ {
+		// Get the random element from the sKeys
+		idx := rnd.Int() % len(sKeys)
+		k := sKeys[idx]
+		// Remove k from keys slice
+		sKeys = append(sKeys[:idx], sKeys[idx+1:]...)
+
+		if n := tree.Delete(k); n.Key() != k {
+			t.Errorf("[%d] Delete(%v) returned %v", i, k, n)
+			t.FailNow()
+		}
+
+		if n := tree.Delete(k); n != nil {
+			t.Errorf("[%d] repeated Delete(%v) returned %v, want - nil", i, k, n)
+			t.FailNow()
+		}
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%d] weight-balanced tree structure issue after deletion of %v: %v", i, k, err)
+			t.FailNow()
+		}
+
+		if keys := tree.Keys(); fmt.Sprint(keys) != fmt.Sprint(sKeys) {
+			t.Errorf("[%d] tree keys after deletion are not equal to expected", i)
+			t.FailNow()
+		}
+	}
+
+	// Tree now must be empty
+	if root := tree.Root(); root != nil {
+		t.Errorf("tree must be empty (root == nil), but root is - %v", root)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newTreeSortedKeys(testKeys)
+
+	for _, i := range []int{0, 1, len(sKeys)/3, len(sKeys)/2, len(sKeys)-1} {
+		for _, k := range []KeyType{sKeys[i], sKeys[i]+1} {
+			l, n, r := tree.Split(k)
+
+			// Expected number of keys in the left tree
+			nl := sort.Search(len(sKeys), func(j int) bool { return sKeys[j] >= k })
+			found := nl < len(sKeys) && sKeys[nl] == k
+
+			switch {
+			case found && n.Key() != k, !found && n != nil:
+				t.Errorf("Split(%v) returned node %v, key found - %t", k, n, found)
+			case fmt.Sprint(l.Keys()) != fmt.Sprint(sKeys[:nl]):
+				t.Errorf("Split(%v) returned incorrect left tree", k)
+			case found && fmt.Sprint(r.Keys()) != fmt.Sprint(sKeys[nl+1:]),
+				 !found && fmt.Sprint(r.Keys()) != fmt.Sprint(sKeys[nl:]):
+				t.Errorf("Split(%v) returned incorrect right tree", k)
+			}
+
+			for _, part := range []*WBTree{l, r} {
+				if _, err := part.SelfTest(); err != nil {
+					t.Errorf("Split(%v) returned tree with structure issue: %v", k, err)
+				}
+			}
+		}
+	}
+
+	// Source tree is not modified
+	if fmt.Sprint(tree.Keys()) != fmt.Sprint(sKeys) {
+		t.Errorf("source tree was modified by Split")
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	// Split keys to three overlapping sets with different sizes
+	for _, test := range []struct {
+		k1, k2	[]KeyType
+	} {
+		{ testKeys[:6000], testKeys[4000:] },
+		{ testKeys[:100], testKeys[50:] },
+		{ testKeys[:5000], testKeys[5000:] },
+		{ testKeys, testKeys[:1] },
+		{ testKeys, nil },
+		{ nil, testKeys[:10] },
+	} {
+		t1, t2 := NewWBTree(), NewWBTree()
+		in1, in2 := map[KeyType]bool{}, map[KeyType]bool{}
+		for _, k := range test.k1 {
+			t1.Insert(k, 1)
+			in1[k] = true
+		}
+		for _, k := range test.k2 {
+			t2.Insert(k, 2)
+			in2[k] = true
+		}
+
+		// Expected results
+		var union, inter, diff []KeyType
+		for _, k := range testKeys {
+			switch {
+			case in1[k] && in2[k]:
+				inter = append(inter, k)
+				union = append(union, k)
+			case in1[k]:
+				diff = append(diff, k)
+				union = append(union, k)
+			case in2[k]:
+				union = append(union, k)
+			}
+		}
+
+		for _, res := range []struct {
+			op		string
+			tree	*WBTree
+			want	[]KeyType
+		} {
+			{ "Union", Union(t1, t2), union },
+			{ "Intersection", Intersection(t1, t2), inter },
+			{ "Difference", Difference(t1, t2), diff },
+		} {
+			sort.Slice(res.want, func(i, j int) bool { return res.want[i] < res.want[j] } )
+			if res.want == nil {
+				res.want = []KeyType{}
+			}
+
+			if fmt.Sprint(res.tree.Keys()) != fmt.Sprint(res.want) {
+				t.Errorf("%s of trees with %d and %d keys returned %d keys, want - %d",
+					res.op, t1.Len(), t2.Len(), res.tree.Len(), len(res.want))
+			}
+
+			if _, err := res.tree.SelfTest(); err != nil {
+				t.Errorf("%s returned tree with structure issue: %v", res.op, err)
+			}
+
+			// Data from the first tree takes precedence
+			res.tree.Ascend(func(n *WBNode) bool {
+				if in1[n.Key()] && n.Value() != 1 {
+					t.Errorf("%s returned node %v with data %v, want - 1", res.op, n, n.Value())
+					return false
+				}
+				return true
+			})
+		}
+
+		// Source trees are not modified
+		if t1.Len() != len(in1) || t2.Len() != len(in2) {
+			t.Errorf("source trees were modified by set operations")
+		}
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, 100, KeyType(MaxItem))
+
+	for i, breaker := range []func(t *WBTree) {
+		// Wrong order of keys
+		func(t *WBTree) { t.root.key = t.Max().key + 1 },
+		// Wrong size
+		func(t *WBTree) { t.root.size++ },
+		// Unbalanced tree
+		func(t *WBTree) { t.root = newWBNode(t.root, t.Max().key + 1, nil, nil) },
+	} {
+		tree, _ := newTreeSortedKeys(testKeys)
+
+		// Apply test to tree
+		breaker(tree)
+
+		h, err := tree.SelfTest()
+		switch {
+		case err == nil:
+			t.Errorf("[%d] self-test does not return expected issue", i)
+		case h != 0:
+			t.Errorf("returned height of the invalid tree is not zero - %d", h)
+		default:
+			t.Log("Expected self-test error:", err)
+		}
+	}
+}
+
+func TestStringFilled(t *testing.T) {
+	//nolint:lll // Predefined colored tree
+	want := fmt.Sprintf(
+`                                                                       %[1]s30 %[2]s                                              ` + `
+                              ________________________________________/   \____________________                         ` + `
+                             /                                                                 \                        ` + `
+                          %[1]s20 %[2]s                                                                   %[1]s35 %[2]s                     ` + `
+                    _____/   \_______________                                        __________/   \__________          ` + `
+                   /                         \                                      /                         \         ` + `
+                %[1]s5  %[2]s                           %[1]s25 %[2]s                                %[1]s32 %[2]s                           %[1]s390%[2]s      ` + `
+          _____/   \                    _____/   \__________                    /   \_____               _____/   \     ` + `
+         /          \                  /                    \                  /          \             /          \    ` + `
+      %[1]s3  %[2]s            %[1]s10 %[2]s            %[1]s22 %[2]s                      %[1]s28 %[2]s            %[1]s31 %[2]s            %[1]s34 %[2]s       %[1]s37 %[2]s            %[1]s400%[2]s ` + `
+     /   \                         /   \                    /   \                         /             \               ` + `
+    /     \                       /     \                  /     \                       /               \              ` + `
+ %[1]s2  %[2]s       %[1]s4  %[2]s                 %[1]s21 %[2]s       %[1]s23 %[2]s            %[1]s27 %[2]s       %[1]s29 %[2]s                 %[1]s33 %[2]s                 %[1]s38 %[2]s           ` + `
+                                                       /                                                                ` + `
+                                                      /                                                                 ` + `
+                                                   %[1]s26 %[2]s                                                                  ` + `
+`,
+	Color, Rst)
+
+	// Make real tree
+	tree := NewWBTree()
+	for _, k := range []KeyType{
+		20, 10, 30, 5, 25, 35, 37, 34, 2, 23, 27, 21, 31,
+		3, 4, 28, 29, 400, 390, 38, 26, 22, 31, 32, 33,
+	} {
+		tree.Insert(k, nil)
+	}
+
+	// Compare
+	if tStr := tree.String(); tStr != want {
+		t.Errorf("WBTree.String() returned:\n---\n%s\n---\nWant:\n---\n%s\n---\n", tStr, want)
+	}
+}
+
+func TestStringEmpty(t *testing.T) {
+	tree := NewWBTree()
+	if tStr := tree.String(); tStr != strEmptyTree {
+		t.Errorf("WBTree.String() returned:\n---\n%s\n---\nWant:\n---\n%s\n---\n", tStr, strEmptyTree)
+	}
+}
+
+func newTreeSortedKeys(keys []KeyType) (*WBTree, []KeyType) {
+	tree := NewWBTree()
+
+	// Insert all keys with doubled keys as values
+	for _, k := range keys {
+		tree.Insert(k, k*2)
+	}
+
+	// Make sorted copy of keys
+	sKeys := make([]KeyType, len(keys))
+	copy(sKeys, keys)
+	sort.Slice(sKeys, func(i, j int) bool { return sKeys[i] < sKeys[j] } )
+
+	return tree, sKeys
+}
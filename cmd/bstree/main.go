@@ -0,0 +1,111 @@
+/*
+Command bstree allows exploring binary search trees of this module interactively.
+
+It reads commands from the standard input or from the script file, applies them
+to the non-balanced binary search tree (package nbtree) or to the red-black tree
+(package rbtree) and prints the tree after each modification, so it is easy to
+see how the tree is rebalanced.
+
+Usage:
+
+	bstree [-tree nb|rb] [-quiet] [script-file]
+
+Commands, one per line, empty lines and lines starting with # are ignored, keys are
+non-negative integers, negative keys are reserved by trees for empty nodes:
+
+	insert k [k ...]	insert keys
+	delete k [k ...]	delete keys
+	search k		search the key
+	min, max		print the minimal or the maximal key
+	succ k, pred k		print the successor or the predecessor of the key
+	print			print the tree
+	check			check the tree and print its statistics
+	dot			print the tree in the DOT language of Graphviz
+	help			print the list of commands
+
+The tree is printed only if the command changed it. Errors of commands are printed
+and do not stop the processing, but the exit status is non-zero if any command failed.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit statuses
+const (
+	exitOK		=	0
+	exitFailed	=	1
+	exitUsage	=	2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command with arguments args and returns the exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("bstree", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	kind := flags.String("tree", kindRB, "type of the tree: "+kindNB+" - non-balanced, "+kindRB+" - red-black")
+	quiet := flags.Bool("quiet", false, "do not print the tree after modifications")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: bstree [options] [script-file]\n\nOptions:\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	tree, err := newTree(*kind)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	input, prompt := stdin, isTerminal(stdin)
+	switch flags.NArg() {
+	case 0:
+		// Read commands from the standard input
+	case 1:
+		f, err := os.Open(flags.Arg(0))
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitFailed
+		}
+		defer f.Close()
+
+		input, prompt = f, false
+	default:
+		flags.Usage()
+		return exitUsage
+	}
+
+	sh := &shell{tree: tree, out: stdout, quiet: *quiet, prompt: prompt}
+	if err := sh.run(input); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailed
+	}
+
+	if sh.failed != 0 {
+		return exitFailed
+	}
+
+	return exitOK
+}
+
+// isTerminal returns true if r is the terminal, then the prompt is printed before each command
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+
+	st, err := f.Stat()
+
+	return err == nil && st.Mode() & os.ModeCharDevice != 0
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// runScript runs the command with arguments args and the script on the standard input
+func runScript(args []string, script string) (int, string, string) {
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	status := run(args, strings.NewReader(script), &stdout, &stderr)
+
+	return status, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	const script = `
+# Comments and empty lines are ignored
+insert 20 10 30 5 15
+search 15
+succ 15
+pred 10
+min
+max
+succ 30
+pred 5
+delete 10
+check
+`
+	for _, kind := range []string{kindNB, kindRB} {
+		status, out, errOut := runScript([]string{"-quiet", "-tree", kind}, script)
+		if status != exitOK || errOut != "" {
+			t.Errorf("[%s] run returned status %d, errors %q, want - %d, no errors", kind, status, errOut, exitOK)
+		}
+
+		want := "found 15\n20\n5\n5\n30\n30 is the maximal key\n5 is the minimal key\nOK: nodes 4,"
+		if !strings.HasPrefix(out, want) {
+			t.Errorf("[%s] run printed:\n%s\nwant prefix:\n%s", kind, out, want)
+		}
+	}
+}
+
+func TestCommandErrors(t *testing.T) {
+	tests := []struct {
+		script	string
+		want	string
+	}{
+		{ "foo", `line 1: unknown command "foo"` },
+		{ "insert", "line 1: invalid number of arguments" },
+		{ "search 1 2", "line 1: invalid number of arguments" },
+		{ "insert x", `line 1: invalid key "x"` },
+		{ "insert 1\ninsert 1", "line 2: key 1 already exists" },
+		{ "delete 1", "line 1: key 1 not found" },
+		{ "insert 1\nsucc 2", "line 2: key 2 not found" },
+		{ "min", "line 1: tree is empty" },
+		{ "insert -1 4", "line 1: key -1 is reserved" },
+		{ "search -1", "line 1: key -1 is reserved" },
+		{ "insert 5 -3 7", "line 1: key -3 is reserved" },
+		{ "delete -3", "line 1: key -3 is reserved" },
+	}
+
+	for _, kind := range []string{kindNB, kindRB} {
+		for _, test := range tests {
+			status, out, _ := runScript([]string{"-quiet", "-tree", kind}, test.script)
+			if status != exitFailed {
+				t.Errorf("[%s: %q] run returned status %d, want - %d", kind, test.script, status, exitFailed)
+			}
+			if !strings.Contains(out, test.want) {
+				t.Errorf("[%s: %q] run printed %q, want - %q", kind, test.script, out, test.want)
+			}
+		}
+	}
+}
+
+func TestUsage(t *testing.T) {
+	for _, args := range [][]string{{"-tree", "avl"}, {"-unknown"}, {"script1", "script2"}} {
+		if status, _, _ := runScript(args, ""); status != exitUsage {
+			t.Errorf("%v: run returned status %d, want - %d", args, status, exitUsage)
+		}
+	}
+
+	if status, _, _ := runScript([]string{"testdata/not-exists"}, ""); status != exitFailed {
+		t.Errorf("run with absent script returned status %d, want - %d", status, exitFailed)
+	}
+}
+
+func TestPrintAfterModification(t *testing.T) {
+	_, out, _ := runScript([]string{"-tree", kindNB}, "insert 2 1 3\nsearch 1\ndelete 2\n")
+
+	// The tree is printed after insertion and deletion only
+	tree, _ := newTree(kindNB)
+	for _, k := range []int{2, 1, 3} {
+		tree.Insert(k)
+	}
+	want := tree.String() + "\nfound 1\n"
+	tree.Delete(2)
+	want += tree.String() + "\n"
+
+	if out != want {
+		t.Errorf("run printed:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestPrintOnlyChanged(t *testing.T) {
+	_, out, _ := runScript([]string{"-tree", kindRB}, "insert 2 1 3\ndelete 99\ninsert 2\ninsert -1\ninsert 4 4\n")
+
+	// The tree is not printed by failed commands that did not change it,
+	// partially applied insertion of 4 prints the tree
+	tree, _ := newTree(kindRB)
+	for _, k := range []int{2, 1, 3} {
+		tree.Insert(k)
+	}
+	want := tree.String() + "\nline 2: key 99 not found\nline 3: key 2 already exists\n" +
+		"line 4: key -1 is reserved by the tree, keys must be non-negative\n"
+	tree.Insert(4)
+	want += tree.String() + "\nline 5: key 4 already exists\n"
+
+	if out != want {
+		t.Errorf("run printed:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestScriptFile(t *testing.T) {
+	for _, kind := range []string{kindNB, kindRB} {
+		status, out, errOut := runScript([]string{"-quiet", "-tree", kind, "testdata/rotations.bst"}, "")
+		if status != exitOK || errOut != "" {
+			t.Errorf("[%s] run returned status %d, errors %q, output:\n%s", kind, status, errOut, out)
+		}
+	}
+}
+
+func TestDOT(t *testing.T) {
+	_, out, _ := runScript([]string{"-tree", kindRB}, "insert 2 1\ndot\n")
+
+	want := "digraph \"bstree\" {\n\tnode [shape=circle];\n" +
+		"\t2 [style=filled, fillcolor=black, fontcolor=white];\n" +
+		"\t2 -> 1;\n" +
+		"\tnull1 [shape=point];\n\t2 -> null1;\n" +
+		"\t1 [style=filled, fillcolor=red, fontcolor=white];\n" +
+		"}\n"
+	if !strings.HasSuffix(out, want) {
+		t.Errorf("dot printed:\n%s\nwant:\n%s", out, want)
+	}
+}
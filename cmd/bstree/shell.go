@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Prompt printed before each command read from the terminal
+const prompt = "bstree> "
+
+var errEmptyTree = errors.New("tree is empty")
+
+// command describes a command of the shell
+type command struct {
+	// Usage of the command, printed by help
+	usage	string
+	// Minimal and maximal number of arguments, maxArgs < 0 - unlimited
+	minArgs	int
+	maxArgs	int
+	// Command handler, keys - parsed arguments of the command
+	exec	func(sh *shell, keys []int) error
+}
+
+//nolint:gochecknoglobals // Table of commands, initialized in init to allow the help command to refer to it
+var commands map[string]*command
+
+// Order of commands in the help output
+//nolint:gochecknoglobals // Constant list
+var commandsOrder = []string{"insert", "delete", "search", "min", "max", "succ", "pred", "print", "check", "dot", "help"}
+
+//nolint:gochecknoinits
+func init() {
+	commands = map[string]*command{
+		"insert":	{ "insert k [k ...] - insert keys", 1, -1, (*shell).insert },
+		"delete":	{ "delete k [k ...] - delete keys", 1, -1, (*shell).delete },
+		"search":	{ "search k - search the key", 1, 1, (*shell).search },
+		"min":		{ "min - print the minimal key", 0, 0, (*shell).min },
+		"max":		{ "max - print the maximal key", 0, 0, (*shell).max },
+		"succ":		{ "succ k - print the successor of the key", 1, 1, (*shell).succ },
+		"pred":		{ "pred k - print the predecessor of the key", 1, 1, (*shell).pred },
+		"print":	{ "print - print the tree", 0, 0, (*shell).print },
+		"check":	{ "check - check the tree and print its statistics", 0, 0, (*shell).check },
+		"dot":		{ "dot - print the tree in the DOT language of Graphviz", 0, 0, (*shell).dot },
+		"help":		{ "help - print this list", 0, 0, (*shell).help },
+	}
+}
+
+// shell reads commands and applies them to the tree
+type shell struct {
+	tree	tree
+	out	io.Writer
+	// Do not print the tree after modifications
+	quiet	bool
+	// Print the prompt before each command
+	prompt	bool
+	// Number of failed commands
+	failed	int
+	// The tree was modified by the current command
+	changed	bool
+}
+
+// run executes all commands read from r, errors of commands are printed to the output,
+// only errors of reading are returned
+func (sh *shell) run(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; ; line++ {
+		if sh.prompt {
+			fmt.Fprint(sh.out, prompt)
+		}
+
+		if !scanner.Scan() {
+			break
+		}
+
+		if err := sh.exec(scanner.Text()); err != nil {
+			fmt.Fprintf(sh.out, "line %d: %v\n", line, err)
+			sh.failed++
+		}
+	}
+
+	if sh.prompt {
+		// Finish the line with the prompt
+		fmt.Fprintln(sh.out)
+	}
+
+	return scanner.Err()
+}
+
+// exec parses and executes the command line
+func (sh *shell) exec(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		// Empty line or comment
+		return nil
+	}
+
+	name, args := strings.ToLower(fields[0]), fields[1:]
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q, use help to get the list of commands", name)
+	}
+
+	if len(args) < cmd.minArgs || cmd.maxArgs >= 0 && len(args) > cmd.maxArgs {
+		return fmt.Errorf("invalid number of arguments, usage: %s", cmd.usage)
+	}
+
+	keys := make([]int, 0, len(args))
+	for _, arg := range args {
+		k, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid key %q, integer expected", arg)
+		}
+		if sh.tree.Reserved(k) {
+			return fmt.Errorf("key %d is reserved by the tree, keys must be non-negative", k)
+		}
+		keys = append(keys, k)
+	}
+
+	sh.changed = false
+	err := cmd.exec(sh, keys)
+	if sh.changed && !sh.quiet {
+		// Print the tree even if only a part of keys was processed
+		fmt.Fprintln(sh.out, sh.tree)
+	}
+
+	return err
+}
+
+func (sh *shell) insert(keys []int) error {
+	for _, k := range keys {
+		if !sh.tree.Insert(k) {
+			return fmt.Errorf("key %d already exists", k)
+		}
+		sh.changed = true
+	}
+
+	return nil
+}
+
+func (sh *shell) delete(keys []int) error {
+	for _, k := range keys {
+		if !sh.tree.Delete(k) {
+			return fmt.Errorf("key %d not found", k)
+		}
+		sh.changed = true
+	}
+
+	return nil
+}
+
+func (sh *shell) search(keys []int) error {
+	if !sh.tree.Search(keys[0]) {
+		return fmt.Errorf("key %d not found", keys[0])
+	}
+
+	fmt.Fprintf(sh.out, "found %d\n", keys[0])
+
+	return nil
+}
+
+func (sh *shell) min([]int) error {
+	return sh.printKey(sh.tree.Min())
+}
+
+func (sh *shell) max([]int) error {
+	return sh.printKey(sh.tree.Max())
+}
+
+func (sh *shell) succ(keys []int) error {
+	k, ok, err := sh.tree.Successor(keys[0])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintf(sh.out, "%d is the maximal key\n", keys[0])
+		return nil
+	}
+
+	return sh.printKey(k, true)
+}
+
+func (sh *shell) pred(keys []int) error {
+	k, ok, err := sh.tree.Predecessor(keys[0])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintf(sh.out, "%d is the minimal key\n", keys[0])
+		return nil
+	}
+
+	return sh.printKey(k, true)
+}
+
+func (sh *shell) print([]int) error {
+	fmt.Fprintln(sh.out, sh.tree)
+
+	return nil
+}
+
+func (sh *shell) check([]int) error {
+	stats, err := sh.tree.Check()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(sh.out, "OK: %s\n", stats)
+
+	return nil
+}
+
+func (sh *shell) dot([]int) error {
+	return sh.tree.WriteDOT(sh.out)
+}
+
+func (sh *shell) help([]int) error {
+	for _, name := range commandsOrder {
+		fmt.Fprintln(sh.out, commands[name].usage)
+	}
+
+	return nil
+}
+
+// printKey prints the key k if ok is true, otherwise returns the error of the empty tree
+func (sh *shell) printKey(k int, ok bool) error {
+	if !ok {
+		return errEmptyTree
+	}
+
+	fmt.Fprintln(sh.out, k)
+
+	return nil
+}
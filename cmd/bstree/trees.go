@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/r-che/algorithms/bst/nbtree"
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/internal/bintree"
+)
+
+// Types of trees selected by the -tree flag
+const (
+	kindNB	=	"nb"
+	kindRB	=	"rb"
+)
+
+// tree is the common interface of trees driven by the shell
+type tree interface {
+	// Insert inserts the key k, it returns false if the key already exists
+	Insert(k int) bool
+	// Delete deletes the key k, it returns false if there is no such key
+	Delete(k int) bool
+	// Search returns true if the key k exists
+	Search(k int) bool
+	// Reserved returns true if the key k cannot be used: trees use -1 for empty nodes and
+	// treat all negative keys as invalid
+	Reserved(k int) bool
+	// Min and Max return the minimal and the maximal key, or false if the tree is empty
+	Min() (int, bool)
+	Max() (int, bool)
+	// Successor and Predecessor return the next and the previous key of the existing
+	// key k, or false if there is no such key
+	Successor(k int) (int, bool, error)
+	Predecessor(k int) (int, bool, error)
+	// Check checks the tree and returns its statistics
+	Check() (string, error)
+	// WriteDOT writes the tree in the DOT language of Graphviz
+	WriteDOT(w io.Writer) error
+	// String returns the graphical representation of the tree
+	String() string
+}
+
+// newTree returns the empty tree of the kind
+func newTree(kind string) (tree, error) {
+	switch kind {
+	case kindNB:
+		return &nbTree{nbtree.NewBSTree()}, nil
+	case kindRB:
+		return &rbTree{rbtree.NewRBTree()}, nil
+	default:
+		return nil, fmt.Errorf("unknown type of the tree %q, supported - %s, %s", kind, kindNB, kindRB)
+	}
+}
+
+// errNoKey returns the error for the absent key k
+func errNoKey(k int) error {
+	return fmt.Errorf("key %d not found", k)
+}
+
+//
+// Non-balanced tree
+//
+
+type nbTree struct{ t *nbtree.BSTree }
+
+func (a *nbTree) Insert(k int) bool { return a.t.Insert(nbtree.NewBSTNode(nbtree.KeyType(k), nil)) != nil }
+func (a *nbTree) Search(k int) bool { return a.t.Search(nbtree.KeyType(k)) != nil }
+func (a *nbTree) Reserved(k int) bool { return nbtree.KeyType(k) < 0 }
+func (a *nbTree) Min() (int, bool) { return nbKey(a.t.Min()) }
+func (a *nbTree) Max() (int, bool) { return nbKey(a.t.Max()) }
+func (a *nbTree) String() string { return a.t.String() }
+
+func (a *nbTree) Delete(k int) bool {
+	n := a.t.Search(nbtree.KeyType(k))
+	if n == nil {
+		return false
+	}
+
+	a.t.Delete(n)
+
+	return true
+}
+
+func (a *nbTree) Successor(k int) (int, bool, error) {
+	n := a.t.Search(nbtree.KeyType(k))
+	if n == nil {
+		return 0, false, errNoKey(k)
+	}
+
+	s, ok := nbKey(a.t.Successor(n))
+
+	return s, ok, nil
+}
+
+func (a *nbTree) Predecessor(k int) (int, bool, error) {
+	n := a.t.Search(nbtree.KeyType(k))
+	if n == nil {
+		return 0, false, errNoKey(k)
+	}
+
+	p, ok := nbKey(a.t.Predecessor(n))
+
+	return p, ok, nil
+}
+
+func (a *nbTree) Check() (string, error) {
+	// The tree has no self-test, check links and the order of keys
+	if err := bintree.CheckLinks(a.t.Root()); err != nil {
+		return "", err
+	}
+
+	for n, next := a.t.Min(), a.t.Min(); n != nil; n = next {
+		if next = a.t.Successor(n); next != nil && next.Key() <= n.Key() {
+			return "", fmt.Errorf("key %v is followed by %v", n.Key(), next.Key())
+		}
+	}
+
+	st := a.t.Stats()
+
+	return fmt.Sprintf("nodes %d, height %d, min leaf depth %d, avg depth %.2f, imbalance %.2f, levels %v",
+		st.Nodes, st.Height, st.MinLeafDepth, st.AvgDepth, st.Imbalance, st.Levels), nil
+}
+
+func (a *nbTree) WriteDOT(w io.Writer) error {
+	return bintree.WriteDOT(w, a.t.Root(),
+		func(n *nbtree.BSTNode) int { return int(n.Key()) },
+		func(*nbtree.BSTNode) string { return "" })
+}
+
+// nbKey returns the key of the node n or false if n is nil
+func nbKey(n *nbtree.BSTNode) (int, bool) {
+	if n == nil {
+		return 0, false
+	}
+
+	return int(n.Key()), true
+}
+
+//
+// Red-black tree
+//
+
+type rbTree struct{ t *rbtree.RBTree }
+
+func (a *rbTree) Insert(k int) bool { return a.t.Insert(rbtree.NewRBNode(rbtree.KeyType(k), nil)) != nil }
+func (a *rbTree) Search(k int) bool { return a.t.Search(rbtree.KeyType(k)) != nil }
+func (a *rbTree) Reserved(k int) bool { return rbtree.KeyType(k) < 0 }
+func (a *rbTree) Delete(k int) bool { return a.t.DeleteKey(rbtree.KeyType(k)) }
+func (a *rbTree) Min() (int, bool) { return rbKey(a.t.Min()) }
+func (a *rbTree) Max() (int, bool) { return rbKey(a.t.Max()) }
+func (a *rbTree) String() string { return a.t.String() }
+
+func (a *rbTree) Successor(k int) (int, bool, error) {
+	n := a.t.Search(rbtree.KeyType(k))
+	if n == nil {
+		return 0, false, errNoKey(k)
+	}
+
+	s, ok := rbKey(a.t.Successor(n))
+
+	return s, ok, nil
+}
+
+func (a *rbTree) Predecessor(k int) (int, bool, error) {
+	n := a.t.Search(rbtree.KeyType(k))
+	if n == nil {
+		return 0, false, errNoKey(k)
+	}
+
+	p, ok := rbKey(a.t.Predecessor(n))
+
+	return p, ok, nil
+}
+
+func (a *rbTree) Check() (string, error) {
+	if err := bintree.CheckLinks(a.t.Root()); err != nil {
+		return "", err
+	}
+
+	if _, err := a.t.SelfTest(); err != nil {
+		return "", err
+	}
+
+	st := a.t.Stats()
+
+	return fmt.Sprintf("nodes %d, height %d, black-height %d, red %d, black %d, min leaf depth %d, " +
+		"avg depth %.2f, imbalance %.2f, levels %v",
+		st.Nodes, st.Height, st.BlackHeight, st.Red, st.Black, st.MinLeafDepth,
+		st.AvgDepth, st.Imbalance, st.Levels), nil
+}
+
+func (a *rbTree) WriteDOT(w io.Writer) error {
+	return bintree.WriteDOT(w, a.t.Root(),
+		func(n *rbtree.RBNode) int { return int(n.Key()) },
+		func(n *rbtree.RBNode) string {
+			if n.Color() == rbtree.Red {
+				return `style=filled, fillcolor=red, fontcolor=white`
+			}
+
+			return `style=filled, fillcolor=black, fontcolor=white`
+		})
+}
+
+// rbKey returns the key of the node n or false if n is nil
+func rbKey(n *rbtree.RBNode) (int, bool) {
+	if n == nil {
+		return 0, false
+	}
+
+	return int(n.Key()), true
+}
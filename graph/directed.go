@@ -0,0 +1,85 @@
+package graph
+
+import "sort"
+
+// TopoSort returns vertices of the directed acyclic graph in topological order: for each
+// edge u->v the vertex u precedes v. Vertices without mutual order are ordered by Kahn's
+// algorithm starting from sources in ascending order. It returns ErrCycle if the graph
+// has a cycle.
+func (g *Graph) TopoSort() ([]int, error) {
+	if g.kind != Directed {
+		return nil, ErrUndirected
+	}
+
+	// Number of incoming edges of each vertex
+	inDeg := make([]int, g.Order())
+	for _, list := range g.adj {
+		for _, e := range list {
+			inDeg[e.To]++
+		}
+	}
+
+	// Queue of vertices without incoming edges from unsorted vertices
+	order := make([]int, 0, g.Order())
+	for v, d := range inDeg {
+		if d == 0 {
+			order = append(order, v)
+		}
+	}
+
+	// Sorted vertices are used as the queue
+	for head := 0; head < len(order); head++ {
+		for _, e := range g.adj[order[head]] {
+			if inDeg[e.To]--; inDeg[e.To] == 0 {
+				order = append(order, e.To)
+			}
+		}
+	}
+
+	if len(order) != g.Order() {
+		// Vertices on cycles never lose all incoming edges
+		return nil, ErrCycle
+	}
+
+	return order, nil
+}
+
+// SCC returns strongly connected components of the directed graph found by Kosaraju's
+// algorithm. Components are in topological order of the condensation of the graph: edges
+// between components go only from earlier components to later ones. Vertices of each
+// component are in ascending order.
+func (g *Graph) SCC() ([][]int, error) {
+	if g.kind != Directed {
+		return nil, ErrUndirected
+	}
+
+	// Order vertices by decreasing finish time of depth-first search
+	visited := make([]bool, g.Order())
+	finished := make([]int, 0, g.Order())
+	for v := range g.adj {
+		if !visited[v] {
+			g.dfs(v, visited, nil, func(u int) { finished = append(finished, u) })
+		}
+	}
+
+	// Each search in the reversed graph starting from the latest finished vertex
+	// visits exactly one component
+	r := g.Reverse()
+	visited = make([]bool, g.Order())
+	var comps [][]int
+	for i := len(finished) - 1; i >= 0; i-- {
+		if visited[finished[i]] {
+			continue
+		}
+
+		var comp []int
+		r.dfs(finished[i], visited, func(u, _ int) bool {
+			comp = append(comp, u)
+			return true
+		}, nil)
+		sort.Ints(comp)
+		comps = append(comps, comp)
+	}
+
+	return comps, nil
+}
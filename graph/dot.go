@@ -0,0 +1,42 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the graph with the name to w in the DOT language of Graphviz, weights
+// of edges are written as labels.
+func (g *Graph) WriteDOT(w io.Writer, name string) error {
+	keyword, edgeOp := "digraph", "->"
+	if g.kind == Undirected {
+		keyword, edgeOp = "graph", "--"
+	}
+
+	out := strings.Builder{}
+	fmt.Fprintf(&out, "%s %q {\n", keyword, name)
+
+	// Write all vertices to show isolated ones
+	for v := range g.adj {
+		fmt.Fprintf(&out, "\t%d;\n", v)
+	}
+
+	for _, e := range g.AllEdges() {
+		fmt.Fprintf(&out, "\t%d %s %d [label=\"%v\"];\n", e.From, edgeOp, e.To, e.Weight)
+	}
+	out.WriteString("}\n")
+
+	_, err := io.WriteString(w, out.String())
+
+	return err
+}
+
+// DOT returns the graph with the name in the DOT language of Graphviz, see WriteDOT.
+func (g *Graph) DOT(name string) string {
+	out := strings.Builder{}
+	// XXX Writing to strings.Builder never fails
+	_ = g.WriteDOT(&out, name)
+
+	return out.String()
+}
@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"fmt"
+	"os"
+)
+
+func Example_shortestPaths() {
+	// Road network, weights are distances
+	g := NewGraph(5, Undirected)
+	g.AddEdge(0, 1, 4)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(2, 1, 2)
+	g.AddEdge(1, 3, 5)
+	g.AddEdge(2, 3, 8)
+
+	dist, parent, _ := g.Dijkstra(0)
+	for v, d := range dist {
+		fmt.Printf("%d: distance %v, path %v\n", v, d, PathTo(parent, v))
+	}
+
+	// Output:
+	// 0: distance 0, path [0]
+	// 1: distance 3, path [0 2 1]
+	// 2: distance 1, path [0 2]
+	// 3: distance 8, path [0 2 1 3]
+	// 4: distance +Inf, path [4]
+}
+
+func Example_topoSort() {
+	// Build dependencies: edge u->v means that u must be built before v
+	names := []string{"lib", "util", "app", "tests"}
+	g := NewGraph(len(names), Directed)
+	g.AddEdge(1, 0, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(0, 3, 1)
+	g.AddEdge(2, 3, 1)
+
+	order, _ := g.TopoSort()
+	for i, v := range order {
+		if i != 0 {
+			fmt.Print(" -> ")
+		}
+		fmt.Print(names[v])
+	}
+	fmt.Println()
+
+	// Make a cycle
+	g.AddEdge(3, 1, 1)
+	_, err := g.TopoSort()
+	fmt.Println(err)
+
+	// Output:
+	// util -> lib -> app -> tests
+	// graph has a cycle
+}
+
+func Example_minimumSpanningTree() {
+	g := NewGraph(4, Undirected)
+	g.AddEdge(0, 1, 10)
+	g.AddEdge(0, 2, 6)
+	g.AddEdge(0, 3, 5)
+	g.AddEdge(1, 3, 15)
+	g.AddEdge(2, 3, 4)
+
+	edges, total, _ := g.Kruskal()
+	fmt.Println(edges, total)
+
+	// Output:
+	// [2-3(4) 0-3(5) 0-1(10)] 19
+}
+
+func Example_dot() {
+	g := NewGraph(3, Directed)
+	g.AddEdge(0, 1, 2)
+	g.AddEdge(1, 2, 3)
+
+	// Write the graph to render it by Graphviz, e.g.: dot -Tpng graph.dot -o graph.png
+	_ = g.WriteDOT(os.Stdout, "example")
+
+	// Output:
+	// digraph "example" {
+	// 	0;
+	// 	1;
+	// 	2;
+	// 	0 -> 1 [label="2"];
+	// 	1 -> 2 [label="3"];
+	// }
+}
@@ -0,0 +1,193 @@
+/*
+Package graph provides an example of a graph implementation based on adjacency lists
+with basic graph algorithms.
+
+Vertices of the graph are integers in the range [0, Order()), edges have float64
+weights. The graph can be directed or undirected, an undirected edge is stored
+in adjacency lists of both its ends.
+
+The package implements:
+  - breadth-first and depth-first traversals, connected components of undirected graphs
+  - topological sort and strongly connected components of directed graphs
+  - single-source shortest paths by Dijkstra's algorithm, that uses the indexed
+    d-ary heap from the [dheap] package as the priority queue, and by the
+    Bellman-Ford algorithm, that supports negative weights and detects negative cycles
+  - minimum spanning forest of undirected graphs by Kruskal's algorithm, that uses
+    the disjoint-set union from the [dsu] package, and by Prim's algorithm
+  - export of the graph to the DOT language of Graphviz
+
+[dheap]: https://pkg.go.dev/github.com/r-che/algorithms/heap/dheap
+[dsu]: https://pkg.go.dev/github.com/r-che/algorithms/set/dsu
+*/
+package graph
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind defines the kind of the graph
+type Kind int
+const (
+	// Directed - each edge goes from one vertex to another
+	Directed = Kind(iota)
+	// Undirected - each edge connects two vertices in both directions
+	Undirected
+)
+
+func (k Kind) String() string {
+	switch k {
+		case Directed:		return "Directed"
+		case Undirected:	return "Undirected"
+	}
+
+	panic(fmt.Sprintf("Unexpected graph kind value: %d", k))
+}
+
+var (
+	// ErrCycle is returned by TopoSort if the graph has a cycle
+	ErrCycle			=	errors.New("graph has a cycle")
+	// ErrNegativeWeight is returned by Dijkstra if the graph has an edge with negative weight
+	ErrNegativeWeight	=	errors.New("graph has an edge with negative weight")
+	// ErrNegativeCycle is returned by BellmanFord if a negative cycle is reachable from the source
+	ErrNegativeCycle	=	errors.New("graph has a negative cycle")
+	// ErrDirected is returned by algorithms for undirected graphs only
+	ErrDirected			=	errors.New("graph is directed")
+	// ErrUndirected is returned by algorithms for directed graphs only
+	ErrUndirected		=	errors.New("graph is undirected")
+)
+
+// Edge represents an edge of the graph
+type Edge struct {
+	From	int
+	To		int
+	Weight	float64
+}
+
+func (e Edge) String() string {
+	return fmt.Sprintf("%d-%d(%v)", e.From, e.To, e.Weight)
+}
+
+// Graph implements a weighted graph based on adjacency lists.
+type Graph struct {
+	kind	Kind
+	// Outgoing edges of each vertex, Edge.From is always equal to the index of the list
+	adj		[][]Edge
+	// Number of edges, an undirected edge is counted once
+	size	int
+}
+
+// NewGraph returns new graph of the kind with n vertices and without edges.
+func NewGraph(n int, kind Kind) *Graph {
+	// Check for the correct kind
+	_ = kind.String()
+
+	if n < 0 {
+		panic(fmt.Sprintf("Invalid number of vertices %d", n))
+	}
+
+	return &Graph{kind: kind, adj: make([][]Edge, n)}
+}
+
+// Kind returns the kind of the graph
+func (g *Graph) Kind() Kind {
+	return g.kind
+}
+
+// Order returns the number of vertices
+func (g *Graph) Order() int {
+	return len(g.adj)
+}
+
+// Size returns the number of edges, an undirected edge is counted once
+func (g *Graph) Size() int {
+	return g.size
+}
+
+// AddVertex adds a new vertex without edges and returns it.
+func (g *Graph) AddVertex() int {
+	g.adj = append(g.adj, nil)
+
+	return len(g.adj) - 1
+}
+
+// AddEdge adds the edge from u to v with weight w. Parallel edges and loops are allowed.
+func (g *Graph) AddEdge(u, v int, w float64) {
+	g.checkVertex(u)
+	g.checkVertex(v)
+
+	g.adj[u] = append(g.adj[u], Edge{From: u, To: v, Weight: w})
+	if g.kind == Undirected && u != v {
+		// Add the reverse edge, the loop is added only once
+		g.adj[v] = append(g.adj[v], Edge{From: v, To: u, Weight: w})
+	}
+
+	g.size++
+}
+
+// Edges returns outgoing edges of the vertex u. The returned slice must not be modified.
+func (g *Graph) Edges(u int) []Edge {
+	g.checkVertex(u)
+
+	return g.adj[u]
+}
+
+// AllEdges returns all edges of the graph, each undirected edge is returned once
+// with From <= To.
+func (g *Graph) AllEdges() []Edge {
+	edges := make([]Edge, 0, g.size)
+	for _, list := range g.adj {
+		for _, e := range list {
+			if g.kind == Undirected && e.From > e.To {
+				// The same edge is returned from another end
+				continue
+			}
+			edges = append(edges, e)
+		}
+	}
+
+	return edges
+}
+
+// Reverse returns a new graph with reversed directions of all edges. For undirected graphs
+// it returns a copy of the graph.
+func (g *Graph) Reverse() *Graph {
+	r := NewGraph(g.Order(), g.kind)
+	for _, e := range g.AllEdges() {
+		if g.kind == Directed {
+			r.AddEdge(e.To, e.From, e.Weight)
+		} else {
+			r.AddEdge(e.From, e.To, e.Weight)
+		}
+	}
+
+	return r
+}
+
+// PathTo returns the path to the vertex v from the root of the tree defined by parent
+// links, the parent of the root is -1. Parent links are returned by Hops, Dijkstra and
+// BellmanFord, they do not define the path to unreachable vertices, so reachability of
+// v should be checked by distances before.
+func PathTo(parent []int, v int) []int {
+	var path []int
+	for ; v != -1; v = parent[v] {
+		path = append(path, v)
+		if len(path) > len(parent) {
+			panic("cycle in parent links")
+		}
+	}
+
+	// Reverse path
+	for i, j := 0, len(path) - 1; i < j; i, j = i + 1, j - 1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// checkVertex panics if v is not a vertex of the graph
+func (g *Graph) checkVertex(v int) {
+	if v < 0 || v >= len(g.adj) {
+		panic(fmt.Sprintf("vertex %d out of range [0, %d)", v, len(g.adj)))
+	}
+}
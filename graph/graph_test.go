@@ -0,0 +1,436 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/r-che/algorithms/set/dsu"
+)
+
+const (
+	// Number of vertices and edges of random graphs
+	verticesCount	=	128
+	edgesCount		=	512
+	// Maximal weight of edges
+	MaxWeight		=	99
+
+	// Number of random graphs for each test
+	graphsCount		=	16
+	// Seed of random sources of tests
+	testSeed		=	2039
+)
+
+// randomGraph returns a graph with random edges generated by rnd,
+// weights are in the range [minW, MaxWeight]
+func randomGraph(rnd *rand.Rand, kind Kind, n, m int, minW int) *Graph {
+	g := NewGraph(n, kind)
+	for i := 0; i < m; i++ {
+		w := minW + rnd.Intn(MaxWeight - minW + 1)
+		g.AddEdge(rnd.Intn(n), rnd.Intn(n), float64(w))
+	}
+
+	return g
+}
+
+// floydWarshall returns lengths of the shortest paths between all pairs of vertices
+func floydWarshall(g *Graph) [][]float64 {
+	n := g.Order()
+	dist := make([][]float64, n)
+	for u := range dist {
+		dist[u] = make([]float64, n)
+		for v := range dist[u] {
+			dist[u][v] = math.Inf(1)
+		}
+		dist[u][u] = 0
+
+		for _, e := range g.Edges(u) {
+			dist[u][e.To] = math.Min(dist[u][e.To], e.Weight)
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				dist[u][v] = math.Min(dist[u][v], dist[u][k] + dist[k][v])
+			}
+		}
+	}
+
+	return dist
+}
+
+// checkPaths checks that parent links define paths with lengths dist
+func checkPaths(t *testing.T, g *Graph, s int, dist []float64, parent []int) {
+	t.Helper()
+
+	for v := range dist {
+		if math.IsInf(dist[v], 1) {
+			continue
+		}
+
+		path := PathTo(parent, v)
+		if path[0] != s || path[len(path)-1] != v {
+			t.Fatalf("path to %d from %d is %v", v, s, path)
+		}
+
+		length := 0.0
+		for i := 1; i < len(path); i++ {
+			w := math.Inf(1)
+			for _, e := range g.Edges(path[i-1]) {
+				if e.To == path[i] {
+					w = math.Min(w, e.Weight)
+				}
+			}
+			length += w
+		}
+
+		if length != dist[v] {
+			t.Fatalf("path %v has length %v, want - %v", path, length, dist[v])
+		}
+	}
+}
+
+func TestGraph(t *testing.T) {
+	for _, kind := range []Kind{Directed, Undirected} {
+		g := NewGraph(3, kind)
+		g.AddEdge(0, 1, 1)
+		g.AddEdge(2, 1, 2)
+		g.AddEdge(2, 2, 3)
+		if v := g.AddVertex(); v != 3 {
+			t.Errorf("[%v] AddVertex returned %d, want - 3", kind, v)
+		}
+
+		if g.Kind() != kind || g.Order() != 4 || g.Size() != 3 {
+			t.Errorf("[%v] graph has kind %v, order %d, size %d, want - %v, 4, 3",
+				kind, g.Kind(), g.Order(), g.Size(), kind)
+		}
+
+		want := []Edge{{0, 1, 1}, {2, 1, 2}, {2, 2, 3}}
+		if kind == Undirected {
+			// Undirected edges are returned with From <= To
+			want = []Edge{{0, 1, 1}, {1, 2, 2}, {2, 2, 3}}
+		}
+		if edges := g.AllEdges(); !reflect.DeepEqual(edges, want) {
+			t.Errorf("[%v] AllEdges returned %v, want - %v", kind, edges, want)
+		}
+
+		// Vertex 1 has incoming edges only in the directed graph
+		if n := len(g.Edges(1)); kind == Directed && n != 0 || kind == Undirected && n != 2 {
+			t.Errorf("[%v] vertex 1 has %d outgoing edges", kind, n)
+		}
+
+		// Edges to vertex 1 go out from it in the reversed graph
+		if r := g.Reverse(); len(r.Edges(1)) != 2 || r.Size() != g.Size() {
+			t.Errorf("[%v] Reverse returned graph with edges %v", kind, r.AllEdges())
+		}
+	}
+}
+
+func TestTraversal(t *testing.T) {
+	g := NewGraph(7, Directed)
+	for _, e := range [][2]int{{0, 1}, {0, 2}, {1, 3}, {2, 3}, {3, 4}, {2, 5}, {6, 0}} {
+		g.AddEdge(e[0], e[1], 1)
+	}
+
+	var bfs, dfs []int
+	g.BFS(0, func(v, _, _ int) bool { bfs = append(bfs, v); return true })
+	g.DFS(0, func(v, _ int) bool { dfs = append(dfs, v); return true })
+
+	if want := []int{0, 1, 2, 3, 5, 4}; !reflect.DeepEqual(bfs, want) {
+		t.Errorf("BFS visited %v, want - %v", bfs, want)
+	}
+	if want := []int{0, 1, 3, 4, 2, 5}; !reflect.DeepEqual(dfs, want) {
+		t.Errorf("DFS visited %v, want - %v", dfs, want)
+	}
+
+	// Stop traversals on the vertex 3
+	bfs, dfs = nil, nil
+	g.BFS(0, func(v, _, _ int) bool { bfs = append(bfs, v); return v != 3 })
+	g.DFS(0, func(v, _ int) bool { dfs = append(dfs, v); return v != 3 })
+	if want := []int{0, 1, 2, 3}; !reflect.DeepEqual(bfs, want) {
+		t.Errorf("stopped BFS visited %v, want - %v", bfs, want)
+	}
+	if want := []int{0, 1, 3}; !reflect.DeepEqual(dfs, want) {
+		t.Errorf("stopped DFS visited %v, want - %v", dfs, want)
+	}
+
+	dist, parent := g.Hops(0)
+	if want := []int{0, 1, 1, 2, 3, 2, -1}; !reflect.DeepEqual(dist, want) {
+		t.Errorf("Hops returned distances %v, want - %v", dist, want)
+	}
+	if path := PathTo(parent, 4); !reflect.DeepEqual(path, []int{0, 1, 3, 4}) {
+		t.Errorf("PathTo(4) returned %v", path)
+	}
+
+	if _, err := g.Components(); !errors.Is(err, ErrDirected) {
+		t.Errorf("Components on directed graph returned error %v, want - %v", err, ErrDirected)
+	}
+}
+
+func TestComponents(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	for i := 0; i < graphsCount; i++ {
+		// Sparse graph has several components
+		g := randomGraph(rnd, Undirected, verticesCount, verticesCount / 2, 1)
+		comps, err := g.Components()
+		if err != nil {
+			t.Fatalf("[%d] Components returned error: %v", i, err)
+		}
+
+		d := dsu.NewDSU(g.Order(), dsu.BySize)
+		for _, e := range g.AllEdges() {
+			d.Union(e.From, e.To)
+		}
+
+		if len(comps) != d.Count() {
+			t.Fatalf("[%d] Components returned %d components, want - %d", i, len(comps), d.Count())
+		}
+		for _, comp := range comps {
+			for _, v := range comp {
+				if !d.Connected(comp[0], v) || d.Size(v) != len(comp) {
+					t.Fatalf("[%d] component %v is not connected or not complete", i, comp)
+				}
+			}
+		}
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	for i := 0; i < graphsCount; i++ {
+		// Random DAG - edges go from lesser to greater positions in the random permutation
+		perm := rnd.Perm(verticesCount)
+		g := NewGraph(verticesCount, Directed)
+		for j := 0; j < edgesCount; j++ {
+			a, b := rnd.Int() % verticesCount, rnd.Int() % verticesCount
+			if a == b {
+				continue
+			}
+			if a > b {
+				a, b = b, a
+			}
+			g.AddEdge(perm[a], perm[b], 1)
+		}
+
+		order, err := g.TopoSort()
+		if err != nil {
+			t.Fatalf("[%d] TopoSort returned error on DAG: %v", i, err)
+		}
+
+		pos := make([]int, verticesCount)
+		for p, v := range order {
+			pos[v] = p
+		}
+		for _, e := range g.AllEdges() {
+			if pos[e.From] >= pos[e.To] {
+				t.Fatalf("[%d] TopoSort placed %d after %d, but edge %v exists", i, e.From, e.To, e)
+			}
+		}
+
+		// Add back edge to make a cycle
+		e := g.AllEdges()[0]
+		g.AddEdge(e.To, e.From, 1)
+		if _, err := g.TopoSort(); !errors.Is(err, ErrCycle) {
+			t.Fatalf("[%d] TopoSort returned error %v on graph with cycle, want - %v", i, err, ErrCycle)
+		}
+	}
+
+	if _, err := NewGraph(1, Undirected).TopoSort(); !errors.Is(err, ErrUndirected) {
+		t.Errorf("TopoSort on undirected graph returned error %v, want - %v", err, ErrUndirected)
+	}
+}
+
+func TestSCC(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	for i := 0; i < graphsCount; i++ {
+		// Sparse graph has many components
+		g := randomGraph(rnd, Directed, verticesCount, verticesCount * 3 / 2, 1)
+		dist := floydWarshall(g)
+
+		comps, err := g.SCC()
+		if err != nil {
+			t.Fatalf("[%d] SCC returned error: %v", i, err)
+		}
+
+		compOf := make([]int, verticesCount)
+		total := 0
+		for c, comp := range comps {
+			total += len(comp)
+			for _, v := range comp {
+				compOf[v] = c
+			}
+		}
+		if total != verticesCount {
+			t.Fatalf("[%d] SCC returned components with %d vertices, want - %d", i, total, verticesCount)
+		}
+
+		for u := range dist {
+			for v := range dist[u] {
+				strong := !math.IsInf(dist[u][v], 1) && !math.IsInf(dist[v][u], 1)
+				if strong != (compOf[u] == compOf[v]) {
+					t.Fatalf("[%d] vertices %d and %d are mutually reachable: %t, but in components %d and %d",
+						i, u, v, strong, compOf[u], compOf[v])
+				}
+			}
+		}
+
+		// Components are in topological order
+		for _, e := range g.AllEdges() {
+			if compOf[e.From] > compOf[e.To] {
+				t.Fatalf("[%d] edge %v goes from component %d to earlier component %d",
+					i, e, compOf[e.From], compOf[e.To])
+			}
+		}
+	}
+}
+
+func TestShortestPaths(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	for i := 0; i < graphsCount; i++ {
+		kind := []Kind{Directed, Undirected}[i % 2]
+		g := randomGraph(rnd, kind, verticesCount, edgesCount, 0)
+		want := floydWarshall(g)
+		s := rnd.Int() % verticesCount
+
+		dist, parent, err := g.Dijkstra(s)
+		if err != nil {
+			t.Fatalf("[%d] Dijkstra returned error: %v", i, err)
+		}
+		if !reflect.DeepEqual(dist, want[s]) {
+			t.Fatalf("[%d] Dijkstra returned distances %v, want - %v", i, dist, want[s])
+		}
+		checkPaths(t, g, s, dist, parent)
+
+		dist, parent, err = g.BellmanFord(s)
+		if err != nil {
+			t.Fatalf("[%d] BellmanFord returned error: %v", i, err)
+		}
+		if !reflect.DeepEqual(dist, want[s]) {
+			t.Fatalf("[%d] BellmanFord returned distances %v, want - %v", i, dist, want[s])
+		}
+		checkPaths(t, g, s, dist, parent)
+
+		// Number of hops is the distance in the graph with unit weights
+		unit := NewGraph(verticesCount, kind)
+		for _, e := range g.AllEdges() {
+			unit.AddEdge(e.From, e.To, 1)
+		}
+		hops, _ := g.Hops(s)
+		for v, d := range floydWarshall(unit)[s] {
+			if math.IsInf(d, 1) && hops[v] != -1 || !math.IsInf(d, 1) && float64(hops[v]) != d {
+				t.Fatalf("[%d] Hops returned %d edges to %d, want - %v", i, hops[v], v, d)
+			}
+		}
+	}
+}
+
+func TestNegativeWeights(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	// DAG with negative weights has no negative cycles
+	g := NewGraph(verticesCount, Directed)
+	for j := 0; j < edgesCount; j++ {
+		a, b := rnd.Int() % verticesCount, rnd.Int() % verticesCount
+		if a >= b {
+			continue
+		}
+		g.AddEdge(a, b, float64(rnd.Int() % (2 * MaxWeight) - MaxWeight))
+	}
+
+	if _, _, err := g.Dijkstra(0); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Dijkstra returned error %v, want - %v", err, ErrNegativeWeight)
+	}
+
+	dist, parent, err := g.BellmanFord(0)
+	if err != nil {
+		t.Fatalf("BellmanFord returned error: %v", err)
+	}
+	if want := floydWarshall(g)[0]; !reflect.DeepEqual(dist, want) {
+		t.Fatalf("BellmanFord returned distances %v, want - %v", dist, want)
+	}
+	checkPaths(t, g, 0, dist, parent)
+
+	// Make a negative cycle reachable from 0
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, -3)
+	if _, _, err := g.BellmanFord(0); !errors.Is(err, ErrNegativeCycle) {
+		t.Errorf("BellmanFord returned error %v, want - %v", err, ErrNegativeCycle)
+	}
+}
+
+func TestMST(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	for i := 0; i < graphsCount; i++ {
+		// Dense graphs are connected, sparse - not
+		g := randomGraph(rnd, Undirected, verticesCount, []int{edgesCount, verticesCount / 2}[i % 2], 1)
+		comps, _ := g.Components()
+
+		kEdges, kTotal, err := g.Kruskal()
+		if err != nil {
+			t.Fatalf("[%d] Kruskal returned error: %v", i, err)
+		}
+
+		pEdges, pTotal, err := g.Prim()
+		if err != nil {
+			t.Fatalf("[%d] Prim returned error: %v", i, err)
+		}
+
+		if kTotal != pTotal {
+			t.Fatalf("[%d] Kruskal returned total weight %v, Prim - %v", i, kTotal, pTotal)
+		}
+
+		for name, edges := range map[string][]Edge{"Kruskal": kEdges, "Prim": pEdges} {
+			// Spanning forest has exactly one tree for each component
+			if want := verticesCount - len(comps); len(edges) != want {
+				t.Fatalf("[%d] %s returned %d edges, want - %d", i, name, len(edges), want)
+			}
+
+			d := dsu.NewDSU(verticesCount, dsu.ByRank)
+			total := 0.0
+			for _, e := range edges {
+				if !d.Union(e.From, e.To) {
+					t.Fatalf("[%d] %s returned edges with cycle on %v", i, name, e)
+				}
+				total += e.Weight
+			}
+			if total != kTotal {
+				t.Fatalf("[%d] %s returned edges with total weight %v, want - %v", i, name, total, kTotal)
+			}
+		}
+	}
+
+	d := NewGraph(2, Directed)
+	if _, _, err := d.Kruskal(); !errors.Is(err, ErrDirected) {
+		t.Errorf("Kruskal on directed graph returned error %v, want - %v", err, ErrDirected)
+	}
+	if _, _, err := d.Prim(); !errors.Is(err, ErrDirected) {
+		t.Errorf("Prim on directed graph returned error %v, want - %v", err, ErrDirected)
+	}
+}
+
+func TestDOT(t *testing.T) {
+	for _, test := range []struct {
+		kind	Kind
+		want	string
+	} {
+		{ Directed, "digraph \"g\" {\n\t0;\n\t1;\n\t2;\n\t0 -> 1 [label=\"1.5\"];\n\t2 -> 0 [label=\"-2\"];\n}\n" },
+		{ Undirected, "graph \"g\" {\n\t0;\n\t1;\n\t2;\n\t0 -- 1 [label=\"1.5\"];\n\t0 -- 2 [label=\"-2\"];\n}\n" },
+	} {
+		g := NewGraph(3, test.kind)
+		g.AddEdge(0, 1, 1.5)
+		g.AddEdge(2, 0, -2)
+
+		if dot := g.DOT("g"); dot != test.want {
+			t.Errorf("[%v] DOT returned:\n---\n%s\n---\nWant:\n---\n%s\n---\n", test.kind, dot, test.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"math"
+	"sort"
+
+	"github.com/r-che/algorithms/heap/dheap"
+	"github.com/r-che/algorithms/set/dsu"
+)
+
+// Kruskal returns edges of the minimum spanning forest of the undirected graph and their
+// total weight. Edges are in the order of adding to the forest - in ascending order of
+// weights. It returns ErrDirected for directed graphs.
+func (g *Graph) Kruskal() ([]Edge, float64, error) {
+	if g.kind != Undirected {
+		return nil, 0, ErrDirected
+	}
+
+	edges := g.AllEdges()
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].Weight < edges[j].Weight })
+
+	// Trees of the forest, an edge between vertices of the same tree makes a cycle
+	trees := dsu.NewDSU(g.Order(), dsu.ByRank)
+
+	forest := make([]Edge, 0, g.Order())
+	total := 0.0
+	for _, e := range edges {
+		if trees.Union(e.From, e.To) {
+			forest = append(forest, e)
+			total += e.Weight
+		}
+	}
+
+	return forest, total, nil
+}
+
+// Prim returns edges of the minimum spanning forest of the undirected graph and their total
+// weight. Trees of the forest are grown from the vertices in ascending order, edges of each
+// tree are in the order of adding, Edge.From of each edge is a vertex that is already in the
+// tree. It returns ErrDirected for directed graphs.
+func (g *Graph) Prim() ([]Edge, float64, error) {
+	if g.kind != Undirected {
+		return nil, 0, ErrDirected
+	}
+
+	// Lightest known edges connecting vertices to the tree
+	best := make([]Edge, g.Order())
+	for i := range best {
+		best[i] = Edge{From: -1, To: i, Weight: math.Inf(1)}
+	}
+	inTree := make([]bool, g.Order())
+
+	pq := dheap.NewDHeap(heapArity, func(a, b float64) bool { return a < b })
+
+	forest := make([]Edge, 0, g.Order())
+	total := 0.0
+	for root := range g.adj {
+		if inTree[root] {
+			continue
+		}
+
+		// Grow a new tree from root
+		pq.Push(root, 0)
+		for pq.Len() != 0 {
+			u, _, _ := pq.Pop()
+			inTree[u] = true
+			if best[u].From != -1 {
+				forest = append(forest, best[u])
+				total += best[u].Weight
+			}
+
+			for _, e := range g.adj[u] {
+				if inTree[e.To] || e.Weight >= best[e.To].Weight {
+					continue
+				}
+
+				// Lighter edge to e.To is found
+				if pq.Contains(e.To) {
+					pq.DecreaseKey(e.To, e.Weight)
+				} else {
+					pq.Push(e.To, e.Weight)
+				}
+				best[e.To] = e
+			}
+		}
+	}
+
+	return forest, total, nil
+}
@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"math"
+
+	"github.com/r-che/algorithms/heap/dheap"
+)
+
+// heapArity is the arity of the priority queue used by Dijkstra and Prim. The algorithms
+// do more decrease-key operations than pops, so the heap shallower than binary is faster
+const heapArity = 4
+
+// Dijkstra returns lengths of the shortest paths from s to each vertex, +Inf for
+// unreachable vertices, and parent links of the shortest paths, see PathTo. It
+// returns ErrNegativeWeight if the graph has an edge with negative weight.
+func (g *Graph) Dijkstra(s int) (dist []float64, parent []int, err error) {	//nolint:nonamedreturns
+	g.checkVertex(s)
+
+	if g.hasNegativeWeight() {
+		return nil, nil, ErrNegativeWeight
+	}
+
+	dist, parent = g.initPaths(s)
+
+	// Vertices are handles of the queue, tentative distances are priorities
+	pq := dheap.NewDHeap(heapArity, func(a, b float64) bool { return a < b })
+	pq.Push(s, 0)
+
+	for pq.Len() != 0 {
+		u, du, _ := pq.Pop()
+		// The distance to u is final now
+		for _, e := range g.adj[u] {
+			nd := du + e.Weight
+			if nd >= dist[e.To] {
+				continue
+			}
+
+			// Shorter path is found
+			if math.IsInf(dist[e.To], 1) {
+				// First visit of the vertex
+				pq.Push(e.To, nd)
+			} else {
+				pq.DecreaseKey(e.To, nd)
+			}
+			dist[e.To], parent[e.To] = nd, u
+		}
+	}
+
+	return dist, parent, nil
+}
+
+// BellmanFord returns lengths of the shortest paths from s to each vertex, +Inf for
+// unreachable vertices, and parent links of the shortest paths, see PathTo. Negative
+// weights are allowed, it returns ErrNegativeCycle if a cycle with negative total
+// weight is reachable from s. Note, that an undirected edge with negative weight is
+// such cycle.
+func (g *Graph) BellmanFord(s int) (dist []float64, parent []int, err error) {	//nolint:nonamedreturns
+	g.checkVertex(s)
+
+	dist, parent = g.initPaths(s)
+
+	// The shortest path has at most Order()-1 edges, so Order()-1 passes of relaxation
+	// of all edges are enough. One more pass finds improvements only on negative cycles
+	for pass := 0; pass < g.Order(); pass++ {
+		relaxed := false
+		for u, list := range g.adj {
+			if math.IsInf(dist[u], 1) {
+				// Not reached yet
+				continue
+			}
+
+			for _, e := range list {
+				if nd := dist[u] + e.Weight; nd < dist[e.To] {
+					dist[e.To], parent[e.To] = nd, u
+					relaxed = true
+				}
+			}
+		}
+
+		if !relaxed {
+			// Distances are final
+			return dist, parent, nil
+		}
+	}
+
+	return nil, nil, ErrNegativeCycle
+}
+
+// initPaths returns initial distances and parent links for single-source shortest paths
+func (g *Graph) initPaths(s int) ([]float64, []int) {
+	dist, parent := make([]float64, g.Order()), make([]int, g.Order())
+	for i := range dist {
+		dist[i], parent[i] = math.Inf(1), -1
+	}
+	dist[s] = 0
+
+	return dist, parent
+}
+
+// hasNegativeWeight returns true if the graph has an edge with negative weight
+func (g *Graph) hasNegativeWeight() bool {
+	for _, list := range g.adj {
+		for _, e := range list {
+			if e.Weight < 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
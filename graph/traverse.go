@@ -0,0 +1,130 @@
+package graph
+
+import "sort"
+
+// BFS traverses vertices reachable from s in breadth-first order and calls f for
+// each vertex with its parent in the traversal tree (-1 for s) and the number of
+// edges from s. The traversal stops when f returns false.
+func (g *Graph) BFS(s int, f func(v, parent, depth int) bool) {
+	g.checkVertex(s)
+
+	depth := make([]int, g.Order())
+	for i := range depth {
+		depth[i] = -1
+	}
+
+	depth[s] = 0
+	if !f(s, -1, 0) {
+		return
+	}
+
+	for queue := []int{s}; len(queue) != 0; queue = queue[1:] {
+		u := queue[0]
+		for _, e := range g.adj[u] {
+			if depth[e.To] != -1 {
+				// Already visited
+				continue
+			}
+
+			depth[e.To] = depth[u] + 1
+			if !f(e.To, u, depth[e.To]) {
+				return
+			}
+			queue = append(queue, e.To)
+		}
+	}
+}
+
+// Hops returns the minimal number of edges from s to each vertex, -1 for unreachable
+// vertices, and parent links of the shortest paths, see PathTo.
+func (g *Graph) Hops(s int) (dist, parent []int) {	//nolint:nonamedreturns
+	dist, parent = make([]int, g.Order()), make([]int, g.Order())
+	for i := range dist {
+		dist[i], parent[i] = -1, -1
+	}
+
+	g.BFS(s, func(v, p, depth int) bool {
+		dist[v], parent[v] = depth, p
+		return true
+	})
+
+	return dist, parent
+}
+
+// DFS traverses vertices reachable from s in depth-first order and calls f for
+// each vertex when it is discovered, with its parent in the traversal tree
+// (-1 for s). The traversal stops when f returns false.
+func (g *Graph) DFS(s int, f func(v, parent int) bool) {
+	g.checkVertex(s)
+
+	visited := make([]bool, g.Order())
+	g.dfs(s, visited, f, nil)
+}
+
+// dfs traverses vertices reachable from s that are not visited yet, it calls pre when
+// a vertex is discovered and post when all its descendants are finished. It returns
+// false if the traversal was stopped by pre.
+func (g *Graph) dfs(s int, visited []bool, pre func(v, parent int) bool, post func(v int)) bool {
+	// Stack of vertices with positions in their adjacency lists, emulates recursion
+	type frame struct {
+		v	int
+		next	int
+	}
+
+	visited[s] = true
+	if pre != nil && !pre(s, -1) {
+		return false
+	}
+
+	for stack := []frame{{v: s}}; len(stack) != 0; {
+		top := &stack[len(stack)-1]
+		if top.next == len(g.adj[top.v]) {
+			// All descendants are finished
+			if post != nil {
+				post(top.v)
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		e := g.adj[top.v][top.next]
+		top.next++
+		if visited[e.To] {
+			continue
+		}
+
+		visited[e.To] = true
+		if pre != nil && !pre(e.To, top.v) {
+			return false
+		}
+		stack = append(stack, frame{v: e.To})
+	}
+
+	return true
+}
+
+// Components returns connected components of the undirected graph, vertices of each
+// component are in ascending order, components are ordered by their minimal vertices.
+func (g *Graph) Components() ([][]int, error) {
+	if g.kind != Undirected {
+		return nil, ErrDirected
+	}
+
+	visited := make([]bool, g.Order())
+	var comps [][]int
+	for v := range g.adj {
+		if visited[v] {
+			continue
+		}
+
+		var comp []int
+		g.dfs(v, visited, func(u, _ int) bool {
+			comp = append(comp, u)
+			return true
+		}, nil)
+		sort.Ints(comp)
+		comps = append(comps, comp)
+	}
+
+	return comps, nil
+}
@@ -0,0 +1,356 @@
+package hashtab
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+)
+
+const (
+	// ChainMaxLoadFactor is the maximal load factor of ChainMap
+	ChainMaxLoadFactor	=	0.75
+	// TreeifyThreshold is the length of the bucket list above which it is converted to the tree
+	TreeifyThreshold	=	8
+	// UntreeifyThreshold is the number of entries of the bucket tree below which it is converted
+	// back to the list, it is less than TreeifyThreshold to avoid conversions on each operation
+	UntreeifyThreshold	=	6
+)
+
+// bucket contains entries with the same index in the table, either in the list or in the tree
+type bucket[K comparable, V any] struct {
+	list	[]entry[K, V]
+	// Entries with the same tree key are kept in the slice in the node data, nil if the list is used
+	tree	*rbtree.RBTree
+	// Number of entries in the tree
+	treeLen	int
+}
+
+// ChainMap implements a hash table with separate chaining.
+type ChainMap[K comparable, V any] struct {
+	buckets	[]bucket[K, V]
+	n		int
+	hash	Hasher[K]
+	// Convert long lists to trees
+	treeify	bool
+}
+
+// Make sure that the table implements the common interface
+var _ Map[int, int] = (*ChainMap[int, int])(nil)
+
+// NewChainMap returns new empty hash table with separate chaining, hash is used to hash keys.
+// If treeify is true, buckets longer than TreeifyThreshold are converted to red-black trees.
+func NewChainMap[K comparable, V any](hash Hasher[K], treeify bool) *ChainMap[K, V] {
+	return &ChainMap[K, V]{
+		buckets:	make([]bucket[K, V], MinCapacity),
+		hash:		hash,
+		treeify:	treeify,
+	}
+}
+
+// Len returns the number of entries
+func (m *ChainMap[K, V]) Len() int {
+	return m.n
+}
+
+// LoadFactor returns the ratio of the number of entries to the number of buckets
+func (m *ChainMap[K, V]) LoadFactor() float64 {
+	return float64(m.n) / float64(len(m.buckets))
+}
+
+// Get returns the value associated with the key k and true, or zero value and false if there is no such key
+func (m *ChainMap[K, V]) Get(k K) (V, bool) {
+	h := m.hash(k)
+	if e := m.bucketOf(h).find(k, h); e != nil {
+		return e.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Put associates the value v with the key k, it returns true if the key k was added
+// and false if the value of the existing key was replaced
+func (m *ChainMap[K, V]) Put(k K, v V) bool {
+	h := m.hash(k)
+	if e := m.bucketOf(h).find(k, h); e != nil {
+		e.value = v
+		return false
+	}
+
+	if c := newCapacity(m.n + 1, len(m.buckets), ChainMaxLoadFactor); c != len(m.buckets) {
+		m.resize(c)
+	}
+
+	m.bucketOf(h).add(entry[K, V]{key: k, value: v, hash: h}, m.treeify)
+	m.n++
+
+	return true
+}
+
+// Delete deletes the key k and returns its value and true, or zero value and false if there is no such key
+func (m *ChainMap[K, V]) Delete(k K) (V, bool) {
+	h := m.hash(k)
+	v, ok := m.bucketOf(h).remove(k, h)
+	if !ok {
+		return v, false
+	}
+	m.n--
+
+	if c := newCapacity(m.n, len(m.buckets), ChainMaxLoadFactor); c != len(m.buckets) {
+		m.resize(c)
+	}
+
+	return v, true
+}
+
+// Range calls f for each entry in undefined order until f returns false
+func (m *ChainMap[K, V]) Range(f func(k K, v V) bool) {
+	for i := range m.buckets {
+		if !m.buckets[i].each(func(e *entry[K, V]) bool { return f(e.key, e.value) }) {
+			return
+		}
+	}
+}
+
+// SelfTest checks the invariants of the table and returns the maximal number of entries
+// compared with a key to find it, and a description of the problem if detected. If an
+// issue is detected, the returned number is zero.
+func (m *ChainMap[K, V]) SelfTest() (int, error) {
+	n, maxLen := 0, 0
+	mask := uint64(len(m.buckets) - 1)
+	for i := range m.buckets {
+		b := &m.buckets[i]
+
+		// Check bucket form
+		switch {
+		case b.tree != nil && (!m.treeify || b.treeLen <= UntreeifyThreshold):
+			return 0, fmt.Errorf("v#4: bucket %d is a tree with %d entries", i, b.treeLen)
+		case b.tree == nil && m.treeify && len(b.list) > TreeifyThreshold:
+			return 0, fmt.Errorf("v#4: bucket %d is a list with %d entries", i, len(b.list))
+		case b.tree != nil:
+			if _, err := b.tree.SelfTest(); err != nil {
+				return 0, fmt.Errorf("v#5: tree of bucket %d is broken: %w", i, err)
+			}
+		}
+
+		var err error
+		bn := 0
+		b.each(func(e *entry[K, V]) bool {
+			if e.hash & mask != uint64(i) || e.hash != m.hash(e.key) {
+				err = fmt.Errorf("v#1: key %v with hash %#x is in bucket %d", e.key, e.hash, i)
+				return false
+			}
+			bn++
+			return true
+		})
+		if err != nil {
+			return 0, err
+		}
+		if b.tree != nil && bn != b.treeLen {
+			return 0, fmt.Errorf("v#2: tree of bucket %d has %d entries, want - %d", i, b.treeLen, bn)
+		}
+
+		n += bn
+		if l := b.searchLen(); l > maxLen {
+			maxLen = l
+		}
+	}
+
+	if n != m.n {
+		return 0, fmt.Errorf("v#2: table has %d entries, want - %d", m.n, n)
+	}
+
+	if m.LoadFactor() > ChainMaxLoadFactor {
+		return 0, fmt.Errorf("v#3: load factor %v is greater than %v", m.LoadFactor(), ChainMaxLoadFactor)
+	}
+
+	return maxLen, nil
+}
+
+// bucketOf returns the bucket of the hash h
+func (m *ChainMap[K, V]) bucketOf(h uint64) *bucket[K, V] {
+	return &m.buckets[h & uint64(len(m.buckets) - 1)]
+}
+
+// resize moves all entries to the new table with the capacity
+func (m *ChainMap[K, V]) resize(capacity int) {
+	old := m.buckets
+	m.buckets = make([]bucket[K, V], capacity)
+
+	for i := range old {
+		old[i].each(func(e *entry[K, V]) bool {
+			m.bucketOf(e.hash).add(*e, m.treeify)
+			return true
+		})
+	}
+}
+
+// treeKey returns the key of the tree node for the hash h, keys of the tree are non-negative
+func treeKey(h uint64) rbtree.KeyType {
+	return rbtree.KeyType(h >> 1 & math.MaxInt)
+}
+
+// find returns the pointer to the entry with the key k and its hash h, or nil
+func (b *bucket[K, V]) find(k K, h uint64) *entry[K, V] {
+	if b.tree == nil {
+		for i := range b.list {
+			if b.list[i].hash == h && b.list[i].key == k {
+				return &b.list[i]
+			}
+		}
+
+		return nil
+	}
+
+	n := b.tree.Search(treeKey(h))
+	if n == nil {
+		return nil
+	}
+
+	list := *n.Value().(*[]entry[K, V])
+	for i := range list {
+		if list[i].key == k {
+			return &list[i]
+		}
+	}
+
+	return nil
+}
+
+// add adds the new entry e to the bucket, the bucket is converted to the tree if required
+func (b *bucket[K, V]) add(e entry[K, V], treeify bool) {
+	if b.tree != nil {
+		b.treeAdd(e)
+		return
+	}
+
+	b.list = append(b.list, e)
+	if !treeify || len(b.list) <= TreeifyThreshold {
+		return
+	}
+
+	// Convert the list to the tree
+	b.tree = rbtree.NewRBTree()
+	for _, e := range b.list {
+		b.treeAdd(e)
+	}
+	b.list = nil
+}
+
+// treeAdd adds the new entry e to the tree of the bucket
+func (b *bucket[K, V]) treeAdd(e entry[K, V]) {
+	b.treeLen++
+
+	tk := treeKey(e.hash)
+	if n := b.tree.Search(tk); n != nil {
+		// Entries with the same tree key
+		list := n.Value().(*[]entry[K, V])
+		*list = append(*list, e)
+		return
+	}
+
+	b.tree.Insert(rbtree.NewRBNode(tk, &[]entry[K, V]{e}))
+}
+
+// remove removes the entry with the key k and its hash h, the bucket is converted
+// to the list if required
+func (b *bucket[K, V]) remove(k K, h uint64) (V, bool) {
+	var zero V
+
+	if b.tree == nil {
+		for i := range b.list {
+			if b.list[i].hash == h && b.list[i].key == k {
+				v := b.list[i].value
+				// Replace the entry by the last one
+				last := len(b.list) - 1
+				b.list[i] = b.list[last]
+				b.list[last] = entry[K, V]{}
+				b.list = b.list[:last]
+
+				return v, true
+			}
+		}
+
+		return zero, false
+	}
+
+	n := b.tree.Search(treeKey(h))
+	if n == nil {
+		return zero, false
+	}
+
+	list := n.Value().(*[]entry[K, V])
+	for i := range *list {
+		if (*list)[i].key != k {
+			continue
+		}
+
+		v := (*list)[i].value
+		*list = append((*list)[:i], (*list)[i+1:]...)
+		if len(*list) == 0 {
+			b.tree.Delete(n)
+		}
+		b.treeLen--
+
+		if b.treeLen <= UntreeifyThreshold {
+			// Convert the tree back to the list
+			b.list = make([]entry[K, V], 0, TreeifyThreshold)
+			b.each(func(e *entry[K, V]) bool {
+				b.list = append(b.list, *e)
+				return true
+			})
+			b.tree, b.treeLen = nil, 0
+		}
+
+		return v, true
+	}
+
+	return zero, false
+}
+
+// each calls f for each entry of the bucket until f returns false, it returns false if f did
+func (b *bucket[K, V]) each(f func(e *entry[K, V]) bool) bool {
+	if b.tree == nil {
+		for i := range b.list {
+			if !f(&b.list[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for n := b.tree.Min(); n != nil; n = b.tree.Successor(n) {
+		list := *n.Value().(*[]entry[K, V])
+		for i := range list {
+			if !f(&list[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// searchLen returns the maximal number of entries compared with a key to find it in the bucket
+func (b *bucket[K, V]) searchLen() int {
+	if b.tree == nil {
+		return len(b.list)
+	}
+
+	maxLen := 0
+	for n := b.tree.Min(); n != nil; n = b.tree.Successor(n) {
+		// Nodes on the path from the root and entries with the same tree key
+		l := len(*n.Value().(*[]entry[K, V]))
+		for p := n; p != nil; p = p.Parent() {
+			l++
+		}
+
+		if l > maxLen {
+			maxLen = l
+		}
+	}
+
+	return maxLen
+}
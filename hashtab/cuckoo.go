@@ -0,0 +1,287 @@
+package hashtab
+
+import "fmt"
+
+const (
+	// CuckooMaxLoadFactor is the maximal load factor of CuckooMap
+	CuckooMaxLoadFactor	=	0.45
+	// cuckooMaxKicks is the maximal number of evictions during one insertion,
+	// the table is rebuilt with another hash function when it is exceeded
+	cuckooMaxKicks		=	64
+	// cuckooMaxRehashes is the number of attempts to rebuild the table with different
+	// seeds, entries that cannot be placed by the last attempt go to the stash
+	cuckooMaxRehashes	=	4
+	// cuckooMaxStash is the maximal length of the stash, the table is rebuilt with
+	// another seed when the stash overflows
+	cuckooMaxStash		=	8
+	// cuckooInitSeed is the initial seed of the second hash function
+	cuckooInitSeed		=	0x9e3779b97f4a7c15
+)
+
+// CuckooMap implements a hash table with cuckoo hashing: each key can be placed only in one
+// of two slots - in the first table by the hash of the key and in the second table by the
+// second hash function, that is derived from the hash with a seed. Insertion evicts the
+// entry from the occupied slot to its alternative slot.
+//
+// If evictions make a cycle, the last evicted entry is kept in the stash - a list of
+// at most cuckooMaxStash entries checked by lookups after both tables. When the stash
+// overflows, the table is rebuilt with another seed of the second hash function, the
+// table is also rebuilt when it grows or shrinks.
+//
+// No seed can separate more than two keys with equal 64-bit hashes, such keys are the
+// failure of the hash function. If they do not fit the stash after the rebuild, the limit
+// of the stash is raised to twice the length of the stash until the next rebuild, so
+// rebuilds stay rare, but lookups of these keys check the whole stash.
+type CuckooMap[K comparable, V any] struct {
+	tables		[2][]slot[K, V]
+	// Entries that have no place in the tables
+	stash		[]slot[K, V]
+	// Maximal length of the stash until the next rebuild
+	stashLimit	int
+	n		int
+	hash		Hasher[K]
+	seed		uint64
+}
+
+// Make sure that the table implements the common interface
+var _ Map[int, int] = (*CuckooMap[int, int])(nil)
+
+// NewCuckooMap returns new empty hash table with cuckoo hashing, hash is used to hash keys.
+func NewCuckooMap[K comparable, V any](hash Hasher[K]) *CuckooMap[K, V] {
+	m := &CuckooMap[K, V]{hash: hash, seed: cuckooInitSeed, stashLimit: cuckooMaxStash}
+	m.tables[0] = make([]slot[K, V], MinCapacity / 2)
+	m.tables[1] = make([]slot[K, V], MinCapacity / 2)
+
+	return m
+}
+
+// Len returns the number of entries
+func (m *CuckooMap[K, V]) Len() int {
+	return m.n
+}
+
+// LoadFactor returns the ratio of the number of entries to the number of slots in both tables
+func (m *CuckooMap[K, V]) LoadFactor() float64 {
+	return float64(m.n) / float64(m.capacity())
+}
+
+// Get returns the value associated with the key k and true, or zero value and false if there is no such key
+func (m *CuckooMap[K, V]) Get(k K) (V, bool) {
+	if s := m.find(k, m.hash(k)); s != nil {
+		return s.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Put associates the value v with the key k, it returns true if the key k was added
+// and false if the value of the existing key was replaced
+func (m *CuckooMap[K, V]) Put(k K, v V) bool {
+	h := m.hash(k)
+	if s := m.find(k, h); s != nil {
+		s.value = v
+		return false
+	}
+
+	if capacity := newCapacity(m.n + 1, m.capacity(), CuckooMaxLoadFactor); capacity != m.capacity() {
+		m.rebuild(capacity)
+	}
+
+	if e, ok := m.add(entry[K, V]{key: k, value: v, hash: h}); !ok {
+		// The evicted entry has no place, keep it in the stash until the next rebuild
+		m.stash = append(m.stash, slot[K, V]{entry: e, used: true})
+	}
+	m.n++
+
+	if len(m.stash) > m.stashLimit {
+		// The stash overflowed, try another second hash function
+		m.seed = mix(m.seed)
+		m.rebuild(m.capacity())
+	}
+
+	return true
+}
+
+// Delete deletes the key k and returns its value and true, or zero value and false if there is no such key
+func (m *CuckooMap[K, V]) Delete(k K) (V, bool) {
+	s := m.find(k, m.hash(k))
+	if s == nil {
+		var zero V
+		return zero, false
+	}
+
+	v := s.value
+	*s = slot[K, V]{}
+	m.n--
+
+	// Remove the emptied slot of the stash, if any
+	for i := range m.stash {
+		if !m.stash[i].used {
+			last := len(m.stash) - 1
+			m.stash[i] = m.stash[last]
+			m.stash = m.stash[:last]
+			break
+		}
+	}
+
+	if c := newCapacity(m.n, m.capacity(), CuckooMaxLoadFactor); c != m.capacity() {
+		m.rebuild(c)
+	}
+
+	return v, true
+}
+
+// Range calls f for each entry in undefined order until f returns false
+func (m *CuckooMap[K, V]) Range(f func(k K, v V) bool) {
+	for _, table := range [...][]slot[K, V]{m.tables[0], m.tables[1], m.stash} {
+		for i := range table {
+			if table[i].used && !f(table[i].key, table[i].value) {
+				return
+			}
+		}
+	}
+}
+
+// SelfTest checks the invariants of the table and returns the maximal number of slots
+// checked to find a key - 1 if all keys are in the first table, 2 plus the length of
+// the stash otherwise, and a description of the problem if detected. If an issue is
+// detected, the returned number is zero.
+func (m *CuckooMap[K, V]) SelfTest() (int, error) {
+	n, maxProbes := 0, 0
+	for t, table := range m.tables {
+		for i := range table {
+			if !table[i].used {
+				continue
+			}
+			n++
+
+			if h := table[i].hash; h != m.hash(table[i].key) || m.index(t, h) != i {
+				return 0, fmt.Errorf("v#1: key %v with hash %#x is in wrong slot %d of table %d",
+					table[i].key, h, i, t)
+			}
+
+			if t + 1 > maxProbes {
+				maxProbes = t + 1
+			}
+		}
+	}
+
+	for i := range m.stash {
+		if !m.stash[i].used || m.stash[i].hash != m.hash(m.stash[i].key) {
+			return 0, fmt.Errorf("v#4: invalid entry %d of the stash with key %v", i, m.stash[i].key)
+		}
+		n++
+	}
+	if len(m.stash) != 0 {
+		maxProbes = 2 + len(m.stash)
+	}
+
+	if len(m.stash) > m.stashLimit || m.stashLimit < cuckooMaxStash {
+		return 0, fmt.Errorf("v#5: stash has %d entries, the limit is %d", len(m.stash), m.stashLimit)
+	}
+
+	if n != m.n {
+		return 0, fmt.Errorf("v#2: table has %d entries, want - %d", m.n, n)
+	}
+
+	if m.LoadFactor() > CuckooMaxLoadFactor {
+		return 0, fmt.Errorf("v#3: load factor %v is greater than %v", m.LoadFactor(), CuckooMaxLoadFactor)
+	}
+
+	return maxProbes, nil
+}
+
+// capacity returns the number of slots in both tables
+func (m *CuckooMap[K, V]) capacity() int {
+	return 2 * len(m.tables[0])
+}
+
+// index returns the slot of the hash h in the table t
+func (m *CuckooMap[K, V]) index(t int, h uint64) int {
+	if t == 1 {
+		// The second hash function
+		h = mix(h ^ m.seed)
+	}
+
+	return int(h & uint64(len(m.tables[t]) - 1))
+}
+
+// find returns the slot with the key k and its hash h, or nil
+func (m *CuckooMap[K, V]) find(k K, h uint64) *slot[K, V] {
+	for t := range m.tables {
+		if s := &m.tables[t][m.index(t, h)]; s.used && s.hash == h && s.key == k {
+			return s
+		}
+	}
+
+	for i := range m.stash {
+		if s := &m.stash[i]; s.hash == h && s.key == k {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// add inserts the new entry e evicting entries to their alternative slots. It returns false
+// with the last evicted entry if the number of evictions exceeds the limit.
+func (m *CuckooMap[K, V]) add(e entry[K, V]) (entry[K, V], bool) {
+	for kick, t := 0, 0; kick < cuckooMaxKicks; kick, t = kick + 1, t ^ 1 {
+		s := &m.tables[t][m.index(t, e.hash)]
+		if !s.used {
+			*s = slot[K, V]{entry: e, used: true}
+			return e, true
+		}
+
+		// Evict the entry, it goes to the other table
+		e, s.entry = s.entry, e
+	}
+
+	return e, false
+}
+
+// rebuild places all entries to the new tables with the capacity, new seeds of the second hash
+// function are tried until all entries are placed, entries that cannot be placed by the last
+// attempt go to the stash. The limit of the stash is raised if they do not fit it.
+func (m *CuckooMap[K, V]) rebuild(capacity int) {
+	entries := make([]entry[K, V], 0, m.n)
+	for _, table := range [...][]slot[K, V]{m.tables[0], m.tables[1], m.stash} {
+		for i := range table {
+			if table[i].used {
+				entries = append(entries, table[i].entry)
+			}
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		m.tables[0] = make([]slot[K, V], capacity / 2)
+		m.tables[1] = make([]slot[K, V], capacity / 2)
+		m.stash = nil
+
+		var failed []entry[K, V]
+		for _, e := range entries {
+			if last, placed := m.add(e); !placed {
+				// The last evicted entry has no place
+				failed = append(failed, last)
+			}
+		}
+
+		if len(failed) == 0 || attempt == cuckooMaxRehashes {
+			for _, e := range failed {
+				m.stash = append(m.stash, slot[K, V]{entry: e, used: true})
+			}
+
+			m.stashLimit = cuckooMaxStash
+			if len(m.stash) > cuckooMaxStash {
+				// No seed helped, e.g. keys have equal hashes
+				m.stashLimit = 2 * len(m.stash)
+			}
+
+			return
+		}
+
+		// Try another second hash function
+		m.seed = mix(m.seed)
+	}
+}
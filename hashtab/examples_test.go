@@ -0,0 +1,60 @@
+package hashtab
+
+import (
+	"fmt"
+	"sort"
+)
+
+func Example_commonInterface() {
+	tables := []Map[string, int]{
+		NewChainMap[string, int](HashString, true),
+		NewLinearMap[string, int](HashString),
+		NewRobinHoodMap[string, int](HashString),
+		NewCuckooMap[string, int](HashString),
+	}
+
+	for _, m := range tables {
+		for i, word := range []string{"one", "two", "three", "two"} {
+			m.Put(word, i)
+		}
+		m.Delete("one")
+
+		var keys []string
+		m.Range(func(k string, v int) bool {
+			keys = append(keys, fmt.Sprintf("%s=%d", k, v))
+			return true
+		})
+		// Order of iteration is undefined
+		sort.Strings(keys)
+
+		fmt.Printf("%T: %v\n", m, keys)
+	}
+
+	// Output:
+	// *hashtab.ChainMap[string,int]: [three=2 two=3]
+	// *hashtab.LinearMap[string,int]: [three=2 two=3]
+	// *hashtab.RobinHoodMap[string,int]: [three=2 two=3]
+	// *hashtab.CuckooMap[string,int]: [three=2 two=3]
+}
+
+func Example_collisionResistance() {
+	// Hash function that gives the same bucket for all keys
+	badHash := func(k int) uint64 { return uint64(k) << 32 }
+
+	list := NewChainMap[int, bool](badHash, false)
+	tree := NewChainMap[int, bool](badHash, true)
+	for k := 0; k < 1000; k++ {
+		list.Put(k, true)
+		tree.Put(k, true)
+	}
+
+	// Maximal number of entries compared to find a key
+	lc, _ := list.SelfTest()
+	tc, _ := tree.SelfTest()
+	fmt.Println("List buckets:", lc)
+	fmt.Println("Tree buckets:", tc)
+
+	// Output:
+	// List buckets: 1000
+	// Tree buckets: 18
+}
@@ -0,0 +1,112 @@
+/*
+Package hashtab provides examples of hash table implementations with a common
+map interface.
+
+The package contains the following tables:
+  - ChainMap - separate chaining, each bucket is a list of entries. Optionally,
+    long buckets are converted to red-black trees from the [rbtree] package
+    ordered by hashes of keys, like Java 8 HashMap does, so a lot of collisions
+    in one bucket costs O(log n) instead of O(n) per operation
+  - LinearMap - open addressing with linear probing and backward shift deletion
+    without tombstones
+  - RobinHoodMap - open addressing with linear probing, where entries far from
+    their home slots take slots of entries that are closer to their home slots,
+    it keeps probe sequences short even on high load factors
+  - CuckooMap - cuckoo hashing with two tables and two hash functions, lookup
+    checks two slots and a small stash of entries that have no place in the tables
+
+All tables grow twice when the load factor exceeds the maximal one of the table,
+and shrink twice when it falls below MinLoadFactor, capacities of tables are
+powers of two not less than MinCapacity.
+
+Keys are hashed by a user-supplied function, the package provides HashInt and
+HashString functions for integer and string keys.
+
+[rbtree]: https://pkg.go.dev/github.com/r-che/algorithms/bst/rbtree
+*/
+package hashtab
+
+import "hash/maphash"
+
+const (
+	// MinCapacity is the minimal number of slots or buckets of a table
+	MinCapacity		=	8
+	// MinLoadFactor is the load factor below which tables shrink
+	MinLoadFactor	=	0.125
+)
+
+// Map is the common interface of the hash tables
+type Map[K comparable, V any] interface {
+	// Len returns the number of entries
+	Len() int
+	// Get returns the value associated with the key k and true, or zero value and false if there is no such key
+	Get(k K) (V, bool)
+	// Put associates the value v with the key k, it returns true if the key k was added
+	// and false if the value of the existing key was replaced
+	Put(k K, v V) bool
+	// Delete deletes the key k and returns its value and true, or zero value and false if there is no such key
+	Delete(k K) (V, bool)
+	// Range calls f for each entry in undefined order until f returns false,
+	// the table must not be modified by f
+	Range(f func(k K, v V) bool)
+	// LoadFactor returns the ratio of the number of entries to the capacity of the table
+	LoadFactor() float64
+	// SelfTest checks the invariants of the table and returns the maximal number of slots or
+	// entries that have to be checked to find a key, and a description of the problem if detected.
+	// If an issue is detected, the returned number is zero
+	SelfTest() (int, error)
+}
+
+// Hasher returns a 64-bit hash of the key, all bits of the hash should be well mixed
+type Hasher[K comparable] func(k K) uint64
+
+// Integer is the constraint of integer key types
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// HashInt returns the hash of the integer k using the finalizer of the SplitMix64 generator.
+func HashInt[K Integer](k K) uint64 {
+	return mix(uint64(k))
+}
+
+//nolint:gochecknoglobals // Seed is chosen once per process to make hashes unpredictable
+var stringSeed = maphash.MakeSeed()
+
+// HashString returns the hash of the string s. The hash function is seeded randomly
+// when the program starts, so it is hard to find collisions in advance.
+func HashString(s string) uint64 {
+	return maphash.String(stringSeed, s)
+}
+
+// mix mixes bits of x
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
+
+// entry is a key-value pair with the hash of the key
+type entry[K comparable, V any] struct {
+	key		K
+	value	V
+	hash	uint64
+}
+
+// newCapacity returns the capacity of the table required to keep n entries, it is twice greater or
+// less than the current capacity if the load factor goes out of range [MinLoadFactor, maxLoad]
+func newCapacity(n, capacity int, maxLoad float64) int {
+	switch {
+	case float64(n) > maxLoad * float64(capacity):
+		return capacity * 2
+	case capacity > MinCapacity && float64(n) < MinLoadFactor * float64(capacity):
+		return capacity / 2
+	}
+
+	return capacity
+}
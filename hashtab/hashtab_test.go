@@ -0,0 +1,326 @@
+package hashtab
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Tables are resized several times while keysCount keys are inserted
+	keysCount	=	10240
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2041
+
+	// Number of random operations for each table
+	opsCount	=	4 * keysCount
+	// Run self-test after each selfTestStep modifications
+	selfTestStep	=	64
+	// Number of keys with colliding hashes
+	collisionsCount	=	1024
+)
+
+// newMaps returns all tables with integer keys hashed by hash
+func newMaps(hash Hasher[int]) map[string]Map[int, int] {
+	return map[string]Map[int, int]{
+		"Chain":		NewChainMap[int, int](hash, false),
+		"ChainTree":	NewChainMap[int, int](hash, true),
+		"Linear":		NewLinearMap[int, int](hash),
+		"RobinHood":	NewRobinHoodMap[int, int](hash),
+		"Cuckoo":		NewCuckooMap[int, int](hash),
+	}
+}
+
+// capacityOf returns the number of buckets or slots of the table
+func capacityOf(m Map[int, int]) int {
+	switch m := m.(type) {
+	case *ChainMap[int, int]:
+		return len(m.buckets)
+	case *LinearMap[int, int]:
+		return len(m.slots)
+	case *RobinHoodMap[int, int]:
+		return len(m.slots)
+	case *CuckooMap[int, int]:
+		return m.capacity()
+	}
+
+	panic(fmt.Sprintf("unexpected table type %T", m))
+}
+
+// checkModel checks that the table contains the same entries as the model
+func checkModel(t *testing.T, name string, m Map[int, int], model map[int]int) {
+	t.Helper()
+
+	if m.Len() != len(model) {
+		t.Fatalf("[%s] Len returned %d, want - %d", name, m.Len(), len(model))
+	}
+
+	seen := make(map[int]bool, len(model))
+	m.Range(func(k, v int) bool {
+		if mv, ok := model[k]; !ok || mv != v || seen[k] {
+			t.Fatalf("[%s] Range returned unexpected or repeated entry %d: %d", name, k, v)
+		}
+		seen[k] = true
+		return true
+	})
+
+	if len(seen) != len(model) {
+		t.Fatalf("[%s] Range returned %d entries, want - %d", name, len(seen), len(model))
+	}
+}
+
+func TestRandomOps(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for name, m := range newMaps(HashInt[int]) {
+		model := map[int]int{}
+
+		for i := 0; i < opsCount; i++ {
+			k := testKeys[rnd.Int() % keysCount]
+			_, exists := model[k]
+
+			switch op := rnd.Int() % 3; op {
+			case 0:
+				if added := m.Put(k, i); added == exists {
+					t.Fatalf("[%s:%d] Put(%d) returned %t, key exists - %t", name, i, k, added, exists)
+				}
+				model[k] = i
+			case 1:
+				v, ok := m.Delete(k)
+				if ok != exists || v != model[k] {
+					t.Fatalf("[%s:%d] Delete(%d) returned %d, %t, want - %d, %t", name, i, k, v, ok, model[k], exists)
+				}
+				delete(model, k)
+			default:
+				v, ok := m.Get(k)
+				if ok != exists || v != model[k] {
+					t.Fatalf("[%s:%d] Get(%d) returned %d, %t, want - %d, %t", name, i, k, v, ok, model[k], exists)
+				}
+			}
+
+			if i % selfTestStep != 0 {
+				continue
+			}
+
+			if _, err := m.SelfTest(); err != nil {
+				t.Fatalf("[%s:%d] table structure issue: %v", name, i, err)
+			}
+		}
+
+		checkModel(t, name, m, model)
+	}
+}
+
+func TestResize(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for name, m := range newMaps(HashInt[int]) {
+		model := map[int]int{}
+		for i, k := range testKeys {
+			m.Put(k, -k)
+			model[k] = -k
+
+			if i % selfTestStep != 0 {
+				continue
+			}
+			if _, err := m.SelfTest(); err != nil {
+				t.Fatalf("[%s:%d] table structure issue after insertion of %d: %v", name, i, k, err)
+			}
+		}
+		checkModel(t, name, m, model)
+
+		if lf := m.LoadFactor(); lf < MinLoadFactor {
+			t.Errorf("[%s] load factor %v is less than %v after insertions", name, lf, MinLoadFactor)
+		}
+
+		for i, k := range testKeys {
+			if v, ok := m.Delete(k); !ok || v != -k {
+				t.Fatalf("[%s:%d] Delete(%d) returned %d, %t", name, i, k, v, ok)
+			}
+
+			if i % selfTestStep != 0 {
+				continue
+			}
+			if _, err := m.SelfTest(); err != nil {
+				t.Fatalf("[%s:%d] table structure issue after deletion of %d: %v", name, i, k, err)
+			}
+		}
+
+		if c := capacityOf(m); m.Len() != 0 || c != MinCapacity {
+			t.Errorf("[%s] table has %d entries and capacity %d after deletion of all keys, want - 0, %d",
+				name, m.Len(), c, MinCapacity)
+		}
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, collisionsCount, MaxItem)
+
+	// All hashes have the same lower bits, so all keys have the same bucket or home slot
+	collide := func(k int) uint64 { return uint64(k) << 32 }
+
+	maps := newMaps(collide)
+
+	for name, m := range maps {
+		model := map[int]int{}
+		for _, k := range testKeys {
+			m.Put(k, k)
+			model[k] = k
+		}
+		for _, k := range testKeys[:collisionsCount/2] {
+			m.Delete(k)
+			delete(model, k)
+		}
+		checkModel(t, name, m, model)
+
+		probes, err := m.SelfTest()
+		if err != nil {
+			t.Fatalf("[%s] table structure issue: %v", name, err)
+		}
+
+		// Trees keep lookup logarithmic, cuckoo hashing places a part of keys by the second
+		// hash function and keeps the rest in the stash, other tables check all colliding entries
+		if name == "ChainTree" && probes > 2 * 10 || name == "Cuckoo" && probes >= len(model) ||
+		   name != "ChainTree" && name != "Cuckoo" && probes < collisionsCount / 2 {
+			t.Errorf("[%s] lookup checks %d entries for %d colliding keys", name, probes, len(model))
+		}
+	}
+}
+
+func TestCuckooEqualHashes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, collisionsCount, MaxItem)
+
+	// All keys have equal hashes, only two of them can be placed to the tables
+	m := NewCuckooMap[int, int](func(k int) uint64 { return 42 })
+	model := map[int]int{}
+
+	for _, k := range testKeys {
+		m.Put(k, k)
+		model[k] = k
+	}
+	for _, k := range testKeys[:collisionsCount/2] {
+		m.Delete(k)
+		delete(model, k)
+	}
+	checkModel(t, "Cuckoo", m, model)
+
+	probes, err := m.SelfTest()
+	if err != nil {
+		t.Fatalf("table structure issue: %v", err)
+	}
+	// Two keys are in the tables and the rest in the stash
+	if want := len(model); probes != want {
+		t.Errorf("lookup checks %d entries for %d keys with equal hashes, want - %d", probes, len(model), want)
+	}
+}
+
+func TestCuckooStashLimit(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	m := NewCuckooMap[int, int](HashInt[int])
+	model := map[int]int{}
+
+	for _, k := range testKeys {
+		m.Put(k, k)
+		model[k] = k
+
+		// Keys with distinct hashes never overflow the stash
+		if len(m.stash) > cuckooMaxStash {
+			t.Fatalf("stash has %d entries after %d keys, the limit is %d", len(m.stash), len(model), cuckooMaxStash)
+		}
+	}
+	checkModel(t, "Cuckoo", m, model)
+
+	if probes, err := m.SelfTest(); err != nil || probes > 2 + cuckooMaxStash {
+		t.Errorf("SelfTest returned %d, %v, want - at most %d, nil", probes, err, 2 + cuckooMaxStash)
+	}
+}
+
+func TestHashString(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	if HashString("key") != HashString("key") {
+		t.Errorf("HashString returned different hashes for equal strings")
+	}
+
+	hashes := map[uint64]bool{}
+	for _, k := range testKeys {
+		hashes[HashString(fmt.Sprint(k))] = true
+	}
+	if len(hashes) != len(testKeys) {
+		t.Errorf("HashString returned %d unique hashes for %d strings", len(hashes), len(testKeys))
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	for i, test := range []struct {
+		name	string
+		breaker	func(m Map[int, int])
+		want	string
+	} {
+		{ "Chain", func(m Map[int, int]) { m.(*ChainMap[int, int]).buckets[0].list[0].hash++ }, "v#1" },
+		{ "Chain", func(m Map[int, int]) { m.(*ChainMap[int, int]).n++ }, "v#2" },
+		{ "Chain", func(m Map[int, int]) { m.(*ChainMap[int, int]).buckets = m.(*ChainMap[int, int]).buckets[:1] }, "v#3" },
+		{ "ChainTree", func(m Map[int, int]) {
+			b := &m.(*ChainMap[int, int]).buckets[0]
+			for i := 0; i < TreeifyThreshold; i++ {
+				b.list = append(b.list, b.list[0])
+			}
+		}, "v#4" },
+		{ "Linear", func(m Map[int, int]) {
+			// Remove the entry in the home slot of the next one
+			s := m.(*LinearMap[int, int]).slots
+			for i := range s {
+				if s[i].used && s[(i+1) % len(s)].used && int(s[(i+1) % len(s)].hash) & (len(s) - 1) == i {
+					s[i] = slot[int, int]{}
+					return
+				}
+			}
+		}, "v#1" },
+		{ "Linear", func(m Map[int, int]) { m.(*LinearMap[int, int]).n-- }, "v#2" },
+		{ "RobinHood", func(m Map[int, int]) { m.(*RobinHoodMap[int, int]).slots[0].dist++ }, "v#1" },
+		{ "RobinHood", func(m Map[int, int]) { m.(*RobinHoodMap[int, int]).n++ }, "v#2" },
+		{ "Cuckoo", func(m Map[int, int]) {
+			c := m.(*CuckooMap[int, int])
+			c.tables[0], c.tables[1] = c.tables[1], c.tables[0]
+		}, "v#1" },
+		{ "Cuckoo", func(m Map[int, int]) { m.(*CuckooMap[int, int]).n++ }, "v#2" },
+		{ "Cuckoo", func(m Map[int, int]) {
+			c := m.(*CuckooMap[int, int])
+			c.stash = append(c.stash, slot[int, int]{entry: entry[int, int]{key: -1, hash: 1}, used: true})
+			c.n++
+		}, "v#4" },
+		{ "Cuckoo", func(m Map[int, int]) { m.(*CuckooMap[int, int]).stashLimit = -1 }, "v#5" },
+	} {
+		// Keys colliding in the first bucket or slot
+		m := newMaps(func(k int) uint64 { return uint64(k) << 32 | uint64(k % 2) })[test.name]
+		for k := 0; k < MinCapacity / 2; k += 2 {
+			m.Put(k, k)
+		}
+		if _, err := m.SelfTest(); err != nil {
+			t.Fatalf("[%d] table structure issue before breaking: %v", i, err)
+		}
+
+		test.breaker(m)
+
+		n, err := m.SelfTest()
+		if err == nil || n != 0 {
+			t.Errorf("[%d] SelfTest returned %d, %v on broken %s table, want - 0, %s error",
+				i, n, err, test.name, test.want)
+			continue
+		}
+
+		if msg := err.Error(); msg[:len(test.want)] != test.want {
+			t.Errorf("[%d] SelfTest returned error %q on %s table, want - %s error", i, msg, test.name, test.want)
+		}
+	}
+}
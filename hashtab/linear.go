@@ -0,0 +1,183 @@
+package hashtab
+
+import "fmt"
+
+// LinearMaxLoadFactor is the maximal load factor of LinearMap
+const LinearMaxLoadFactor = 0.7
+
+// slot is a slot of the open addressing table
+type slot[K comparable, V any] struct {
+	entry[K, V]
+	used	bool
+}
+
+// LinearMap implements a hash table with open addressing and linear probing.
+type LinearMap[K comparable, V any] struct {
+	slots	[]slot[K, V]
+	n		int
+	hash	Hasher[K]
+}
+
+// Make sure that the table implements the common interface
+var _ Map[int, int] = (*LinearMap[int, int])(nil)
+
+// NewLinearMap returns new empty hash table with linear probing, hash is used to hash keys.
+func NewLinearMap[K comparable, V any](hash Hasher[K]) *LinearMap[K, V] {
+	return &LinearMap[K, V]{
+		slots:	make([]slot[K, V], MinCapacity),
+		hash:	hash,
+	}
+}
+
+// Len returns the number of entries
+func (m *LinearMap[K, V]) Len() int {
+	return m.n
+}
+
+// LoadFactor returns the ratio of the number of entries to the number of slots
+func (m *LinearMap[K, V]) LoadFactor() float64 {
+	return float64(m.n) / float64(len(m.slots))
+}
+
+// Get returns the value associated with the key k and true, or zero value and false if there is no such key
+func (m *LinearMap[K, V]) Get(k K) (V, bool) {
+	if i := m.find(k, m.hash(k)); i != -1 {
+		return m.slots[i].value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Put associates the value v with the key k, it returns true if the key k was added
+// and false if the value of the existing key was replaced
+func (m *LinearMap[K, V]) Put(k K, v V) bool {
+	h := m.hash(k)
+	if i := m.find(k, h); i != -1 {
+		m.slots[i].value = v
+		return false
+	}
+
+	if c := newCapacity(m.n + 1, len(m.slots), LinearMaxLoadFactor); c != len(m.slots) {
+		m.resize(c)
+	}
+
+	m.add(entry[K, V]{key: k, value: v, hash: h})
+	m.n++
+
+	return true
+}
+
+// Delete deletes the key k and returns its value and true, or zero value and false if there is no such key
+func (m *LinearMap[K, V]) Delete(k K) (V, bool) {
+	i := m.find(k, m.hash(k))
+	if i == -1 {
+		var zero V
+		return zero, false
+	}
+
+	v := m.slots[i].value
+	mask := len(m.slots) - 1
+
+	// Shift entries of the probe sequence back to fill the hole, so no tombstones are required
+	for j := (i + 1) & mask; m.slots[j].used; j = (j + 1) & mask {
+		// The entry in j can fill the hole in i if its home slot is not in the cyclic range (i, j]
+		if home := int(m.slots[j].hash) & mask; (j - home) & mask >= (j - i) & mask {
+			m.slots[i] = m.slots[j]
+			i = j
+		}
+	}
+	m.slots[i] = slot[K, V]{}
+	m.n--
+
+	if c := newCapacity(m.n, len(m.slots), LinearMaxLoadFactor); c != len(m.slots) {
+		m.resize(c)
+	}
+
+	return v, true
+}
+
+// Range calls f for each entry in undefined order until f returns false
+func (m *LinearMap[K, V]) Range(f func(k K, v V) bool) {
+	for i := range m.slots {
+		if m.slots[i].used && !f(m.slots[i].key, m.slots[i].value) {
+			return
+		}
+	}
+}
+
+// SelfTest checks the invariants of the table and returns the maximal number of slots
+// checked to find a key, and a description of the problem if detected. If an issue is
+// detected, the returned number is zero.
+func (m *LinearMap[K, V]) SelfTest() (int, error) {
+	n, maxProbes := 0, 0
+	mask := len(m.slots) - 1
+	for i := range m.slots {
+		if !m.slots[i].used {
+			continue
+		}
+		n++
+
+		if m.slots[i].hash != m.hash(m.slots[i].key) {
+			return 0, fmt.Errorf("v#1: key %v in slot %d has wrong hash %#x", m.slots[i].key, i, m.slots[i].hash)
+		}
+
+		// All slots from the home slot to the entry must be used, otherwise the key is unreachable
+		home := int(m.slots[i].hash) & mask
+		for j := home; j != i; j = (j + 1) & mask {
+			if !m.slots[j].used {
+				return 0, fmt.Errorf("v#1: key %v in slot %d is unreachable from slot %d due to empty slot %d",
+					m.slots[i].key, i, home, j)
+			}
+		}
+
+		if probes := (i - home) & mask + 1; probes > maxProbes {
+			maxProbes = probes
+		}
+	}
+
+	if n != m.n {
+		return 0, fmt.Errorf("v#2: table has %d entries, want - %d", m.n, n)
+	}
+
+	if m.LoadFactor() > LinearMaxLoadFactor {
+		return 0, fmt.Errorf("v#3: load factor %v is greater than %v", m.LoadFactor(), LinearMaxLoadFactor)
+	}
+
+	return maxProbes, nil
+}
+
+// find returns the slot with the key k and its hash h, or -1
+func (m *LinearMap[K, V]) find(k K, h uint64) int {
+	mask := len(m.slots) - 1
+	for i := int(h) & mask; m.slots[i].used; i = (i + 1) & mask {
+		if m.slots[i].hash == h && m.slots[i].key == k {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// add puts the new entry e to the first empty slot of its probe sequence
+func (m *LinearMap[K, V]) add(e entry[K, V]) {
+	mask := len(m.slots) - 1
+	i := int(e.hash) & mask
+	for m.slots[i].used {
+		i = (i + 1) & mask
+	}
+
+	m.slots[i] = slot[K, V]{entry: e, used: true}
+}
+
+// resize moves all entries to the new table with the capacity
+func (m *LinearMap[K, V]) resize(capacity int) {
+	old := m.slots
+	m.slots = make([]slot[K, V], capacity)
+
+	for i := range old {
+		if old[i].used {
+			m.add(old[i].entry)
+		}
+	}
+}
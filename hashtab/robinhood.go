@@ -0,0 +1,191 @@
+package hashtab
+
+import "fmt"
+
+// RobinHoodMaxLoadFactor is the maximal load factor of RobinHoodMap
+const RobinHoodMaxLoadFactor = 0.9
+
+// rhSlot is a slot of the Robin Hood table
+type rhSlot[K comparable, V any] struct {
+	slot[K, V]
+	// Distance from the home slot of the entry
+	dist	int
+}
+
+// RobinHoodMap implements a hash table with open addressing and Robin Hood hashing: during
+// insertion an entry takes the slot of an entry that is closer to its home slot, the latter
+// continues probing. Probe sequences are sorted by home slots, so lookup of the absent key
+// stops early.
+type RobinHoodMap[K comparable, V any] struct {
+	slots	[]rhSlot[K, V]
+	n		int
+	hash	Hasher[K]
+}
+
+// Make sure that the table implements the common interface
+var _ Map[int, int] = (*RobinHoodMap[int, int])(nil)
+
+// NewRobinHoodMap returns new empty hash table with Robin Hood hashing, hash is used to hash keys.
+func NewRobinHoodMap[K comparable, V any](hash Hasher[K]) *RobinHoodMap[K, V] {
+	return &RobinHoodMap[K, V]{
+		slots:	make([]rhSlot[K, V], MinCapacity),
+		hash:	hash,
+	}
+}
+
+// Len returns the number of entries
+func (m *RobinHoodMap[K, V]) Len() int {
+	return m.n
+}
+
+// LoadFactor returns the ratio of the number of entries to the number of slots
+func (m *RobinHoodMap[K, V]) LoadFactor() float64 {
+	return float64(m.n) / float64(len(m.slots))
+}
+
+// Get returns the value associated with the key k and true, or zero value and false if there is no such key
+func (m *RobinHoodMap[K, V]) Get(k K) (V, bool) {
+	if i := m.find(k, m.hash(k)); i != -1 {
+		return m.slots[i].value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Put associates the value v with the key k, it returns true if the key k was added
+// and false if the value of the existing key was replaced
+func (m *RobinHoodMap[K, V]) Put(k K, v V) bool {
+	h := m.hash(k)
+	if i := m.find(k, h); i != -1 {
+		m.slots[i].value = v
+		return false
+	}
+
+	if c := newCapacity(m.n + 1, len(m.slots), RobinHoodMaxLoadFactor); c != len(m.slots) {
+		m.resize(c)
+	}
+
+	m.add(entry[K, V]{key: k, value: v, hash: h})
+	m.n++
+
+	return true
+}
+
+// Delete deletes the key k and returns its value and true, or zero value and false if there is no such key
+func (m *RobinHoodMap[K, V]) Delete(k K) (V, bool) {
+	i := m.find(k, m.hash(k))
+	if i == -1 {
+		var zero V
+		return zero, false
+	}
+
+	v := m.slots[i].value
+	mask := len(m.slots) - 1
+
+	// Shift following entries of the probe sequence back until an empty slot or
+	// an entry in its home slot, each shifted entry becomes closer to its home
+	for j := (i + 1) & mask; m.slots[j].used && m.slots[j].dist > 0; i, j = j, (j + 1) & mask {
+		m.slots[i] = m.slots[j]
+		m.slots[i].dist--
+	}
+	m.slots[i] = rhSlot[K, V]{}
+	m.n--
+
+	if c := newCapacity(m.n, len(m.slots), RobinHoodMaxLoadFactor); c != len(m.slots) {
+		m.resize(c)
+	}
+
+	return v, true
+}
+
+// Range calls f for each entry in undefined order until f returns false
+func (m *RobinHoodMap[K, V]) Range(f func(k K, v V) bool) {
+	for i := range m.slots {
+		if m.slots[i].used && !f(m.slots[i].key, m.slots[i].value) {
+			return
+		}
+	}
+}
+
+// SelfTest checks the invariants of the table and returns the maximal number of slots
+// checked to find a key, and a description of the problem if detected. If an issue is
+// detected, the returned number is zero.
+func (m *RobinHoodMap[K, V]) SelfTest() (int, error) {
+	n, maxProbes := 0, 0
+	mask := len(m.slots) - 1
+	for i := range m.slots {
+		s := &m.slots[i]
+		if !s.used {
+			continue
+		}
+		n++
+
+		if s.hash != m.hash(s.key) || (i - int(s.hash) & mask) & mask != s.dist {
+			return 0, fmt.Errorf("v#1: key %v with hash %#x in slot %d has wrong distance %d from the home slot",
+				s.key, s.hash, i, s.dist)
+		}
+
+		// Each entry can be at most one slot farther from its home than the previous one
+		if prev := &m.slots[(i - 1) & mask]; s.dist > 0 && (!prev.used || s.dist > prev.dist + 1) {
+			return 0, fmt.Errorf("v#4: key %v in slot %d has distance %d, previous slot distance - %d",
+				s.key, i, s.dist, prev.dist)
+		}
+
+		if s.dist + 1 > maxProbes {
+			maxProbes = s.dist + 1
+		}
+	}
+
+	if n != m.n {
+		return 0, fmt.Errorf("v#2: table has %d entries, want - %d", m.n, n)
+	}
+
+	if m.LoadFactor() > RobinHoodMaxLoadFactor {
+		return 0, fmt.Errorf("v#3: load factor %v is greater than %v", m.LoadFactor(), RobinHoodMaxLoadFactor)
+	}
+
+	return maxProbes, nil
+}
+
+// find returns the slot with the key k and its hash h, or -1
+func (m *RobinHoodMap[K, V]) find(k K, h uint64) int {
+	mask := len(m.slots) - 1
+	// The key cannot be farther from its home than entries of the following home slots
+	for i, d := int(h) & mask, 0; m.slots[i].used && m.slots[i].dist >= d; i, d = (i + 1) & mask, d + 1 {
+		if m.slots[i].hash == h && m.slots[i].key == k {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// add inserts the new entry e taking slots of entries that are closer to their home slots
+func (m *RobinHoodMap[K, V]) add(e entry[K, V]) {
+	mask := len(m.slots) - 1
+	cur := rhSlot[K, V]{slot: slot[K, V]{entry: e, used: true}}
+	for i := int(e.hash) & mask; ; i, cur.dist = (i + 1) & mask, cur.dist + 1 {
+		if !m.slots[i].used {
+			m.slots[i] = cur
+			return
+		}
+
+		if m.slots[i].dist < cur.dist {
+			// Take the slot from the richer entry, it continues probing
+			m.slots[i], cur = cur, m.slots[i]
+		}
+	}
+}
+
+// resize moves all entries to the new table with the capacity
+func (m *RobinHoodMap[K, V]) resize(capacity int) {
+	old := m.slots
+	m.slots = make([]rhSlot[K, V], capacity)
+
+	for i := range old {
+		if old[i].used {
+			m.add(old[i].entry)
+		}
+	}
+}
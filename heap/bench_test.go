@@ -0,0 +1,157 @@
+package heap_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/r-che/algorithms/heap"
+	"github.com/r-che/algorithms/heap/binheap"
+	"github.com/r-che/algorithms/heap/fibheap"
+	"github.com/r-che/algorithms/heap/pairing"
+)
+
+const (
+	// Number of keys makes heaps large enough to compare amortized costs of operations
+	keysCount	=	10240
+	MaxItem		=	99999
+	// Seed of random sources of benchmarks
+	benchSeed	=	2032
+)
+
+// benchKeys returns keysCount random keys with duplicates generated by the new random source
+// with the static seed, so all benchmarks get the same keys
+func benchKeys() []int {
+	rnd := rand.New(rand.NewSource(benchSeed))	//nolint:gosec // Reproducible sequence is required
+
+	keys := make([]int, keysCount)
+	for i := range keys {
+		keys[i] = rnd.Intn(MaxItem + 1)
+	}
+
+	return keys
+}
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+// benchPushPop pushes all keys testKeys to the heap and pops them
+func benchPushPop[N heap.Node[int], H heap.Interface[int, N, H]](b *testing.B, testKeys []int, newHeap func() H) {
+	b.Helper()
+
+	for n := 0; n < b.N; n++ {
+		h := newHeap()
+		for _, k := range testKeys {
+			h.Push(k)
+		}
+		for h.Len() != 0 {
+			h.Pop()
+		}
+	}
+}
+
+// benchDecreaseKey pushes all keys testKeys to the heap, pops a few values to make the
+// heap non-trivial, decreases all values and pops them
+func benchDecreaseKey[N heap.Node[int], H heap.Interface[int, N, H]](b *testing.B, testKeys []int, newHeap func() H) {
+	b.Helper()
+
+	nodes := make([]N, len(testKeys))
+	for n := 0; n < b.N; n++ {
+		h := newHeap()
+		for i, k := range testKeys {
+			nodes[i] = h.Push(k)
+		}
+		h.Pop()
+
+		for _, nd := range nodes {
+			if nd.InHeap() {
+				h.DecreaseKey(nd, nd.Value() - MaxItem)
+			}
+		}
+		for h.Len() != 0 {
+			h.Pop()
+		}
+	}
+}
+
+// benchMeld builds heaps of 64 values, melds them and pops all values
+func benchMeld[N heap.Node[int], H heap.Interface[int, N, H]](b *testing.B, testKeys []int, newHeap func() H) {
+	b.Helper()
+
+	const chunk = 64
+
+	for n := 0; n < b.N; n++ {
+		h := newHeap()
+		for i := 0; i < len(testKeys); i += chunk {
+			part := newHeap()
+			for _, k := range testKeys[i:i+chunk] {
+				part.Push(k)
+			}
+			h.Meld(part)
+		}
+		for h.Len() != 0 {
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	testKeys := benchKeys()
+
+	b.Run("Pairing", func(b *testing.B) {
+		benchPushPop(b, testKeys, func() *pairing.PairingHeap[int] { return pairing.NewPairingHeap(intLess) })
+	})
+	b.Run("Fibonacci", func(b *testing.B) {
+		benchPushPop(b, testKeys, func() *fibheap.FibHeap[int] { return fibheap.NewFibHeap(intLess) })
+	})
+	b.Run("Binary", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			h := binheap.NewBinHeap(binheap.Min, intLess)
+			for _, k := range testKeys {
+				h.Push(k)
+			}
+			for h.Len() != 0 {
+				h.Pop()
+			}
+		}
+	})
+}
+
+func BenchmarkDecreaseKey(b *testing.B) {
+	testKeys := benchKeys()
+
+	b.Run("Pairing", func(b *testing.B) {
+		benchDecreaseKey(b, testKeys, func() *pairing.PairingHeap[int] { return pairing.NewPairingHeap(intLess) })
+	})
+	b.Run("Fibonacci", func(b *testing.B) {
+		benchDecreaseKey(b, testKeys, func() *fibheap.FibHeap[int] { return fibheap.NewFibHeap(intLess) })
+	})
+	b.Run("Binary", func(b *testing.B) {
+		handles := make([]*binheap.Handle[int], len(testKeys))
+		for n := 0; n < b.N; n++ {
+			h := binheap.NewBinHeap(binheap.Min, intLess)
+			for i, k := range testKeys {
+				handles[i] = h.Push(k)
+			}
+			h.Pop()
+
+			for _, hnd := range handles {
+				h.Update(hnd, hnd.Value() - MaxItem)
+			}
+			for h.Len() != 0 {
+				h.Pop()
+			}
+		}
+	})
+}
+
+func BenchmarkMeld(b *testing.B) {
+	testKeys := benchKeys()
+
+	b.Run("Pairing", func(b *testing.B) {
+		benchMeld(b, testKeys, func() *pairing.PairingHeap[int] { return pairing.NewPairingHeap(intLess) })
+	})
+	b.Run("Fibonacci", func(b *testing.B) {
+		benchMeld(b, testKeys, func() *fibheap.FibHeap[int] { return fibheap.NewFibHeap(intLess) })
+	})
+}
@@ -0,0 +1,260 @@
+/*
+Package binheap provides an example of a binary heap implementation that can be
+used as a priority queue.
+
+The heap is generic, the order of values is defined by the comparison function
+passed to the constructor. It works as a min-heap or a max-heap depending on
+the mode. Each value pushed to the heap gets a handle that can be used to
+update the value or to remove it from the heap in O(log n).
+
+It supports output of graphical representation of the heap using ASCII
+graphics. For example, a min-heap with the values 20, 10, 30, 5, 15,
+25, 35, 8, 17, 37, 33, 13, 2, 23, 27 pushed sequentially will look like
+this:
+
+	                             2
+	                ____________/  \____________
+	               /                            \
+	             8                               5
+	        ____/  \____                    ____/  \____
+	       /            \                  /            \
+	     10              15              13              23
+	    /  \            /  \            /  \            /  \
+	   /    \          /    \          /    \          /    \
+	 20      17      37      33      30      25      35      27
+*/
+package binheap
+
+import "fmt"
+
+// Mode defines the order of values in the heap
+type Mode int
+const (
+	// Min mode - the top of the heap is the minimal value
+	Min = Mode(iota)
+	// Max mode - the top of the heap is the maximal value
+	Max
+)
+
+func (m Mode) String() string {
+	switch m {
+		case Min:	return "Min"
+		case Max:	return "Max"
+	}
+
+	panic(fmt.Sprintf("Unexpected mode value: %d", m))
+}
+
+// Handle refers to the value pushed to the heap
+type Handle[T any] struct {
+	value	T
+	// Index of the handle in the heap array, -1 if the value was removed from the heap
+	index	int
+}
+
+// Value returns the value referred by the handle
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// InHeap returns true if the value referred by the handle is still in the heap
+func (h *Handle[T]) InHeap() bool {
+	return h.index >= 0
+}
+
+// BinHeap implements a binary heap.
+type BinHeap[T any] struct {
+	items	[]*Handle[T]
+	mode	Mode
+	less	func(a, b T) bool
+}
+
+// NewBinHeap returns new empty binary heap working in the mode, less reports whether
+// the value a is less than the value b.
+func NewBinHeap[T any](mode Mode, less func(a, b T) bool) *BinHeap[T] {
+	// Check for the correct mode
+	_ = mode.String()
+
+	return &BinHeap[T]{mode: mode, less: less}
+}
+
+// Len returns the number of values in the heap.
+func (h *BinHeap[T]) Len() int {
+	return len(h.items)
+}
+
+// Mode returns the mode of the heap.
+func (h *BinHeap[T]) Mode() Mode {
+	return h.mode
+}
+
+// before returns true if the item i should be closer to the top of the heap than the item j
+func (h *BinHeap[T]) before(i, j int) bool {
+	if h.mode == Max {
+		return h.less(h.items[j].value, h.items[i].value)
+	}
+
+	return h.less(h.items[i].value, h.items[j].value)
+}
+
+// swap swaps items i and j keeping their indexes correct
+func (h *BinHeap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+// up moves the item i up until the heap property is restored
+func (h *BinHeap[T]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2	// parent
+		if !h.before(i, p) {
+			break
+		}
+
+		h.swap(i, p)
+		i = p
+	}
+}
+
+// down moves the item i down until the heap property is restored. It returns true if the item was moved.
+func (h *BinHeap[T]) down(i int) bool {
+	start := i
+	for {
+		// Select the child that should be closer to the top
+		c := 2*i + 1
+		if c >= len(h.items) {
+			break
+		}
+		if r := c + 1; r < len(h.items) && h.before(r, c) {
+			c = r
+		}
+
+		if !h.before(c, i) {
+			break
+		}
+
+		h.swap(i, c)
+		i = c
+	}
+
+	return i != start
+}
+
+// Push pushes the value v to the heap and returns the handle of the value.
+func (h *BinHeap[T]) Push(v T) *Handle[T] {
+	hnd := &Handle[T]{value: v, index: len(h.items)}
+	h.items = append(h.items, hnd)
+	h.up(hnd.index)
+
+	return hnd
+}
+
+// Peek returns the value on the top of the heap and true, or zero value and false if the heap is empty.
+func (h *BinHeap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.items[0].value, true
+}
+
+// Pop removes the value on the top of the heap and returns it and true, or zero
+// value and false if the heap is empty.
+func (h *BinHeap[T]) Pop() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return h.remove(0), true
+}
+
+// Remove removes the value referred by the handle hnd from the heap and returns it and
+// true, or zero value and false if the value was already removed from the heap.
+func (h *BinHeap[T]) Remove(hnd *Handle[T]) (T, bool) {
+	if !h.owns(hnd) {
+		var zero T
+		return zero, false
+	}
+
+	return h.remove(hnd.index), true
+}
+
+// remove removes the item i and returns its value
+func (h *BinHeap[T]) remove(i int) T {
+	last := len(h.items) - 1
+	hnd := h.items[i]
+
+	// Replace the item by the last one
+	if i != last {
+		h.swap(i, last)
+	}
+
+	// Cut the removed item
+	h.items[last] = nil
+	h.items = h.items[:last]
+	hnd.index = -1
+
+	// Restore the heap property for the moved item
+	if i != last {
+		h.Fix(h.items[i])
+	}
+
+	return hnd.value
+}
+
+// Fix restores the heap property after the value referred by the handle hnd was
+// changed, e.g. when the value is a pointer to the modified structure. It does
+// nothing if the value was removed from the heap.
+func (h *BinHeap[T]) Fix(hnd *Handle[T]) {
+	if !h.owns(hnd) {
+		return
+	}
+
+	if !h.down(hnd.index) {
+		h.up(hnd.index)
+	}
+}
+
+// Update replaces the value referred by the handle hnd by v and restores the heap property.
+// It returns false if the value was removed from the heap.
+func (h *BinHeap[T]) Update(hnd *Handle[T], v T) bool {
+	if !h.owns(hnd) {
+		return false
+	}
+
+	hnd.value = v
+	h.Fix(hnd)
+
+	return true
+}
+
+// owns returns true if the handle refers to a value in this heap
+func (h *BinHeap[T]) owns(hnd *Handle[T]) bool {
+	return hnd.index >= 0 && hnd.index < len(h.items) && h.items[hnd.index] == hnd
+}
+
+// Heapify replaces the content of the heap by values in O(n) time and
+// returns handles of the values in the same order.
+func (h *BinHeap[T]) Heapify(values []T) []*Handle[T] {
+	// Invalidate existing handles
+	for _, hnd := range h.items {
+		hnd.index = -1
+	}
+
+	handles := make([]*Handle[T], len(values))
+	h.items = make([]*Handle[T], len(values))
+	for i, v := range values {
+		handles[i] = &Handle[T]{value: v, index: i}
+		h.items[i] = handles[i]
+	}
+
+	// Sift down all internal nodes starting from the last one
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+
+	return handles
+}
@@ -0,0 +1,390 @@
+package binheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Number of keys makes the heap 13 levels high
+	keysCount	=	4096
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2030
+
+	// Self-test checks all nodes of the heap, so it is run once per selfTestStep operations
+	selfTestStep	=	64
+)
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+// sortedKeys returns the copy of test keys in the order expected from the heap in the mode
+func sortedKeys(testKeys []int, mode Mode) []int {
+	keys := make([]int, len(testKeys))
+	copy(keys, testKeys)
+
+	if mode == Max {
+		sort.Sort(sort.Reverse(sort.IntSlice(keys)))
+	} else {
+		sort.Ints(keys)
+	}
+
+	return keys
+}
+
+func newHeapTestKeys(t *testing.T, testKeys []int, mode Mode) (*BinHeap[int], []*Handle[int]) {
+	t.Helper()
+
+	h := NewBinHeap(mode, intLess)
+	handles := make([]*Handle[int], 0, len(testKeys))
+	for _, k := range testKeys {
+		handles = append(handles, h.Push(k))
+	}
+
+	if _, err := h.SelfTest(); err != nil {
+		t.Fatalf("Self-test of the %v-heap failed: %v", mode, err)
+	}
+
+	return h, handles
+}
+
+// checkPopAll pops all values from the heap and compares them with expected values
+func checkPopAll(t *testing.T, h *BinHeap[int], want []int) {
+	t.Helper()
+
+	for i, w := range want {
+		v, ok := h.Pop()
+		if !ok {
+			t.Fatalf("[%d] Pop() returned false, want - %d, heap size - %d", i, w, h.Len())
+		}
+		if v != w {
+			t.Fatalf("[%d] Pop() returned %d, want - %d", i, v, w)
+		}
+
+		if i % selfTestStep == 0 {
+			if _, err := h.SelfTest(); err != nil {
+				t.Fatalf("[%d] Self-test failed after Pop(): %v", i, err)
+			}
+		}
+	}
+
+	if h.Len() != 0 {
+		t.Errorf("Heap is not empty after popping all values, size - %d", h.Len())
+	}
+}
+
+func TestMode(t *testing.T) {
+	for i, test := range []struct {
+		mode	Mode
+		want	string
+	} {
+		{ Min, "Min" },
+		{ Max, "Max" },
+	} {
+		if v := test.mode.String(); v != test.want {
+			t.Errorf("[%d] Mode.String() on %d, want - %q, got - %q", i, test.mode, test.want, v)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewBinHeap() did not panic on invalid mode")
+		}
+	}()
+
+	NewBinHeap(Mode(2), intLess)
+}
+
+func TestEmpty(t *testing.T) {
+	h := NewBinHeap(Min, intLess)
+
+	if v, ok := h.Peek(); ok {
+		t.Errorf("Peek() on empty heap returned %d, true", v)
+	}
+
+	if v, ok := h.Pop(); ok {
+		t.Errorf("Pop() on empty heap returned %d, true", v)
+	}
+
+	if h.Len() != 0 {
+		t.Errorf("Len() of empty heap returned %d", h.Len())
+	}
+
+	if height, err := h.SelfTest(); err != nil || height != 0 {
+		t.Errorf("SelfTest() of empty heap returned %d, %v", height, err)
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for _, mode := range []Mode{Min, Max} {
+		h, _ := newHeapTestKeys(t, testKeys, mode)
+
+		if h.Len() != len(testKeys) {
+			t.Fatalf("%v-heap has size %d, want - %d", mode, h.Len(), len(testKeys))
+		}
+
+		want := sortedKeys(testKeys, mode)
+		if v, ok := h.Peek(); !ok || v != want[0] {
+			t.Errorf("Peek() on %v-heap returned %d, %t, want - %d, true", mode, v, ok, want[0])
+		}
+
+		checkPopAll(t, h, want)
+	}
+}
+
+func TestPushDupes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h := NewBinHeap(Min, intLess)
+	for i := 0; i < 3; i++ {
+		for _, k := range testKeys {
+			h.Push(k)
+		}
+	}
+
+	want := make([]int, 0, 3 * len(testKeys))
+	for _, k := range sortedKeys(testKeys, Min) {
+		want = append(want, k, k, k)
+	}
+
+	checkPopAll(t, h, want)
+}
+
+func TestHeapify(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for _, mode := range []Mode{Min, Max} {
+		h := NewBinHeap(mode, intLess)
+
+		// Push some values to check that they are replaced
+		old := h.Push(-1)
+
+		handles := h.Heapify(testKeys)
+
+		if old.InHeap() {
+			t.Errorf("Handle of the value pushed before Heapify() is still in the heap")
+		}
+
+		if _, err := h.SelfTest(); err != nil {
+			t.Fatalf("Self-test of the %v-heap after Heapify() failed: %v", mode, err)
+		}
+
+		for i, hnd := range handles {
+			if hnd.Value() != testKeys[i] || !hnd.InHeap() {
+				t.Fatalf("[%d] Handle returned by Heapify() refers to %d (in heap - %t), want - %d",
+					i, hnd.Value(), hnd.InHeap(), testKeys[i])
+			}
+		}
+
+		checkPopAll(t, h, sortedKeys(testKeys, mode))
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h, handles := newHeapTestKeys(t, testKeys, Min)
+
+	// Double the odd values and negate the even ones
+	want := make([]int, 0, len(testKeys))
+	for i, hnd := range handles {
+		v := hnd.Value()
+		if v % 2 == 0 {
+			v = -v
+		} else {
+			v *= 2
+		}
+
+		if !h.Update(hnd, v) {
+			t.Fatalf("[%d] Update(%d) returned false for value in the heap", i, v)
+		}
+		want = append(want, v)
+
+		if i % selfTestStep == 0 {
+			if _, err := h.SelfTest(); err != nil {
+				t.Fatalf("[%d] Self-test failed after Update(): %v", i, err)
+			}
+		}
+	}
+
+	sort.Ints(want)
+	checkPopAll(t, h, want)
+
+	// Update of removed values should fail
+	if h.Update(handles[0], 0) {
+		t.Errorf("Update() returned true for value removed from the heap")
+	}
+}
+
+func TestFix(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	type task struct {
+		prio	int
+	}
+
+	h := NewBinHeap(Max, func(a, b *task) bool { return a.prio < b.prio })
+	handles := make([]*Handle[*task], 0, len(testKeys))
+	for _, k := range testKeys {
+		handles = append(handles, h.Push(&task{prio: k}))
+	}
+
+	// Change priorities in place and fix the heap
+	want := make([]int, 0, len(testKeys))
+	for i, hnd := range handles {
+		hnd.Value().prio = MaxItem - hnd.Value().prio
+		h.Fix(hnd)
+		want = append(want, hnd.Value().prio)
+
+		if i % selfTestStep == 0 {
+			if _, err := h.SelfTest(); err != nil {
+				t.Fatalf("[%d] Self-test failed after Fix(): %v", i, err)
+			}
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(want)))
+	for i, w := range want {
+		v, _ := h.Pop()
+		if v.prio != w {
+			t.Fatalf("[%d] Pop() returned value with priority %d, want - %d", i, v.prio, w)
+		}
+	}
+
+	// Fix of removed value should be no-op
+	h.Fix(handles[0])
+	if h.Len() != 0 {
+		t.Errorf("Fix() of removed value changed the heap size to %d", h.Len())
+	}
+}
+
+func TestRemove(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h, handles := newHeapTestKeys(t, testKeys, Min)
+
+	// Remove the values in random order
+	rnd.Shuffle(len(handles), func(i, j int) { handles[i], handles[j] = handles[j], handles[i] })
+
+	// Keep the last half of values in the heap
+	keep := handles[len(handles)/2:]
+	for i, hnd := range handles[:len(handles)/2] {
+		v, ok := h.Remove(hnd)
+		if !ok || v != hnd.Value() {
+			t.Fatalf("[%d] Remove() returned %d, %t, want - %d, true", i, v, ok, hnd.Value())
+		}
+
+		if hnd.InHeap() {
+			t.Fatalf("[%d] Removed value %d is still in the heap", i, v)
+		}
+
+		// Second removal must fail
+		if v, ok := h.Remove(hnd); ok {
+			t.Fatalf("[%d] Second Remove() of the value %d returned %d, true", i, hnd.Value(), v)
+		}
+
+		if i % selfTestStep == 0 {
+			if _, err := h.SelfTest(); err != nil {
+				t.Fatalf("[%d] Self-test failed after Remove(): %v", i, err)
+			}
+		}
+	}
+
+	want := make([]int, 0, len(keep))
+	for _, hnd := range keep {
+		want = append(want, hnd.Value())
+	}
+	sort.Ints(want)
+
+	checkPopAll(t, h, want)
+}
+
+func TestRemoveForeign(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h1, handles := newHeapTestKeys(t, testKeys, Min)
+	h2, _ := newHeapTestKeys(t, testKeys, Min)
+
+	// Handles of one heap cannot be used with another heap
+	for i, hnd := range handles {
+		if v, ok := h2.Remove(hnd); ok {
+			t.Fatalf("[%d] Remove() of value %d returned %d, true using handle of another heap", i, hnd.Value(), v)
+		}
+	}
+
+	if h1.Len() != len(testKeys) || h2.Len() != len(testKeys) {
+		t.Errorf("Sizes of heaps were changed: %d, %d, want - %d", h1.Len(), h2.Len(), len(testKeys))
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for i, test := range []struct {
+		breaker	func(h *BinHeap[int])
+		want	string
+	} {
+		{
+			breaker:	func(h *BinHeap[int]) { h.items[3].index = 5 },
+			want:		"v#1: handle of the value",
+		},
+		{
+			breaker:	func(h *BinHeap[int]) { h.items[7].value = -1 },
+			want:		"v#2: value -1 at position 7 violates the Min-heap property",
+		},
+	} {
+		h, _ := newHeapTestKeys(t, testKeys, Min)
+		test.breaker(h)
+
+		height, err := h.SelfTest()
+		if err == nil {
+			t.Errorf("[%d] SelfTest() did not detect the problem", i)
+			continue
+		}
+		if height != 0 {
+			t.Errorf("[%d] SelfTest() returned non-zero height %d with error", i, height)
+		}
+		if len(err.Error()) < len(test.want) || err.Error()[:len(test.want)] != test.want {
+			t.Errorf("[%d] SelfTest() returned %q, want prefix - %q", i, err, test.want)
+		}
+	}
+}
+
+func TestStringFilled(t *testing.T) {
+	h := NewBinHeap(Max, intLess)
+	h.Heapify([]int{1, 2, 3, 4, 5, 6})
+
+	want :=
+		`          6` + "\n" +
+		`      ___/ \___` + "\n" +
+		`     /         \` + "\n" +
+		`    5           3` + "\n" +
+		`   / \         /` + "\n" +
+		`  /   \       /` + "\n" +
+		` 4     2     1` + "\n"
+
+	if v := h.String(); v != want {
+		t.Errorf("String() of filled heap returned:\n%s\nwant:\n%s", v, want)
+	}
+}
+
+func TestStringEmpty(t *testing.T) {
+	if v := NewBinHeap(Min, intLess).String(); v != strEmptyHeap {
+		t.Errorf("String() of empty heap returned %q, want - %q", v, strEmptyHeap)
+	}
+}
@@ -0,0 +1,61 @@
+package binheap
+
+import "fmt"
+
+func Example_heapCreation() {
+	// Create min-heap of integers
+	h := NewBinHeap(Min, func(a, b int) bool { return a < b })
+
+	// Push values
+	for _, v := range []int{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		h.Push(v)
+	}
+
+	// Print graphical representation of the heap
+	fmt.Print(h)
+
+	// Output:
+	//                              2
+	//                 ____________/  \____________
+	//                /                            \
+	//              8                               5
+	//         ____/  \____                    ____/  \____
+	//        /            \                  /            \
+	//      10              15              13              23
+	//     /  \            /  \            /  \            /  \
+	//    /    \          /    \          /    \          /    \
+	//  20      17      37      33      30      25      35      27
+}
+
+func Example_priorityQueue() {
+	type task struct {
+		name	string
+		prio	int
+	}
+
+	// Create max-heap of tasks ordered by priority
+	pq := NewBinHeap(Max, func(a, b task) bool { return a.prio < b.prio })
+
+	// Build the queue in O(n)
+	handles := pq.Heapify([]task{
+		{"write docs", 1},
+		{"fix bug", 5},
+		{"review", 3},
+		{"release", 2},
+	})
+
+	// Raise the priority of the release
+	pq.Update(handles[3], task{"release", 10})
+	// Remove the review task from the queue
+	pq.Remove(handles[2])
+
+	for pq.Len() != 0 {
+		t, _ := pq.Pop()
+		fmt.Println(t.prio, t.name)
+	}
+
+	// Output:
+	// 10 release
+	// 5 fix bug
+	// 1 write docs
+}
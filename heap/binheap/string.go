@@ -0,0 +1,172 @@
+package binheap
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	strEmptyHeap	= `<heap-is-empty>`
+)
+
+// String returns the ASCII representation of the heap as a binary tree. The
+// values are converted to strings using the default format of the fmt package.
+func (h *BinHeap[T]) String() string {
+	if len(h.items) == 0 {
+		return strEmptyHeap
+	}
+
+	// Get a list of levels with item indexes and
+	// a list of positions of items in a linear ordering of the implicit tree
+	levels, positions := h.stringPrepareData()
+
+	// Tree width
+	width := len(positions)
+
+	// Create output matrix
+	const linesPerLevel = 3	// each output matrix level contains 3 lines, for:
+							// * values
+							// * initial slope of edge from node + horizontal part of edge
+							// * final slanting part of the edge
+	// So, the output matrix will have vertical dimension - heap height * linesPerLevel
+	oMatrix := make([][]string, len(levels) * linesPerLevel)
+
+	// String representation of values and the maximal value width
+	values := make([]string, len(h.items))
+	vw := 0
+	for i, hnd := range h.items {
+		values[i] = fmt.Sprint(hnd.value)
+		if w := utf8.RuneCountInString(values[i]); w > vw {
+			vw = w
+		}
+	}
+
+	// Value output format
+	vFmt := fmt.Sprintf("%%-%ds", vw)
+	// Summary cell width that contains node
+	cellWidth := len(" ") +  vw + len(" ")	// one space left + one space right of the value
+	// Short stub that used to print cells that contain part of edges
+	stub := strings.Repeat(" ", vw)
+	// Fragment of a branch with one cell width
+	branchFrag := strings.Repeat("_", cellWidth)
+
+	oLine := 0	// output matrix line
+	level := 0	// levels line
+	for ; oLine < len(levels) * linesPerLevel; oLine, level = oLine + linesPerLevel, level+1 {
+		// Fill output matrix level
+		oMatrix[oLine] = make([]string, width)
+		oMatrix[oLine+1] = make([]string, width)
+		oMatrix[oLine+2] = make([]string, width)
+		for _, i := range levels[level] {
+			// Write value to the output matrix
+			oMatrix[oLine][positions[i]] = " " + fmt.Sprintf(vFmt, values[i]) + " "
+
+			// Write the initial fragment of the branch from the children to its parent
+			h.stringInitBranchFrag(oMatrix[oLine+1], positions, i, stub)
+
+			// Is it the top of the heap?
+			if i == 0 {
+				// Top has no parents, no need to draw connections to them
+				continue
+			}
+
+			parent := (i - 1) / 2
+
+			// Determine direction of drawing
+			var step int
+			if i % 2 == 1 {
+				// Node - LEFT child of its parent, need to draw branch to the right toward the parent
+				oMatrix[oLine-1][positions[i]] = ` ` + stub + `/`
+				step = 1
+			} else {
+				// Node - RIGHT child of its parent, need to draw branch to the left toward the parent
+				oMatrix[oLine-1][positions[i]] = `\` + stub + ` `
+				step = -1
+			}
+
+			for ni := positions[i] + step; ni != positions[parent]; ni += step {
+				oMatrix[oLine-2][ni] = branchFrag
+			}
+		}
+	}
+
+	return stringMakeOutput(oMatrix, cellWidth)
+}
+
+// stringPrepareData prepares source data to create string representation of the heap. It returns:
+// levels - list of levels (starting from the top - 0), each of that level contains
+//          indexes of corresponding items from left to right
+// positions - position of each item in the in-order walk of the implicit binary tree
+func (h *BinHeap[T]) stringPrepareData() ([][]int, []int) {
+	// Items of the level n occupy indexes 2^n-1 ... 2^(n+1)-2
+	var levels [][]int
+	for first := 0; first < len(h.items); first = 2*first + 1 {
+		last := 2*first + 1
+		if last > len(h.items) {
+			last = len(h.items)
+		}
+
+		level := make([]int, 0, last - first)
+		for i := first; i < last; i++ {
+			level = append(level, i)
+		}
+		levels = append(levels, level)
+	}
+
+	positions := make([]int, len(h.items))
+	pos := 0
+	var walk func(i int)
+	walk = func(i int) {
+		if i >= len(h.items) {
+			return
+		}
+
+		walk(2*i + 1)
+		positions[i] = pos
+		pos++
+		walk(2*i + 2)
+	}
+	walk(0)
+
+	return levels, positions
+}
+
+// stringInitBranchFrag writes the initial fragment of branches to children, if any
+func (h *BinHeap[T]) stringInitBranchFrag(row []string, positions []int, i int, stub string) {
+	switch l, r := 2*i + 1, 2*i + 2; {
+	case r < len(h.items):
+		row[positions[i]] = `/` + stub + `\`
+	case l < len(h.items):
+		row[positions[i]] = `/` + stub + ` `
+	}
+}
+
+// stringMakeOutput converts matrix-representation of the heap to the multiline string value
+// without trailing spaces
+func stringMakeOutput(matrix [][]string, cellWidth int) string {
+	// Remove last two rows from matrix - it always empty
+	matrix = matrix[:len(matrix)-2]
+
+	// Make output buffer
+	out := strings.Builder{}
+
+	// Stub that used to print completely empty cells
+	stubFull := strings.Repeat(" ", cellWidth)
+
+	for _, level := range matrix {
+		line := strings.Builder{}
+		for _, n := range level {
+			if n == "" {
+				line.WriteString(stubFull)
+			} else {
+				line.WriteString(n)
+			}
+		}
+		// Trailing spaces are useless, append new line instead of them
+		out.WriteString(strings.TrimRight(line.String(), " "))
+		out.WriteString("\n")
+	}
+
+	return out.String()
+}
@@ -0,0 +1,31 @@
+package binheap
+
+import "fmt"
+
+// SelfTest performs a self-test of the heap and returns the height of the heap,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero.
+func (h *BinHeap[T]) SelfTest() (int, error) {
+	for i, hnd := range h.items {
+		if hnd.index != i {
+			return 0, fmt.Errorf("v#1: handle of the value %v at position %d has index %d", hnd.value, i, hnd.index)
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		if p := (i - 1) / 2; h.before(i, p) {
+			return 0, fmt.Errorf("v#2: value %v at position %d violates the %v-heap property with parent %v",
+				hnd.value, i, h.mode, h.items[p].value)
+		}
+	}
+
+	// OK, calculate the height of the complete binary tree
+	height := 0
+	for n := len(h.items); n > 0; n >>= 1 {
+		height++
+	}
+
+	return height, nil
+}
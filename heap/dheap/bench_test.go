@@ -0,0 +1,112 @@
+package dheap
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+// rbQueue is a priority queue built on the red-black tree. Keys of the tree are
+// combined from the priority and the handle to make them unique
+type rbQueue struct {
+	tree	*rbtree.RBTree
+	prio	[]int
+}
+
+func newRBQueue(n int) *rbQueue {
+	return &rbQueue{tree: rbtree.NewRBTree(), prio: make([]int, n)}
+}
+
+func (q *rbQueue) key(i int) rbtree.KeyType {
+	return rbtree.KeyType(q.prio[i] * keysCount + i)
+}
+
+func (q *rbQueue) push(i, p int) {
+	q.prio[i] = p
+	q.tree.Insert(rbtree.NewRBNode(q.key(i), i))
+}
+
+func (q *rbQueue) pop() (int, int) {
+	n := q.tree.Min()
+	i := n.Value().(int)	//nolint:forcetypeassert
+	q.tree.Delete(n)
+
+	return i, q.prio[i]
+}
+
+func (q *rbQueue) decreaseKey(i, p int) {
+	// Nodes cannot be updated in place, the node has to be reinserted
+	q.tree.Delete(q.tree.Search(q.key(i)))
+	q.push(i, p)
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for _, d := range testArities {
+		b.Run(fmt.Sprintf("DHeap-%d", d), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				h := NewDHeap(d, intLess)
+				for i, k := range testKeys {
+					h.Push(i, k)
+				}
+				for h.Len() != 0 {
+					h.Pop()
+				}
+			}
+		})
+	}
+
+	b.Run("RBTree", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			q := newRBQueue(len(testKeys))
+			for i, k := range testKeys {
+				q.push(i, k)
+			}
+			for q.tree.Root() != nil {
+				q.pop()
+			}
+		}
+	})
+}
+
+func BenchmarkDecreaseKey(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for _, d := range testArities {
+		b.Run(fmt.Sprintf("DHeap-%d", d), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				b.StopTimer()
+				h := NewDHeap(d, intLess)
+				for i, k := range testKeys {
+					h.Push(i, k)
+				}
+				b.StartTimer()
+
+				for i, k := range testKeys {
+					h.DecreaseKey(i, k - MaxItem)
+				}
+			}
+		})
+	}
+
+	b.Run("RBTree", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			q := newRBQueue(len(testKeys))
+			for i, k := range testKeys {
+				q.push(i, k)
+			}
+			b.StartTimer()
+
+			for i, k := range testKeys {
+				q.decreaseKey(i, k - MaxItem)
+			}
+		}
+	})
+}
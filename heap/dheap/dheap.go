@@ -0,0 +1,230 @@
+/*
+Package dheap provides an example of an indexed d-ary heap implementation that
+can be used as a priority queue in graph algorithms.
+
+Each item of the heap is identified by an integer handle chosen by the caller,
+e.g. the number of a graph vertex, and has an associated priority. The handle
+allows to check the presence of the item and to change its priority or to
+delete it from the heap in O(d * log_d n) time, which makes the heap suitable
+for Dijkstra's and Prim's algorithms that need the decrease-key operation.
+
+The arity d of the heap is configurable. Heaps with greater arity are shallower,
+so decreasing the priority is cheaper, but popping the top item is more
+expensive since it requires comparison of d children on each level.
+*/
+package dheap
+
+import "fmt"
+
+// MinArity is the minimal allowed arity of the heap
+const MinArity = 2
+
+// DHeap implements an indexed d-ary min-heap, the item with the least priority is on the top.
+type DHeap[P any] struct {
+	// Arity - maximal number of children of each item
+	d		int
+	// Handles of items in heap order
+	heap	[]int
+	// Position of each handle in the heap, -1 if the handle is not in the heap
+	pos		[]int
+	// Priority of each handle
+	prio	[]P
+	less	func(a, b P) bool
+}
+
+// NewDHeap returns new empty heap with arity d, less reports whether the priority a is
+// less than the priority b. It panics if d is less than MinArity.
+func NewDHeap[P any](d int, less func(a, b P) bool) *DHeap[P] {
+	if d < MinArity {
+		panic(fmt.Sprintf("Invalid heap arity %d, must be at least %d", d, MinArity))
+	}
+
+	return &DHeap[P]{d: d, less: less}
+}
+
+// Arity returns the arity of the heap.
+func (h *DHeap[P]) Arity() int {
+	return h.d
+}
+
+// Len returns the number of items in the heap.
+func (h *DHeap[P]) Len() int {
+	return len(h.heap)
+}
+
+// Contains returns true if the item with handle i is in the heap.
+func (h *DHeap[P]) Contains(i int) bool {
+	return i >= 0 && i < len(h.pos) && h.pos[i] >= 0
+}
+
+// Priority returns the priority of the item with handle i and true, or zero value
+// and false if there is no such item in the heap.
+func (h *DHeap[P]) Priority(i int) (P, bool) {
+	if !h.Contains(i) {
+		var zero P
+		return zero, false
+	}
+
+	return h.prio[i], true
+}
+
+// Push adds the item with handle i and priority p to the heap. It returns false if
+// the handle is negative or the item with this handle is already in the heap, in
+// this case the heap is not modified.
+func (h *DHeap[P]) Push(i int, p P) bool {
+	if i < 0 || h.Contains(i) {
+		return false
+	}
+
+	// Grow the handle-indexed arrays if required
+	for len(h.pos) <= i {
+		h.pos = append(h.pos, -1)
+	}
+	if len(h.prio) < len(h.pos) {
+		h.prio = append(h.prio, make([]P, len(h.pos) - len(h.prio))...)
+	}
+
+	h.prio[i] = p
+	h.pos[i] = len(h.heap)
+	h.heap = append(h.heap, i)
+	h.up(h.pos[i])
+
+	return true
+}
+
+// Peek returns the handle and the priority of the top item and true,
+// or -1, zero value and false if the heap is empty.
+func (h *DHeap[P]) Peek() (int, P, bool) {
+	if len(h.heap) == 0 {
+		var zero P
+		return -1, zero, false
+	}
+
+	return h.heap[0], h.prio[h.heap[0]], true
+}
+
+// Pop removes the top item from the heap and returns its handle, priority and true,
+// or -1, zero value and false if the heap is empty.
+func (h *DHeap[P]) Pop() (int, P, bool) {
+	if len(h.heap) == 0 {
+		var zero P
+		return -1, zero, false
+	}
+
+	i := h.heap[0]
+
+	return i, h.remove(0), true
+}
+
+// Delete removes the item with handle i from the heap and returns its priority
+// and true, or zero value and false if there is no such item in the heap.
+func (h *DHeap[P]) Delete(i int) (P, bool) {
+	if !h.Contains(i) {
+		var zero P
+		return zero, false
+	}
+
+	return h.remove(h.pos[i]), true
+}
+
+// DecreaseKey sets the priority of the item with handle i to p. It returns false if
+// there is no such item in the heap or p is greater than the current priority, in
+// this case the heap is not modified.
+func (h *DHeap[P]) DecreaseKey(i int, p P) bool {
+	if !h.Contains(i) || h.less(h.prio[i], p) {
+		return false
+	}
+
+	h.prio[i] = p
+	h.up(h.pos[i])
+
+	return true
+}
+
+// IncreaseKey sets the priority of the item with handle i to p. It returns false if
+// there is no such item in the heap or p is less than the current priority, in
+// this case the heap is not modified.
+func (h *DHeap[P]) IncreaseKey(i int, p P) bool {
+	if !h.Contains(i) || h.less(p, h.prio[i]) {
+		return false
+	}
+
+	h.prio[i] = p
+	h.down(h.pos[i])
+
+	return true
+}
+
+// remove removes the item at the position k of the heap and returns its priority
+func (h *DHeap[P]) remove(k int) P {
+	i := h.heap[k]
+	last := len(h.heap) - 1
+
+	// Replace the item by the last one
+	if k != last {
+		h.swap(k, last)
+	}
+	h.heap = h.heap[:last]
+	h.pos[i] = -1
+
+	// Restore the heap property for the moved item
+	if k != last && !h.down(k) {
+		h.up(k)
+	}
+
+	return h.prio[i]
+}
+
+// before returns true if the item at the position a has less priority than the item at the position b
+func (h *DHeap[P]) before(a, b int) bool {
+	return h.less(h.prio[h.heap[a]], h.prio[h.heap[b]])
+}
+
+// swap swaps items at positions a and b keeping their positions correct
+func (h *DHeap[P]) swap(a, b int) {
+	h.heap[a], h.heap[b] = h.heap[b], h.heap[a]
+	h.pos[h.heap[a]] = a
+	h.pos[h.heap[b]] = b
+}
+
+// up moves the item at the position k up until the heap property is restored
+func (h *DHeap[P]) up(k int) {
+	for k > 0 {
+		p := (k - 1) / h.d	// parent
+		if !h.before(k, p) {
+			break
+		}
+
+		h.swap(k, p)
+		k = p
+	}
+}
+
+// down moves the item at the position k down until the heap property is restored.
+// It returns true if the item was moved.
+func (h *DHeap[P]) down(k int) bool {
+	start := k
+	for {
+		// Select the child with the least priority
+		first := h.d*k + 1
+		if first >= len(h.heap) {
+			break
+		}
+
+		c := first
+		for j := first + 1; j < first + h.d && j < len(h.heap); j++ {
+			if h.before(j, c) {
+				c = j
+			}
+		}
+
+		if !h.before(c, k) {
+			break
+		}
+
+		h.swap(k, c)
+		k = c
+	}
+
+	return k != start
+}
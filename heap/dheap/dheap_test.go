@@ -0,0 +1,337 @@
+package dheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Number of keys makes heaps of all tested arities several levels high
+	keysCount	=	4096
+	MaxItem		=	99999
+	// Seed of random sources of tests and benchmarks
+	testSeed	=	2031
+
+	// Self-test checks all items of the heap, so it is run once per selfTestStep operations
+	selfTestStep	=	64
+)
+
+//nolint:gochecknoglobals
+var testArities = []int{2, 3, 4, 8}
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+// newHeapTestKeys returns the heap with handles 0..keysCount-1 and test keys as priorities
+func newHeapTestKeys(t *testing.T, testKeys []int, d int) *DHeap[int] {
+	t.Helper()
+
+	h := NewDHeap(d, intLess)
+	for i, k := range testKeys {
+		if !h.Push(i, k) {
+			t.Fatalf("[%d] Push(%d, %d) returned false", i, i, k)
+		}
+	}
+
+	if _, err := h.SelfTest(); err != nil {
+		t.Fatalf("Self-test of the heap with arity %d failed: %v", d, err)
+	}
+
+	return h
+}
+
+// checkPopAll pops all items from the heap and compares their priorities with expected values
+func checkPopAll(t *testing.T, h *DHeap[int], want []int) {
+	t.Helper()
+
+	for n, w := range want {
+		i, p, ok := h.Pop()
+		if !ok {
+			t.Fatalf("[%d] Pop() returned false, want - %d, heap size - %d", n, w, h.Len())
+		}
+		if p != w {
+			t.Fatalf("[%d] Pop() returned priority %d of handle %d, want - %d", n, p, i, w)
+		}
+		if h.Contains(i) {
+			t.Fatalf("[%d] Handle %d returned by Pop() is still in the heap", n, i)
+		}
+
+		if n % selfTestStep == 0 {
+			if _, err := h.SelfTest(); err != nil {
+				t.Fatalf("[%d] Self-test failed after Pop(): %v", n, err)
+			}
+		}
+	}
+
+	if h.Len() != 0 {
+		t.Errorf("Heap is not empty after popping all items, size - %d", h.Len())
+	}
+}
+
+func sortedKeys(testKeys []int) []int {
+	keys := make([]int, len(testKeys))
+	copy(keys, testKeys)
+	sort.Ints(keys)
+
+	return keys
+}
+
+func TestInvalidArity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewDHeap() did not panic on invalid arity")
+		}
+	}()
+
+	NewDHeap(MinArity - 1, intLess)
+}
+
+func TestEmpty(t *testing.T) {
+	h := NewDHeap(4, intLess)
+
+	if i, p, ok := h.Peek(); ok || i != -1 {
+		t.Errorf("Peek() on empty heap returned %d, %d, true", i, p)
+	}
+
+	if i, p, ok := h.Pop(); ok || i != -1 {
+		t.Errorf("Pop() on empty heap returned %d, %d, true", i, p)
+	}
+
+	for _, i := range []int{-1, 0, 100} {
+		if h.Contains(i) {
+			t.Errorf("Contains(%d) on empty heap returned true", i)
+		}
+		if p, ok := h.Priority(i); ok {
+			t.Errorf("Priority(%d) on empty heap returned %d, true", i, p)
+		}
+		if p, ok := h.Delete(i); ok {
+			t.Errorf("Delete(%d) on empty heap returned %d, true", i, p)
+		}
+		if h.DecreaseKey(i, 0) || h.IncreaseKey(i, 0) {
+			t.Errorf("DecreaseKey/IncreaseKey(%d) on empty heap returned true", i)
+		}
+	}
+
+	if height, err := h.SelfTest(); err != nil || height != 0 {
+		t.Errorf("SelfTest() of empty heap returned %d, %v", height, err)
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for _, d := range testArities {
+		h := newHeapTestKeys(t, testKeys, d)
+
+		if h.Len() != len(testKeys) || h.Arity() != d {
+			t.Fatalf("Heap has size %d and arity %d, want - %d, %d", h.Len(), h.Arity(), len(testKeys), d)
+		}
+
+		for i, k := range testKeys {
+			if p, ok := h.Priority(i); !ok || p != k {
+				t.Fatalf("[%d] Priority() returned %d, %t, want - %d, true", i, p, ok, k)
+			}
+		}
+
+		want := sortedKeys(testKeys)
+		if _, p, ok := h.Peek(); !ok || p != want[0] {
+			t.Errorf("Peek() returned priority %d, %t, want - %d, true", p, ok, want[0])
+		}
+
+		checkPopAll(t, h, want)
+	}
+}
+
+func TestPushInvalid(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h := newHeapTestKeys(t, testKeys, 2)
+
+	// Negative handle
+	if h.Push(-1, 0) {
+		t.Errorf("Push() with negative handle returned true")
+	}
+
+	// Existing handles
+	for i := range testKeys {
+		if h.Push(i, -1) {
+			t.Fatalf("Push() of existing handle %d returned true", i)
+		}
+	}
+
+	checkPopAll(t, h, sortedKeys(testKeys))
+}
+
+func TestDecreaseKey(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for _, d := range testArities {
+		h := newHeapTestKeys(t, testKeys, d)
+
+		want := make([]int, 0, len(testKeys))
+		for i, k := range testKeys {
+			// Increase of priority via DecreaseKey is not allowed
+			if h.DecreaseKey(i, k + 1) {
+				t.Fatalf("[%d] DecreaseKey() to the greater priority returned true", i)
+			}
+
+			p := k - MaxItem / 2
+			if !h.DecreaseKey(i, p) {
+				t.Fatalf("[%d] DecreaseKey(%d, %d) returned false", i, i, p)
+			}
+			want = append(want, p)
+
+			if i % selfTestStep == 0 {
+				if _, err := h.SelfTest(); err != nil {
+					t.Fatalf("[%d] Self-test failed after DecreaseKey(): %v", i, err)
+				}
+			}
+		}
+
+		sort.Ints(want)
+		checkPopAll(t, h, want)
+	}
+}
+
+func TestIncreaseKey(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for _, d := range testArities {
+		h := newHeapTestKeys(t, testKeys, d)
+
+		want := make([]int, 0, len(testKeys))
+		for i, k := range testKeys {
+			// Decrease of priority via IncreaseKey is not allowed
+			if h.IncreaseKey(i, k - 1) {
+				t.Fatalf("[%d] IncreaseKey() to the less priority returned true", i)
+			}
+
+			p := 2 * MaxItem - k
+			if !h.IncreaseKey(i, p) {
+				t.Fatalf("[%d] IncreaseKey(%d, %d) returned false", i, i, p)
+			}
+			want = append(want, p)
+
+			if i % selfTestStep == 0 {
+				if _, err := h.SelfTest(); err != nil {
+					t.Fatalf("[%d] Self-test failed after IncreaseKey(): %v", i, err)
+				}
+			}
+		}
+
+		sort.Ints(want)
+		checkPopAll(t, h, want)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for _, d := range testArities {
+		h := newHeapTestKeys(t, testKeys, d)
+
+		// Delete the handles in random order
+		handles := rnd.Perm(len(testKeys))
+
+		// Keep the last half of items in the heap
+		keep := handles[len(handles)/2:]
+		for n, i := range handles[:len(handles)/2] {
+			if p, ok := h.Delete(i); !ok || p != testKeys[i] {
+				t.Fatalf("[%d] Delete(%d) returned %d, %t, want - %d, true", n, i, p, ok, testKeys[i])
+			}
+
+			if h.Contains(i) {
+				t.Fatalf("[%d] Deleted handle %d is still in the heap", n, i)
+			}
+
+			// Second deletion must fail
+			if p, ok := h.Delete(i); ok {
+				t.Fatalf("[%d] Second Delete(%d) returned %d, true", n, i, p)
+			}
+
+			if n % selfTestStep == 0 {
+				if _, err := h.SelfTest(); err != nil {
+					t.Fatalf("[%d] Self-test failed after Delete(): %v", n, err)
+				}
+			}
+		}
+
+		want := make([]int, 0, len(keep))
+		for _, i := range keep {
+			want = append(want, testKeys[i])
+		}
+		sort.Ints(want)
+
+		checkPopAll(t, h, want)
+	}
+}
+
+func TestReuseHandles(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h := newHeapTestKeys(t, testKeys, 4)
+	checkPopAll(t, h, sortedKeys(testKeys))
+
+	// All handles can be pushed again after removal
+	for i, k := range testKeys {
+		if !h.Push(i, MaxItem - k) {
+			t.Fatalf("[%d] Push() of removed handle returned false", i)
+		}
+	}
+
+	want := make([]int, 0, len(testKeys))
+	for _, k := range testKeys {
+		want = append(want, MaxItem - k)
+	}
+	sort.Ints(want)
+
+	checkPopAll(t, h, want)
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for i, test := range []struct {
+		breaker	func(h *DHeap[int])
+		want	string
+	} {
+		{
+			breaker:	func(h *DHeap[int]) { h.pos[h.heap[3]] = 5 },
+			want:		"v#1: handle",
+		},
+		{
+			breaker:	func(h *DHeap[int]) { h.prio[h.heap[7]] = -1 },
+			want:		"v#2: priority -1 of handle",
+		},
+		{
+			breaker:	func(h *DHeap[int]) { h.heap = h.heap[:len(h.heap)-1] },
+			want:		"v#3: index contains",
+		},
+	} {
+		h := newHeapTestKeys(t, testKeys, 3)
+		test.breaker(h)
+
+		height, err := h.SelfTest()
+		if err == nil {
+			t.Errorf("[%d] SelfTest() did not detect the problem", i)
+			continue
+		}
+		if height != 0 {
+			t.Errorf("[%d] SelfTest() returned non-zero height %d with error", i, height)
+		}
+		if len(err.Error()) < len(test.want) || err.Error()[:len(test.want)] != test.want {
+			t.Errorf("[%d] SelfTest() returned %q, want prefix - %q", i, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package dheap
+
+import "fmt"
+
+func Example_dijkstra() {
+	// Weighted directed graph as adjacency lists: vertex -> (vertex, weight)
+	graph := [][][2]int{
+		0: {{1, 4}, {2, 1}},
+		1: {{3, 1}},
+		2: {{1, 2}, {3, 5}},
+		3: {},
+	}
+
+	// Distances from vertex 0
+	dist := []int{0, -1, -1, -1}
+
+	// Vertexes are handles of the heap, distances are priorities
+	pq := NewDHeap(4, func(a, b int) bool { return a < b })
+	pq.Push(0, 0)
+
+	for pq.Len() != 0 {
+		v, d, _ := pq.Pop()
+		for _, e := range graph[v] {
+			u, nd := e[0], d + e[1]
+			switch {
+			case dist[u] == -1:
+				// First visit of the vertex
+				dist[u] = nd
+				pq.Push(u, nd)
+			case nd < dist[u]:
+				// Shorter path is found
+				dist[u] = nd
+				pq.DecreaseKey(u, nd)
+			}
+		}
+	}
+
+	fmt.Println(dist)
+
+	// Output:
+	// [0 3 1 4]
+}
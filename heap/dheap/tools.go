@@ -0,0 +1,42 @@
+package dheap
+
+import "fmt"
+
+// SelfTest performs a self-test of the heap and returns the height of the heap,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero.
+func (h *DHeap[P]) SelfTest() (int, error) {
+	for k, i := range h.heap {
+		if i < 0 || i >= len(h.pos) || h.pos[i] != k {
+			return 0, fmt.Errorf("v#1: handle %d at position %d has invalid position in the index", i, k)
+		}
+
+		if k == 0 {
+			continue
+		}
+
+		if p := (k - 1) / h.d; h.before(k, p) {
+			return 0, fmt.Errorf("v#2: priority %v of handle %d at position %d is less than priority %v of its parent %d",
+				h.prio[i], i, k, h.prio[h.heap[p]], h.heap[p])
+		}
+	}
+
+	// Count handles in the index, it should be the same as the heap size
+	count := 0
+	for _, k := range h.pos {
+		if k >= 0 {
+			count++
+		}
+	}
+	if count != len(h.heap) {
+		return 0, fmt.Errorf("v#3: index contains %d handles, but the heap size is %d", count, len(h.heap))
+	}
+
+	// OK, calculate the height of the complete d-ary tree
+	height := 0
+	for n, width := len(h.heap), 1; n > 0; n, width = n - width, width * h.d {
+		height++
+	}
+
+	return height, nil
+}
@@ -0,0 +1,31 @@
+package fibheap
+
+import "fmt"
+
+func Example_heapMeld() {
+	less := func(a, b int) bool { return a < b }
+
+	h1, h2 := NewFibHeap(less), NewFibHeap(less)
+	for _, v := range []int{20, 10, 30, 5} {
+		h1.Push(v)
+	}
+	n := h2.Push(40)
+	for _, v := range []int{15, 25, 35} {
+		h2.Push(v)
+	}
+
+	// Move all values of h2 to h1
+	h1.Meld(h2)
+
+	// Nodes of h2 can be used with h1
+	h1.DecreaseKey(n, 1)
+
+	for h1.Len() != 0 {
+		v, _ := h1.Pop()
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+
+	// Output:
+	// 1 5 10 15 20 25 30 35
+}
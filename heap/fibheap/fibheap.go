@@ -0,0 +1,268 @@
+/*
+Package fibheap provides an example of a Fibonacci heap implementation.
+
+A Fibonacci heap is a collection of heap-ordered trees. Most of the work is
+deferred to the popping of the minimal value, when the trees of the same degree
+are consolidated. It gives O(1) amortized time of pushing a value, melding two
+heaps and decreasing a value, and O(log n) amortized time of popping the minimal
+value or deleting an arbitrary node. Thanks to the cheap decrease-key operation
+the heap is suitable for graph algorithms such as Dijkstra's or Prim's.
+
+The heap implements the common interface of meldable heaps defined by the
+parent package heap.
+*/
+package fibheap
+
+import "github.com/r-che/algorithms/heap"
+
+// FibHeap implements a Fibonacci min-heap.
+type FibHeap[T any] struct {
+	// Root with the minimal value, entry point of the circular list of roots
+	min		*FHNode[T]
+	size	int
+	less	func(a, b T) bool
+
+	// Buffers reused by the consolidation to avoid allocations on each Pop
+	roots	[]*FHNode[T]
+	list	[]*FHNode[T]
+}
+
+// Make sure that the heap implements the common interface
+var _ heap.Interface[int, *FHNode[int], *FibHeap[int]] = (*FibHeap[int])(nil)
+
+// NewFibHeap returns new empty Fibonacci heap, less reports whether the value a is less than the value b.
+func NewFibHeap[T any](less func(a, b T) bool) *FibHeap[T] {
+	return &FibHeap[T]{less: less}
+}
+
+// Len returns the number of values in the heap.
+func (h *FibHeap[T]) Len() int {
+	return h.size
+}
+
+// Push pushes the value v to the heap and returns the node that holds the value.
+func (h *FibHeap[T]) Push(v T) *FHNode[T] {
+	n := newFHNode(v)
+	h.addRoot(n)
+	h.size++
+
+	return n
+}
+
+// Peek returns the minimal value and true, or zero value and false if the heap is empty.
+func (h *FibHeap[T]) Peek() (T, bool) {
+	if h.min == nil {
+		var zero T
+		return zero, false
+	}
+
+	return h.min.value, true
+}
+
+// Pop removes the minimal value from the heap and returns it and true,
+// or zero value and false if the heap is empty.
+func (h *FibHeap[T]) Pop() (T, bool) {
+	if h.min == nil {
+		var zero T
+		return zero, false
+	}
+
+	z := h.min
+
+	// Move all children of z to the list of roots
+	if c := z.child; c != nil {
+		for x := c; ; x = x.right {
+			x.parent = nil
+			if x.right == c {
+				break
+			}
+		}
+		z.splice(c)
+		z.child = nil
+	}
+
+	// Remove z from the list of roots
+	if z.right == z {
+		// z was the only root
+		h.min = nil
+	} else {
+		h.min = z.right
+		z.unlink()
+		h.consolidate()
+	}
+
+	z.removed = true
+	h.size--
+
+	return z.value, true
+}
+
+// DecreaseKey replaces the value of the node n by v. It returns false if v is greater
+// than the current value or the node was removed from the heap, in this case the heap
+// is not modified.
+func (h *FibHeap[T]) DecreaseKey(n *FHNode[T], v T) bool {
+	if n.removed || h.less(n.value, v) {
+		return false
+	}
+
+	n.value = v
+
+	if p := n.parent; p != nil && h.less(n.value, p.value) {
+		// Heap order is violated, move n to the list of roots
+		h.cut(n, p)
+		h.cascadingCut(p)
+	}
+
+	if h.less(n.value, h.min.value) {
+		h.min = n
+	}
+
+	return true
+}
+
+// Delete removes the node n from the heap and returns its value and true,
+// or zero value and false if the node was already removed.
+func (h *FibHeap[T]) Delete(n *FHNode[T]) (T, bool) {
+	if n.removed {
+		var zero T
+		return zero, false
+	}
+
+	// Move n to the list of roots as if its value was decreased to the minus infinity
+	if p := n.parent; p != nil {
+		h.cut(n, p)
+		h.cascadingCut(p)
+	}
+	h.min = n
+
+	return h.Pop()
+}
+
+// Meld moves all values of the heap other to the heap h, other becomes empty. Nodes of other
+// stay valid and can be used with h. Both heaps must use the same order of values.
+func (h *FibHeap[T]) Meld(other *FibHeap[T]) {
+	if other == h || other.min == nil {
+		return
+	}
+
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		h.min.splice(other.min)
+		if h.less(other.min.value, h.min.value) {
+			h.min = other.min
+		}
+	}
+	h.size += other.size
+
+	other.min, other.size = nil, 0
+}
+
+// addRoot adds the single node n to the list of roots
+func (h *FibHeap[T]) addRoot(n *FHNode[T]) {
+	if h.min == nil {
+		h.min = n
+		return
+	}
+
+	h.min.splice(n)
+	if h.less(n.value, h.min.value) {
+		h.min = n
+	}
+}
+
+// consolidate links the roots of the same degree until all roots have different degrees
+// and finds the new minimal root. The h.min must point to any root.
+func (h *FibHeap[T]) consolidate() {
+	// Roots indexed by their degrees
+	roots := h.roots[:0]
+
+	// Collect the list of roots first, because it is modified by linking
+	list := h.list[:0]
+	for x := h.min; ; x = x.right {
+		list = append(list, x)
+		if x.right == h.min {
+			break
+		}
+	}
+
+	for _, x := range list {
+		for {
+			for len(roots) <= x.degree {
+				roots = append(roots, nil)
+			}
+
+			y := roots[x.degree]
+			if y == nil {
+				break
+			}
+
+			// Two roots of the same degree, the root with the greater value becomes a child
+			if h.less(y.value, x.value) {
+				x, y = y, x
+			}
+			roots[x.degree] = nil
+			h.link(y, x)
+		}
+
+		roots[x.degree] = x
+	}
+
+	// Find the new minimum
+	h.min = nil
+	for i, x := range roots {
+		if x != nil && (h.min == nil || h.less(x.value, h.min.value)) {
+			h.min = x
+		}
+		roots[i] = nil
+	}
+
+	// Keep the buffers for the next consolidation
+	for i := range list {
+		list[i] = nil
+	}
+	h.roots, h.list = roots, list
+}
+
+// link removes the root y from the list of roots and makes it a child of the root x
+func (h *FibHeap[T]) link(y, x *FHNode[T]) {
+	y.unlink()
+	y.parent = x
+	y.mark = false
+
+	if x.child == nil {
+		x.child = y
+	} else {
+		x.child.splice(y)
+	}
+	x.degree++
+}
+
+// cut moves the child n of p to the list of roots
+func (h *FibHeap[T]) cut(n, p *FHNode[T]) {
+	if p.child == n {
+		if n.right == n {
+			p.child = nil
+		} else {
+			p.child = n.right
+		}
+	}
+	n.unlink()
+	p.degree--
+
+	n.parent = nil
+	n.mark = false
+	h.min.splice(n)
+}
+
+// cascadingCut cuts the marked ancestors of the node that lost a child until the unmarked one is found
+func (h *FibHeap[T]) cascadingCut(n *FHNode[T]) {
+	for p := n.parent; p != nil; n, p = p, p.parent {
+		if !n.mark {
+			n.mark = true
+			return
+		}
+
+		h.cut(n, p)
+	}
+}
@@ -0,0 +1,354 @@
+package fibheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Number of keys gives long lists of roots and deep trees after a series of operations
+	keysCount	=	4096
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2232
+
+	// Self-test walks the whole heap, so it is run once per selfTestStep operations
+	selfTestStep	=	64
+)
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+func sortedKeys(testKeys []int) []int {
+	keys := make([]int, len(testKeys))
+	copy(keys, testKeys)
+	sort.Ints(keys)
+
+	return keys
+}
+
+func newHeapTestKeys(t *testing.T, testKeys []int) (*FibHeap[int], []*FHNode[int]) {
+	t.Helper()
+
+	h := NewFibHeap(intLess)
+	nodes := make([]*FHNode[int], 0, len(testKeys))
+	for _, k := range testKeys {
+		nodes = append(nodes, h.Push(k))
+	}
+
+	if _, err := h.SelfTest(); err != nil {
+		t.Fatalf("Self-test of the heap failed: %v", err)
+	}
+
+	return h, nodes
+}
+
+// checkPopAll pops all values from the heap and compares them with expected values
+func checkPopAll(t *testing.T, h *FibHeap[int], want []int) {
+	t.Helper()
+
+	for i, w := range want {
+		v, ok := h.Pop()
+		if !ok {
+			t.Fatalf("[%d] Pop() returned false, want - %d, heap size - %d", i, w, h.Len())
+		}
+		if v != w {
+			t.Fatalf("[%d] Pop() returned %d, want - %d", i, v, w)
+		}
+
+		if i % selfTestStep == 0 {
+			if _, err := h.SelfTest(); err != nil {
+				t.Fatalf("[%d] Self-test failed after Pop(): %v", i, err)
+			}
+		}
+	}
+
+	if h.Len() != 0 {
+		t.Errorf("Heap is not empty after popping all values, size - %d", h.Len())
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	h := NewFibHeap(intLess)
+
+	if v, ok := h.Peek(); ok {
+		t.Errorf("Peek() on empty heap returned %d, true", v)
+	}
+
+	if v, ok := h.Pop(); ok {
+		t.Errorf("Pop() on empty heap returned %d, true", v)
+	}
+
+	if height, err := h.SelfTest(); err != nil || height != 0 {
+		t.Errorf("SelfTest() of empty heap returned %d, %v", height, err)
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h, nodes := newHeapTestKeys(t, testKeys)
+
+	if h.Len() != len(testKeys) {
+		t.Fatalf("Heap has size %d, want - %d", h.Len(), len(testKeys))
+	}
+
+	want := sortedKeys(testKeys)
+	if v, ok := h.Peek(); !ok || v != want[0] {
+		t.Errorf("Peek() returned %d, %t, want - %d, true", v, ok, want[0])
+	}
+
+	checkPopAll(t, h, want)
+
+	for i, n := range nodes {
+		if n.InHeap() {
+			t.Fatalf("[%d] Node %d is still in the heap after popping all values", i, n.Value())
+		}
+	}
+}
+
+func TestPushDupes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h := NewFibHeap(intLess)
+	for i := 0; i < 3; i++ {
+		for _, k := range testKeys {
+			h.Push(k)
+		}
+	}
+
+	want := make([]int, 0, 3 * len(testKeys))
+	for _, k := range sortedKeys(testKeys) {
+		want = append(want, k, k, k)
+	}
+
+	checkPopAll(t, h, want)
+}
+
+func TestDecreaseKey(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h, nodes := newHeapTestKeys(t, testKeys)
+
+	// Pop some values to make the heap non-trivial
+	for i := 0; i < len(testKeys) / 4; i++ {
+		h.Pop()
+	}
+
+	want := make([]int, 0, len(testKeys))
+	for i, n := range nodes {
+		if !n.InHeap() {
+			if h.DecreaseKey(n, -1) {
+				t.Fatalf("[%d] DecreaseKey() returned true for removed node", i)
+			}
+			continue
+		}
+
+		// Increase of values is not allowed
+		if h.DecreaseKey(n, n.Value() + 1) {
+			t.Fatalf("[%d] DecreaseKey() to the greater value returned true", i)
+		}
+
+		v := n.Value() - MaxItem / 2
+		if !h.DecreaseKey(n, v) {
+			t.Fatalf("[%d] DecreaseKey(%d) returned false", i, v)
+		}
+		want = append(want, v)
+
+		if i % selfTestStep == 0 {
+			if _, err := h.SelfTest(); err != nil {
+				t.Fatalf("[%d] Self-test failed after DecreaseKey(): %v", i, err)
+			}
+		}
+	}
+
+	sort.Ints(want)
+	checkPopAll(t, h, want)
+}
+
+func TestDelete(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h, nodes := newHeapTestKeys(t, testKeys)
+
+	// Pop some values to make the heap non-trivial
+	for i := 0; i < len(testKeys) / 4; i++ {
+		h.Pop()
+	}
+
+	// Delete the nodes in random order
+	rnd.Shuffle(len(nodes), func(i, j int) { nodes[i], nodes[j] = nodes[j], nodes[i] })
+
+	// Keep the last half of nodes in the heap
+	keep := nodes[len(nodes)/2:]
+	for i, n := range nodes[:len(nodes)/2] {
+		inHeap := n.InHeap()
+		v, ok := h.Delete(n)
+		if ok != inHeap || (ok && v != n.Value()) {
+			t.Fatalf("[%d] Delete() returned %d, %t, want - %d, %t", i, v, ok, n.Value(), inHeap)
+		}
+
+		if n.InHeap() {
+			t.Fatalf("[%d] Deleted node %d is still in the heap", i, n.Value())
+		}
+
+		if i % selfTestStep == 0 {
+			if _, err := h.SelfTest(); err != nil {
+				t.Fatalf("[%d] Self-test failed after Delete(): %v", i, err)
+			}
+		}
+	}
+
+	want := make([]int, 0, len(keep))
+	for _, n := range keep {
+		if n.InHeap() {
+			want = append(want, n.Value())
+		}
+	}
+	sort.Ints(want)
+
+	checkPopAll(t, h, want)
+}
+
+func TestMeld(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	h1, h2 := NewFibHeap(intLess), NewFibHeap(intLess)
+	var nodes2 []*FHNode[int]
+	for i, k := range testKeys {
+		if i % 2 == 0 {
+			h1.Push(k)
+		} else {
+			nodes2 = append(nodes2, h2.Push(k))
+		}
+	}
+
+	// Meld with itself does nothing
+	h1.Meld(h1)
+
+	h1.Meld(h2)
+
+	if h2.Len() != 0 {
+		t.Errorf("Melded heap has size %d, want - 0", h2.Len())
+	}
+	if v, ok := h2.Pop(); ok {
+		t.Errorf("Pop() on melded heap returned %d, true", v)
+	}
+
+	if _, err := h1.SelfTest(); err != nil {
+		t.Fatalf("Self-test failed after Meld(): %v", err)
+	}
+
+	// Nodes of the melded heap can be used with the resulting heap
+	want := make([]int, 0, len(testKeys))
+	for i, k := range testKeys {
+		if i % 2 == 0 {
+			want = append(want, k)
+		}
+	}
+	for i, n := range nodes2 {
+		if !h1.DecreaseKey(n, -n.Value()) {
+			t.Fatalf("[%d] DecreaseKey() on node from melded heap returned false", i)
+		}
+		want = append(want, n.Value())
+	}
+	sort.Ints(want)
+
+	checkPopAll(t, h1, want)
+}
+
+// maxDegreeRoot returns the root with the maximal degree
+func maxDegreeRoot(h *FibHeap[int]) *FHNode[int] {
+	r := h.min
+	for x := h.min.right; x != h.min; x = x.right {
+		if x.degree > r.degree {
+			r = x
+		}
+	}
+
+	return r
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, MaxItem)
+
+	for i, test := range []struct {
+		breaker	func(h *FibHeap[int])
+		want	string
+	} {
+		{
+			breaker:	func(h *FibHeap[int]) { h.size++ },
+			want:		"v#1: heap contains",
+		},
+		{
+			breaker:	func(h *FibHeap[int]) { h.min.right.parent = h.min },
+			want:		"v#2: root",
+		},
+		{
+			breaker:	func(h *FibHeap[int]) { h.min.right.value = -1 },
+			want:		"v#3: root -1 is less than the minimal root",
+		},
+		{
+			breaker:	func(h *FibHeap[int]) { maxDegreeRoot(h).child.removed = true },
+			want:		"v#4: node",
+		},
+		{
+			breaker:	func(h *FibHeap[int]) { maxDegreeRoot(h).child.left = h.min },
+			want:		"v#5: node",
+		},
+		{
+			breaker:	func(h *FibHeap[int]) { maxDegreeRoot(h).child.parent = nil },
+			want:		"v#6: child",
+		},
+		{
+			breaker:	func(h *FibHeap[int]) { maxDegreeRoot(h).child.value = -1 },
+			want:		"v#7: child -1 is less than its parent",
+		},
+		{
+			breaker:	func(h *FibHeap[int]) { maxDegreeRoot(h).degree++ },
+			want:		"v#8: node",
+		},
+		{
+			breaker:	func(h *FibHeap[int]) {
+				// Cut all grandchildren of the root, its degree stays correct
+				r := maxDegreeRoot(h)
+				for x := r.child; ; x = x.right {
+					for x.child != nil {
+						h.cut(x.child, x)
+					}
+					if x.right == r.child {
+						break
+					}
+				}
+			},
+			want:		"v#9: node",
+		},
+	} {
+		h, _ := newHeapTestKeys(t, testKeys)
+		// Pop the value to consolidate the trees
+		h.Pop()
+		test.breaker(h)
+
+		height, err := h.SelfTest()
+		if err == nil {
+			t.Errorf("[%d] SelfTest() did not detect the problem", i)
+			continue
+		}
+		if height != 0 {
+			t.Errorf("[%d] SelfTest() returned non-zero height %d with error", i, height)
+		}
+		if len(err.Error()) < len(test.want) || err.Error()[:len(test.want)] != test.want {
+			t.Errorf("[%d] SelfTest() returned %q, want prefix - %q", i, err, test.want)
+		}
+	}
+}
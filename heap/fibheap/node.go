@@ -0,0 +1,54 @@
+package fibheap
+
+// FHNode implements a node of the Fibonacci heap
+type FHNode[T any] struct {
+	value	T
+
+	parent	*FHNode[T]
+	// Any of the children
+	child	*FHNode[T]
+	// Siblings in the circular doubly linked list
+	left	*FHNode[T]
+	right	*FHNode[T]
+
+	// Number of children
+	degree	int
+	// The node lost a child since it became a child of its parent
+	mark	bool
+	// The node was removed from the heap
+	removed	bool
+}
+
+func newFHNode[T any](v T) *FHNode[T] {
+	n := &FHNode[T]{value: v}
+	n.left, n.right = n, n
+
+	return n
+}
+
+// Value returns the value held by the node
+func (n *FHNode[T]) Value() T {
+	return n.value
+}
+
+// InHeap returns true if the node was not removed from the heap
+func (n *FHNode[T]) InHeap() bool {
+	return !n.removed
+}
+
+// unlink removes the node n from the list of its siblings and makes it a single-node list
+func (n *FHNode[T]) unlink() {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left, n.right = n, n
+}
+
+// splice inserts the list started from m to the list of n, right after n
+func (n *FHNode[T]) splice(m *FHNode[T]) {
+	nRight, mLeft := n.right, m.left
+
+	n.right = m
+	m.left = n
+	mLeft.right = nRight
+	nRight.left = mLeft
+}
@@ -0,0 +1,106 @@
+package fibheap
+
+import "fmt"
+
+// SelfTest performs a self-test of the heap and returns the height of the heap,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero.
+func (h *FibHeap[T]) SelfTest() (int, error) {
+	if h.min == nil {
+		if h.size != 0 {
+			return 0, fmt.Errorf("v#1: heap is empty but its size is %d", h.size)
+		}
+
+		return 0, nil
+	}
+
+	count, height := 0, 0
+	for x := h.min; ; x = x.right {
+		if x.parent != nil {
+			return 0, fmt.Errorf("v#2: root %v has parent %v", x.value, x.parent.value)
+		}
+
+		if h.less(x.value, h.min.value) {
+			return 0, fmt.Errorf("v#3: root %v is less than the minimal root %v", x.value, h.min.value)
+		}
+
+		xh, xs, err := h.checkNode(x)
+		if err != nil {
+			return 0, err
+		}
+		count += xs
+		if xh > height {
+			height = xh
+		}
+
+		if x.right == h.min {
+			break
+		}
+	}
+
+	if count != h.size {
+		return 0, fmt.Errorf("v#1: heap contains %d nodes but its size is %d", count, h.size)
+	}
+
+	return height, nil
+}
+
+// checkNode checks the tree with root n and returns its height and size
+func (h *FibHeap[T]) checkNode(n *FHNode[T]) (int, int, error) {
+	if n.removed {
+		return 0, 0, fmt.Errorf("v#4: node %v is marked as removed but it is in the heap", n.value)
+	}
+
+	if n.left.right != n || n.right.left != n {
+		return 0, 0, fmt.Errorf("v#5: node %v has invalid links to siblings", n.value)
+	}
+
+	height, size, degree := 0, 1, 0
+	if c := n.child; c != nil {
+		for x := c; ; x = x.right {
+			if x.parent != n {
+				return 0, 0, fmt.Errorf("v#6: child %v has invalid parent link", x.value)
+			}
+
+			if h.less(x.value, n.value) {
+				return 0, 0, fmt.Errorf("v#7: child %v is less than its parent %v", x.value, n.value)
+			}
+
+			xh, xs, err := h.checkNode(x)
+			if err != nil {
+				return 0, 0, err
+			}
+			size += xs
+			if xh > height {
+				height = xh
+			}
+			degree++
+
+			if x.right == c {
+				break
+			}
+		}
+	}
+
+	if degree != n.degree {
+		return 0, 0, fmt.Errorf("v#8: node %v has %d children but its degree is %d", n.value, degree, n.degree)
+	}
+
+	// The size of a tree with root of degree k is at least F(k+2)
+	if fib := fibonacci(n.degree + 2); size < fib {
+		return 0, 0, fmt.Errorf("v#9: node %v of degree %d has %d nodes in its subtree, want at least %d",
+			n.value, n.degree, size, fib)
+	}
+
+	return height + 1, size, nil
+}
+
+// fibonacci returns the k-th Fibonacci number
+func fibonacci(k int) int {
+	a, b := 0, 1
+	for i := 0; i < k; i++ {
+		a, b = b, a + b
+	}
+
+	return a
+}
@@ -0,0 +1,38 @@
+/*
+Package heap defines the common interface of meldable heaps implemented by
+the subpackages, so that they can be used interchangeably, e.g. in graph
+algorithms or in benchmarks.
+*/
+package heap
+
+// Node is the node of the heap that holds a value pushed to the heap
+type Node[T any] interface {
+	// Value returns the value held by the node
+	Value() T
+	// InHeap returns true if the node was not removed from the heap
+	InHeap() bool
+}
+
+// Interface is the common interface of meldable min-heaps. T is the type
+// of values, N is the type of nodes and H is the type of the heap itself.
+type Interface[T any, N Node[T], H any] interface {
+	// Len returns the number of values in the heap
+	Len() int
+	// Push pushes the value to the heap and returns its node
+	Push(v T) N
+	// Peek returns the minimal value and true, or zero value and false if the heap is empty
+	Peek() (T, bool)
+	// Pop removes the minimal value from the heap and returns it and true,
+	// or zero value and false if the heap is empty
+	Pop() (T, bool)
+	// DecreaseKey replaces the value of the node by the lesser one, it returns
+	// false if the value is greater or the node was removed from the heap
+	DecreaseKey(n N, v T) bool
+	// Delete removes the node from the heap and returns its value and true,
+	// or zero value and false if the node was already removed
+	Delete(n N) (T, bool)
+	// Meld moves all values of the other heap to the heap, the other heap becomes empty
+	Meld(other H)
+	// SelfTest checks the invariants of the heap and returns its height or an error
+	SelfTest() (int, error)
+}
@@ -0,0 +1,47 @@
+package pairing
+
+// PHNode implements a node of the pairing heap
+type PHNode[T any] struct {
+	value	T
+
+	// Leftmost child
+	child	*PHNode[T]
+	// Right sibling
+	next	*PHNode[T]
+	// Left sibling or parent for the leftmost child, nil for the root
+	prev	*PHNode[T]
+
+	// The node was removed from the heap
+	removed	bool
+}
+
+// Value returns the value held by the node
+func (n *PHNode[T]) Value() T {
+	return n.value
+}
+
+// InHeap returns true if the node was not removed from the heap
+func (n *PHNode[T]) InHeap() bool {
+	return !n.removed
+}
+
+// cut detaches the subtree with root n from its parent and siblings
+func (n *PHNode[T]) cut() {
+	if n.prev == nil {
+		// Root or already detached
+		return
+	}
+
+	if n.prev.child == n {
+		// Leftmost child - the parent has to point to the next sibling
+		n.prev.child = n.next
+	} else {
+		n.prev.next = n.next
+	}
+
+	if n.next != nil {
+		n.next.prev = n.prev
+	}
+
+	n.prev, n.next = nil, nil
+}
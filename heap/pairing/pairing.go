@@ -0,0 +1,188 @@
+/*
+Package pairing provides an example of a pairing heap implementation.
+
+A pairing heap is a heap-ordered multiway tree. It is simple to implement
+and works fast in practice: pushing a value, melding two heaps and decreasing
+a value take O(1) time (decreasing is conjectured to be o(log n) amortized), and
+popping the minimal value takes O(log n) amortized time using the two-pass
+pairing of the children of the removed root.
+
+The heap implements the common interface of meldable heaps defined by the
+parent package heap.
+*/
+package pairing
+
+import "github.com/r-che/algorithms/heap"
+
+// PairingHeap implements a pairing min-heap.
+type PairingHeap[T any] struct {
+	root	*PHNode[T]
+	size	int
+	less	func(a, b T) bool
+}
+
+// Make sure that the heap implements the common interface
+var _ heap.Interface[int, *PHNode[int], *PairingHeap[int]] = (*PairingHeap[int])(nil)
+
+// NewPairingHeap returns new empty pairing heap, less reports whether the value a is less than the value b.
+func NewPairingHeap[T any](less func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{less: less}
+}
+
+// Len returns the number of values in the heap.
+func (h *PairingHeap[T]) Len() int {
+	return h.size
+}
+
+// Push pushes the value v to the heap and returns the node that holds the value.
+func (h *PairingHeap[T]) Push(v T) *PHNode[T] {
+	n := &PHNode[T]{value: v}
+	h.root = h.link(h.root, n)
+	h.size++
+
+	return n
+}
+
+// Peek returns the minimal value and true, or zero value and false if the heap is empty.
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	return h.root.value, true
+}
+
+// Pop removes the minimal value from the heap and returns it and true,
+// or zero value and false if the heap is empty.
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+
+	n := h.root
+	h.root = h.mergePairs(n.child)
+	h.detach(n)
+
+	return n.value, true
+}
+
+// DecreaseKey replaces the value of the node n by v. It returns false if v is greater
+// than the current value or the node was removed from the heap, in this case the heap
+// is not modified.
+func (h *PairingHeap[T]) DecreaseKey(n *PHNode[T], v T) bool {
+	if n.removed || h.less(n.value, v) {
+		return false
+	}
+
+	n.value = v
+	if n != h.root {
+		// Cut the subtree and link it with the root again
+		n.cut()
+		h.root = h.link(h.root, n)
+	}
+
+	return true
+}
+
+// Delete removes the node n from the heap and returns its value and true,
+// or zero value and false if the node was already removed.
+func (h *PairingHeap[T]) Delete(n *PHNode[T]) (T, bool) {
+	if n.removed {
+		var zero T
+		return zero, false
+	}
+
+	if n == h.root {
+		return h.Pop()
+	}
+
+	// Cut the subtree, merge the children of the node and link them with the root
+	n.cut()
+	h.root = h.link(h.root, h.mergePairs(n.child))
+	h.detach(n)
+
+	return n.value, true
+}
+
+// Meld moves all values of the heap other to the heap h, other becomes empty. Nodes of other
+// stay valid and can be used with h. Both heaps must use the same order of values.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == h {
+		return
+	}
+
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+
+	other.root, other.size = nil, 0
+}
+
+// detach marks the node n as removed from the heap
+func (h *PairingHeap[T]) detach(n *PHNode[T]) {
+	n.child, n.next, n.prev = nil, nil, nil
+	n.removed = true
+	h.size--
+}
+
+// link links two trees with roots a and b and returns the root of the resulting tree
+func (h *PairingHeap[T]) link(a, b *PHNode[T]) *PHNode[T] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case h.less(b.value, a.value):
+		a, b = b, a
+	}
+
+	// Now b becomes the leftmost child of a
+	b.prev = a
+	b.next = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+
+	return a
+}
+
+// mergePairs merges the list of siblings starting from n using the two-pass
+// pairing and returns the root of the resulting tree
+func (h *PairingHeap[T]) mergePairs(n *PHNode[T]) *PHNode[T] {
+	if n == nil {
+		return nil
+	}
+
+	// First pass - link pairs from left to right, the results
+	// are collected in the reverse order using the next pointer
+	var pairs *PHNode[T]
+	for n != nil {
+		a, b := n, n.next
+		if b == nil {
+			n = nil
+		} else {
+			n = b.next
+			b.prev, b.next = nil, nil
+		}
+		a.prev, a.next = nil, nil
+
+		p := h.link(a, b)
+		p.next = pairs
+		pairs = p
+	}
+
+	// Second pass - link the pairs from right to left
+	root := pairs
+	pairs, root.next = pairs.next, nil
+	for pairs != nil {
+		p := pairs
+		pairs = pairs.next
+		p.next = nil
+
+		root = h.link(root, p)
+	}
+
+	return root
+}
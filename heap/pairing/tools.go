@@ -0,0 +1,63 @@
+package pairing
+
+import "fmt"
+
+// SelfTest performs a self-test of the heap and returns the height of the heap,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero.
+func (h *PairingHeap[T]) SelfTest() (int, error) {
+	if h.root == nil {
+		if h.size != 0 {
+			return 0, fmt.Errorf("v#1: heap is empty but its size is %d", h.size)
+		}
+
+		return 0, nil
+	}
+
+	if h.root.prev != nil || h.root.next != nil {
+		return 0, fmt.Errorf("v#2: root %v has siblings or parent", h.root.value)
+	}
+
+	count := 0
+	height, err := h.checkNode(h.root, &count)
+	if err != nil {
+		return 0, err
+	}
+
+	if count != h.size {
+		return 0, fmt.Errorf("v#1: heap contains %d nodes but its size is %d", count, h.size)
+	}
+
+	return height, nil
+}
+
+// checkNode checks the subtree with root n, counts its nodes and returns its height
+func (h *PairingHeap[T]) checkNode(n *PHNode[T], count *int) (int, error) {
+	if n.removed {
+		return 0, fmt.Errorf("v#3: node %v is marked as removed but it is in the heap", n.value)
+	}
+
+	*count++
+
+	height := 0
+	prev := n
+	for c := n.child; c != nil; prev, c = c, c.next {
+		if c.prev != prev {
+			return 0, fmt.Errorf("v#4: node %v has invalid link to the left sibling or parent", c.value)
+		}
+
+		if h.less(c.value, n.value) {
+			return 0, fmt.Errorf("v#5: child %v is less than its parent %v", c.value, n.value)
+		}
+
+		ch, err := h.checkNode(c, count)
+		if err != nil {
+			return 0, err
+		}
+		if ch > height {
+			height = ch
+		}
+	}
+
+	return height + 1, nil
+}
@@ -0,0 +1,63 @@
+/*
+Package bintree contains helpers for binary trees of packages of this module that
+do not depend on the type of nodes: the height of a tree, the check of links between
+nodes and the output of a tree in the DOT language of Graphviz.
+
+Nodes are accessed through the Node and LinkedNode interfaces, the zero value of
+the node type, e.g. the nil pointer, is an absent node.
+*/
+package bintree
+
+import "fmt"
+
+// Node is a node of a binary tree with accessors to children
+type Node[N any] interface {
+	comparable
+	Left() N
+	Right() N
+}
+
+// LinkedNode is a node of a binary tree with accessors to children and to the parent
+type LinkedNode[N any] interface {
+	Node[N]
+	Parent() N
+}
+
+// Height returns the height of the subtree n, the height of an empty subtree is 0
+func Height[N Node[N]](n N) int {
+	var null N
+	if n == null {
+		return 0
+	}
+
+	hl, hr := Height(n.Left()), Height(n.Right())
+	if hl > hr {
+		return hl + 1
+	}
+
+	return hr + 1
+}
+
+// CheckLinks checks that children of each node of the subtree root refer to the node as the parent
+func CheckLinks[N LinkedNode[N]](root N) error {
+	var null N
+
+	for stack := []N{root}; len(stack) != 0; {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if n == null {
+			continue
+		}
+
+		for _, c := range [...]N{n.Left(), n.Right()} {
+			if c != null && c.Parent() != n {
+				return fmt.Errorf("node %v has child %v with the wrong parent %v", n, c, c.Parent())
+			}
+		}
+
+		stack = append(stack, n.Left(), n.Right())
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package bintree
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the subtree root to w in the DOT language, key returns the key of
+// a node that is used as the name of the node, attrs returns additional attributes
+// of a node. Absent children of nodes with one child are shown as points to distinguish
+// left and right children.
+func WriteDOT[N Node[N]](w io.Writer, root N, key func(N) int, attrs func(N) string) error {
+	var null N
+
+	out := strings.Builder{}
+	out.WriteString("digraph \"bstree\" {\n\tnode [shape=circle];\n")
+
+	nulls := 0
+	for queue := []N{root}; len(queue) != 0; queue = queue[1:] {
+		n := queue[0]
+		if n == null {
+			continue
+		}
+
+		fmt.Fprintf(&out, "\t%d", key(n))
+		if a := attrs(n); a != "" {
+			fmt.Fprintf(&out, " [%s]", a)
+		}
+		out.WriteString(";\n")
+
+		l, r := n.Left(), n.Right()
+		if l == null && r == null {
+			continue
+		}
+
+		for _, c := range [...]N{l, r} {
+			if c == null {
+				nulls++
+				fmt.Fprintf(&out, "\tnull%d [shape=point];\n\t%d -> null%d;\n", nulls, key(n), nulls)
+			} else {
+				fmt.Fprintf(&out, "\t%d -> %d;\n", key(n), key(c))
+			}
+		}
+
+		queue = append(queue, l, r)
+	}
+	out.WriteString("}\n")
+
+	_, err := io.WriteString(w, out.String())
+
+	return err
+}
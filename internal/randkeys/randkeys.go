@@ -0,0 +1,35 @@
+/*
+Package randkeys generates random keys for tests of packages of this module.
+
+Each test creates its own random source with a static seed, so tests are reproducible
+and do not depend on each other or on the order of their execution.
+*/
+package randkeys
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Unique returns n unique keys in the range [0, max] generated by rnd in random order.
+// It panics if the range contains less than n keys.
+func Unique[K ~int](rnd *rand.Rand, n int, max K) []K {
+	if int(max) + 1 < n {
+		panic(fmt.Sprintf("cannot generate %d unique keys in the range [0, %d]", n, max))
+	}
+
+	uniqs := make(map[K]bool, n)
+	keys := make([]K, 0, n)
+	for len(keys) < n {
+		k := K(rnd.Intn(int(max) + 1))
+		if uniqs[k] {
+			// Already exists
+			continue
+		}
+
+		uniqs[k] = true
+		keys = append(keys, k)
+	}
+
+	return keys
+}
@@ -0,0 +1,66 @@
+package skiplist
+
+import "fmt"
+
+func Example_listCreation() {
+	// Create list with the static seed to get the same structure each time
+	list := NewSkipListWith(0.5, 4, 1)
+
+	// Insert keys and data
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35} {
+		list.Insert(NewSLNode(k, fmt.Sprintf("Value for key %v", k)))
+	}
+
+	// Print graphical representation of the list
+	fmt.Print(list)
+
+	// Output:
+	// HEAD -------------> 15 -------------------------> NIL
+	// HEAD -> 5  -------> 15 -------------------------> NIL
+	// HEAD -> 5  -------> 15 -------> 25 -------------> NIL
+	// HEAD -> 5  -> 10 -> 15 -> 20 -> 25 -> 30 -> 35 -> NIL
+}
+
+func Example_listWalkingDescending() {
+	// List creation
+	list := NewSkipList()
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		list.Insert(NewSLNode(k, fmt.Sprintf("Value for key %v", k)))
+	}
+
+	// Get the maximal node
+	n := list.Max()
+	// Print it
+	fmt.Print(n.Key())
+
+	// Walking through all nodes in descending order using the Predecessor method
+	for n = list.Predecessor(n); n != nil; n = list.Predecessor(n) {
+		fmt.Print(" <- ", n.Key())
+	}
+
+	fmt.Println()
+
+	// Output:
+	// 37 <- 35 <- 33 <- 30 <- 27 <- 25 <- 23 <- 20 <- 17 <- 15 <- 13 <- 10 <- 8 <- 5 <- 2
+}
+
+func Example_listRange() {
+	// List creation
+	list := NewSkipList()
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		list.Insert(NewSLNode(k, fmt.Sprintf("Value for key %v", k)))
+	}
+
+	// Print values of keys in the range [12, 24]
+	list.Range(12, 24, func(n *SLNode) bool {
+		fmt.Println(n.Value())
+		return true
+	})
+
+	// Output:
+	// Value for key 13
+	// Value for key 15
+	// Value for key 17
+	// Value for key 20
+	// Value for key 23
+}
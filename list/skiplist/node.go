@@ -0,0 +1,74 @@
+package skiplist
+
+import "fmt"
+
+// KeyType represents the key type of a skip list node
+type KeyType int
+
+const (
+	FakeNode = KeyType(-1)
+
+	strFakeNode		=	`<>`
+	strInvalidNode	=	`<invalid>`
+)
+
+func (k KeyType) String() string {
+	switch {
+	case k == FakeNode:
+		return strFakeNode
+	case k < 0:
+		return strInvalidNode
+	default:
+		return fmt.Sprintf("%d", k)
+	}
+}
+
+// SLNode implements a skip list node
+type SLNode struct {
+	key		KeyType
+
+	// Forward pointers, one per level of the node
+	next	[]*SLNode
+	// Backward pointer on the lowest level
+	prev	*SLNode
+
+	data	any
+}
+
+// NewSLNode creates a skip list node with key k and associates the data with it
+func NewSLNode(k KeyType, data any) *SLNode {
+	return &SLNode{key: k, data: data}
+}
+
+func (n *SLNode) String() string {
+	if n == nil {
+		return "<nil>"
+	}
+	return n.key.String()
+}
+
+// Key returns the key value of the node
+func (n *SLNode) Key() KeyType {
+	if n == nil {
+		return FakeNode
+	}
+	return n.key
+}
+
+// Value returns the data associated with the node
+func (n *SLNode) Value() any {
+	if n == nil {
+		return nil
+	}
+
+	return n.data
+}
+
+// Level returns the number of levels of the node, or 0 if the node is not in the list
+func (n *SLNode) Level() int {
+	if n == nil {
+		return 0
+	}
+
+	return len(n.next)
+}
@@ -0,0 +1,250 @@
+/*
+Package skiplist provides an example of a skip list implementation.
+
+A skip list is a linked list with additional levels of forward pointers, each
+next level skips more nodes than the previous one. The number of levels of each
+node is chosen randomly, so the expected time of search, insertion and deletion
+is O(log n).
+
+It supports standard procedures of ordered maps, such as: inserting and deleting
+nodes, finding nodes by given arbitrary key, finding maximum and minimum nodes,
+finding the predecessor and successor of a node and iterating over ranges of keys.
+
+It supports output of graphical representation of the list levels using ASCII
+graphics. For example, a list with the keys 20, 10, 30, 5, 15, 25, 35 added
+sequentially may look like this:
+
+	HEAD -------------> 15 -------------------------> NIL
+	HEAD -> 5  -------> 15 -------------------------> NIL
+	HEAD -> 5  -------> 15 -------> 25 -------------> NIL
+	HEAD -> 5  -> 10 -> 15 -> 20 -> 25 -> 30 -> 35 -> NIL
+*/
+package skiplist
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	// DefaultProbability is the default probability of the node to have the next level
+	DefaultProbability = 0.25
+	// DefaultMaxLevel is the default maximal number of levels
+	DefaultMaxLevel = 16
+)
+
+// SkipList implements a skip list.
+type SkipList struct {
+	// Sentinel node that contains forward pointers to the first node on each level
+	head	SLNode
+	// Number of levels in use
+	level	int
+	// Number of nodes
+	size	int
+
+	// Probability of the node to have the next level
+	p			float64
+	// Maximal number of levels
+	maxLevel	int
+	// Random source to choose the number of levels of new nodes
+	rnd			*rand.Rand
+}
+
+// NewSkipList returns new empty skip list with default parameters and random source seeded by the current time.
+func NewSkipList() *SkipList {
+	return NewSkipListWith(DefaultProbability, DefaultMaxLevel, time.Now().UnixNano())
+}
+
+// NewSkipListWith returns new empty skip list with probability p of the node to have the
+// next level, maximal number of levels maxLevel and random source initialized by seed.
+// It panics if p is not in the range (0, 1) or maxLevel is less than 1.
+func NewSkipListWith(p float64, maxLevel int, seed int64) *SkipList {
+	if p <= 0 || p >= 1 {
+		panic(fmt.Sprintf("Invalid skip list level probability %v, must be in the range (0, 1)", p))
+	}
+	if maxLevel < 1 {
+		panic(fmt.Sprintf("Invalid skip list maximal level %d, must be at least 1", maxLevel))
+	}
+
+	l := &SkipList{
+		p:			p,
+		maxLevel:	maxLevel,
+		rnd:		rand.New(rand.NewSource(seed)),	//nolint:gosec // Not for cryptography
+	}
+	l.head.next = make([]*SLNode, maxLevel)
+
+	return l
+}
+
+// Len returns the number of nodes in the list.
+func (l *SkipList) Len() int {
+	return l.size
+}
+
+// Level returns the number of levels in use.
+func (l *SkipList) Level() int {
+	return l.level
+}
+
+// Search returns a list node with key k or nil if there is no such node.
+func (l *SkipList) Search(k KeyType) *SLNode {
+	n := l.lowerBound(k)
+	if n != nil && n.key == k {
+		return n
+	}
+
+	return nil
+}
+
+// lowerBound returns the first node with key greater or equal to k
+func (l *SkipList) lowerBound(k KeyType) *SLNode {
+	n := &l.head
+	for lvl := l.level - 1; lvl >= 0; lvl-- {
+		for n.next[lvl] != nil && n.next[lvl].key < k {
+			n = n.next[lvl]
+		}
+	}
+
+	return n.next[0]
+}
+
+// Min returns the list node with the minimum key value.
+func (l *SkipList) Min() *SLNode {
+	return l.head.next[0]
+}
+
+// Max returns the list node with the maximum key value.
+func (l *SkipList) Max() *SLNode {
+	n := &l.head
+	for lvl := l.level - 1; lvl >= 0; lvl-- {
+		for n.next[lvl] != nil {
+			n = n.next[lvl]
+		}
+	}
+
+	if n == &l.head {
+		// Empty list
+		return nil
+	}
+
+	return n
+}
+
+// Successor returns the list node following node n in ascending order of keys.
+// If there is none, i.e. n has a maximal key value, then nil is returned.
+func (l *SkipList) Successor(n *SLNode) *SLNode {
+	if len(n.next) == 0 {
+		// Node is not in the list
+		return nil
+	}
+
+	return n.next[0]
+}
+
+// Predecessor returns the list node following node n in descending order of keys.
+// If there is none, i.e. n has a minimum key value, then nil is returned.
+func (l *SkipList) Predecessor(n *SLNode) *SLNode {
+	return n.prev
+}
+
+// Range calls f for each node with key in the range [lo, hi] in ascending order
+// of keys until f returns false.
+func (l *SkipList) Range(lo, hi KeyType, f func(n *SLNode) bool) {
+	for n := l.lowerBound(lo); n != nil && n.key <= hi; n = n.next[0] {
+		if !f(n) {
+			return
+		}
+	}
+}
+
+// randomLevel returns the random number of levels for the new node
+func (l *SkipList) randomLevel() int {
+	lvl := 1
+	for lvl < l.maxLevel && l.rnd.Float64() < l.p {
+		lvl++
+	}
+
+	return lvl
+}
+
+// findUpdates returns the list of nodes on each level after which the node with key k should be placed
+func (l *SkipList) findUpdates(k KeyType) []*SLNode {
+	update := make([]*SLNode, l.maxLevel)
+
+	n := &l.head
+	for lvl := l.maxLevel - 1; lvl >= 0; lvl-- {
+		for n.next[lvl] != nil && n.next[lvl].key < k {
+			n = n.next[lvl]
+		}
+		update[lvl] = n
+	}
+
+	return update
+}
+
+// Insert inserts node n into the list. It returns the inserted node or nil if
+// a node with the same key already exists, in this case the list is not modified.
+func (l *SkipList) Insert(n *SLNode) *SLNode {
+	update := l.findUpdates(n.key)
+	if next := update[0].next[0]; next != nil && next.key == n.key {
+		// Already exists
+		return nil
+	}
+
+	// Choose the number of levels of the new node
+	lvl := l.randomLevel()
+	if lvl > l.level {
+		l.level = lvl
+	}
+
+	// Link the node on each of its levels
+	n.next = make([]*SLNode, lvl)
+	for i := 0; i < lvl; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+
+	// Update backward pointers
+	if update[0] != &l.head {
+		n.prev = update[0]
+	}
+	if n.next[0] != nil {
+		n.next[0].prev = n
+	}
+
+	l.size++
+
+	return n
+}
+
+// Delete deletes the node n from the list. It returns n or nil if n is not in the list.
+func (l *SkipList) Delete(n *SLNode) *SLNode {
+	update := l.findUpdates(n.key)
+	if update[0].next[0] != n {
+		// Not in the list
+		return nil
+	}
+
+	// Unlink the node on each of its levels
+	for i := range n.next {
+		update[i].next[i] = n.next[i]
+	}
+
+	// Update backward pointer
+	if n.next[0] != nil {
+		n.next[0].prev = n.prev
+	}
+
+	// Decrease the number of levels in use if the highest levels are empty now
+	for l.level > 0 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+
+	// Clear pointers of the deleted node
+	n.next, n.prev = nil, nil
+
+	l.size--
+
+	return n
+}
@@ -0,0 +1,399 @@
+package skiplist
+
+import (
+	"fmt"
+	"testing"
+	"math/rand"
+	"sort"
+
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Number of keys gives lists of about 6 levels with the default probability
+	keysCount	=	4096
+	MaxItem		=	99999
+
+	// Seed of the random source of lists
+	listSeed	=	2022
+	// Seed of random sources of keys generated by tests
+	testSeed	=	2029
+	// Self-test walks all levels of the list, so it is run once per selfTestStep deletions
+	selfTestStep	=	64
+)
+
+func TestKeyType(t *testing.T) {
+	for i, test := range []struct {
+		kv		KeyType
+		want	string
+	} {
+		{ FakeNode,  strFakeNode},
+		{ -10, strInvalidNode },
+		{ 1234, "1234" },
+	} {
+		if v := test.kv.String(); v != test.want {
+			t.Errorf("[%d] KeyType.String() on %d, want - %q, got - %q", i, test.kv, test.want, v)
+		}
+	}
+}
+
+func TestInvalidParams(t *testing.T) {
+	for i, test := range []struct {
+		p			float64
+		maxLevel	int
+	} {
+		{ 0, DefaultMaxLevel },
+		{ 1, DefaultMaxLevel },
+		{ DefaultProbability, 0 },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("[%d] NewSkipListWith(%v, %d, ...) did not panic", i, test.p, test.maxLevel)
+				}
+			}()
+
+			NewSkipListWith(test.p, test.maxLevel, listSeed)
+		}()
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	list := NewSkipList()
+
+	if n := list.Min(); n != nil {
+		t.Errorf("Min returned non-nil value %v (%#v) on empty list", n, n)
+	}
+
+	if n := list.Max(); n != nil {
+		t.Errorf("Max returned non-nil value %v (%#v) on empty list", n, n)
+	}
+
+	if n := list.Search(1); n != nil {
+		t.Errorf("Search returned non-nil value %v (%#v) on empty list", n, n)
+	}
+
+	if s := list.String(); s != strEmptyList {
+		t.Errorf("String returned %q on empty list, want - %q", s, strEmptyList)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	list := NewSkipListWith(DefaultProbability, DefaultMaxLevel, listSeed)
+
+	for i, v := range testKeys {
+		n := NewSLNode(v, nil)
+
+		ins := list.Insert(n)
+		if ins != n {
+			t.Errorf("[%d] SkipList.Insert returned %p (%v), want - %p (inserted node: %v)", i, ins, ins, n, n)
+			t.FailNow()
+		}
+	}
+
+	if list.Len() != len(testKeys) {
+		t.Errorf("SkipList.Len returned %d, want - %d", list.Len(), len(testKeys))
+	}
+
+	if _, err := list.SelfTest(); err != nil {
+		t.Errorf("skip list structure issue: %v", err)
+	}
+}
+
+func TestInsertDupes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	list, _ := newListSortedKeys(testKeys)
+
+	for i, v := range testKeys {
+		if ins := list.Insert(NewSLNode(v, nil)); ins != nil {
+			t.Errorf("[%d] SkipList.Insert returned %v, want - nil," +
+				" because node with key %v should be already inserted", i, ins, v)
+			t.FailNow()
+		}
+	}
+
+	if _, err := list.SelfTest(); err != nil {
+		t.Errorf("skip list structure issue: %v", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	list, _ := newListSortedKeys(testKeys)
+
+	for _, k := range testKeys {
+		n := list.Search(k)
+		if n == nil || n.Key() != k {
+			t.Errorf("key %v was added but not found in the list", k)
+			t.FailNow()
+		}
+	}
+
+	if n := list.Search(MaxItem + 1); n != nil {
+		t.Errorf("Search returned %v for the key that was not added", n)
+	}
+}
+
+func TestSuccessor(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	list, sKeys := newListSortedKeys(testKeys)
+
+	i := 0
+	for s := list.Min(); s != nil; s, i = list.Successor(s), i+1 {
+		if i == len(sKeys) || s.Key() != sKeys[i] {
+			t.Errorf("[%d] successor has key %v, but only %d keys are available", i, s.Key(), len(sKeys))
+			t.FailNow()
+		}
+	}
+
+	if i != len(sKeys) {
+		t.Errorf("number of tested successor is %d, want - %d", i, len(sKeys))
+	}
+}
+
+func TestPredecessor(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	list, sKeys := newListSortedKeys(testKeys)
+
+	i := len(sKeys) - 1
+	for p := list.Max(); p != nil; p, i = list.Predecessor(p), i-1 {
+		if i < 0 || p.Key() != sKeys[i] {
+			t.Errorf("[%d] predecessor has key %v, but only %d keys are available", i, p.Key(), len(sKeys))
+			t.FailNow()
+		}
+	}
+
+	if i != -1 {
+		t.Errorf("number of tested predecessor is %d, want - %d", len(sKeys)-1-i, len(sKeys))
+	}
+}
+
+func TestRange(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	list, sKeys := newListSortedKeys(testKeys)
+
+	for _, test := range []struct {
+		lo, hi	KeyType
+	} {
+		{ 0, MaxItem },
+		{ sKeys[10], sKeys[200] },
+		{ sKeys[10] + 1, sKeys[200] - 1 },
+		{ sKeys[500], sKeys[500] },
+		{ 200, 100 },
+	} {
+		var want, got []KeyType
+		for _, k := range sKeys {
+			if k >= test.lo && k <= test.hi {
+				want = append(want, k)
+			}
+		}
+
+		list.Range(test.lo, test.hi, func(n *SLNode) bool {
+			got = append(got, n.Key())
+			return true
+		})
+
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("Range(%v, %v) returned %d keys, want - %d", test.lo, test.hi, len(got), len(want))
+		}
+	}
+
+	// Stop iteration
+	count := 0
+	list.Range(0, MaxItem, func(n *SLNode) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 {
+		t.Errorf("Range was not stopped after 10 nodes, %d nodes were walked", count)
+	}
+}
+
+func TestDelMinMax(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	list, sKeys := newListSortedKeys(testKeys)
+
+	for i := 0; list.Len() != 0; i++ {
+		// Delete minimum and maximum alternately
+		var n *SLNode
+		var want KeyType
+		if i % 2 == 0 {
+			n = list.Min()
+			want, sKeys = sKeys[0], sKeys[1:]
+		} else {
+			n = list.Max()
+			want, sKeys = sKeys[len(sKeys)-1], sKeys[:len(sKeys)-1]
+		}
+
+		if n.Key() != want {
+			t.Errorf("[%d] Min/Max returned %v, want - %v", i, n, want)
+			t.FailNow()
+		}
+
+		if del := list.Delete(n); del != n {
+			t.Errorf("[%d] Delete returned %v, want - %v", i, del, n)
+			t.FailNow()
+		}
+	}
+
+	if lvl, err := list.SelfTest(); err != nil || lvl != 0 {
+		t.Errorf("SelfTest on empty list returned %d, %v, want - 0, nil", lvl, err)
+	}
+}
+
+func TestDelRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	list, sKeys := newListSortedKeys(testKeys)
+
+	for i := 0; len(sKeys) != 0; i++ {
+		// Get the random element from the sKeys
+		idx := rnd.Int() % len(sKeys)
+		k := sKeys[idx]
+		// Remove k from keys slice
+		sKeys = append(sKeys[:idx], sKeys[idx+1:]...)
+
+		n := list.Search(k)
+		if n == nil {
+			t.Errorf("the key %v was not found in the list, but must", k)
+			t.FailNow()
+		}
+
+		if del := list.Delete(n); del != n {
+			t.Errorf("[%d] Delete returned %v, want - %v", i, del, n)
+			t.FailNow()
+		}
+
+		// Repeated deletion
+		if del := list.Delete(n); del != nil {
+			t.Errorf("[%d] repeated Delete returned %v, want - nil", i, del)
+			t.FailNow()
+		}
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+
+		if _, err := list.SelfTest(); err != nil {
+			t.Errorf("[%d] skip list structure issue after deletion of %v: %v", i, k, err)
+			t.FailNow()
+		}
+	}
+
+	if list.Len() != 0 || list.Min() != nil {
+		t.Errorf("list must be empty, but its length is %d", list.Len())
+	}
+}
+
+func TestSeed(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, 100, KeyType(MaxItem))
+
+	// Lists with the same seed must have the same structure
+	l1, _ := newListSortedKeys(testKeys)
+	l2, _ := newListSortedKeys(testKeys)
+
+	if l1.String() != l2.String() {
+		t.Errorf("lists created with the same seed have different structure:\n%v\n%v", l1, l2)
+	}
+
+	// Levels of nodes must not exceed the maximal level
+	const maxLevel = 3
+	list := NewSkipListWith(0.9, maxLevel, listSeed)
+	for _, k := range testKeys {
+		list.Insert(NewSLNode(k, nil))
+	}
+
+	if lvl, err := list.SelfTest(); err != nil || lvl != maxLevel {
+		t.Errorf("SelfTest returned %d, %v, want - %d, nil", lvl, err, maxLevel)
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, 100, KeyType(MaxItem))
+
+	for i, breaker := range []func(l *SkipList) {
+		// Wrong size
+		func(l *SkipList) { l.size++ },
+		// Wrong order of keys
+		func(l *SkipList) { l.Min().key = l.Max().key + 1 },
+		// Broken backward pointer
+		func(l *SkipList) { l.Max().prev = nil },
+		// Node is skipped on the higher level
+		func(l *SkipList) {
+			for n := l.Min(); n != nil; n = n.next[0] {
+				if len(n.next) > 1 {
+					l.head.next[1] = n.next[1]
+					return
+				}
+			}
+			panic("No nodes with several levels were found")
+		},
+	} {
+		list, _ := newListSortedKeys(testKeys)
+
+		// Apply test to list
+		breaker(list)
+
+		lvl, err := list.SelfTest()
+		switch {
+		case err == nil:
+			t.Errorf("[%d] self-test does not return expected issue", i)
+		case lvl != 0:
+			t.Errorf("returned level of the invalid list is not zero - %d", lvl)
+		default:
+			t.Log("Expected self-test error:", err)
+		}
+	}
+}
+
+func TestStringFilled(t *testing.T) {
+	want :=
+`HEAD -------------> 15 -------------------------> NIL
+HEAD -> 5  -------> 15 -------------------------> NIL
+HEAD -> 5  -------> 15 -------> 25 -------------> NIL
+HEAD -> 5  -> 10 -> 15 -> 20 -> 25 -> 30 -> 35 -> NIL
+`
+
+	list := NewSkipListWith(0.5, 4, 1)
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35} {
+		list.Insert(NewSLNode(k, nil))
+	}
+
+	if lStr := list.String(); lStr != want {
+		t.Errorf("SkipList.String() returned:\n---\n%s\n---\nWant:\n---\n%s\n---\n", lStr, want)
+	}
+}
+
+func newListSortedKeys(keys []KeyType) (*SkipList, []KeyType) {
+	list := NewSkipListWith(DefaultProbability, DefaultMaxLevel, listSeed)
+
+	// Insert all keys
+	for _, v := range keys {
+		list.Insert(NewSLNode(v, nil))
+	}
+
+	// Make sorted copy of keys
+	sKeys := make([]KeyType, len(keys))
+	copy(sKeys, keys)
+	sort.Slice(sKeys, func(i, j int) bool { return sKeys[i] < sKeys[j] } )
+
+	return list, sKeys
+}
@@ -0,0 +1,76 @@
+package skiplist
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// Empty list stub
+	strEmptyList	= `<list-is-empty>`
+
+	strHead		=	"HEAD"
+	strNil		=	"NIL"
+	strArrow	=	"-> "
+)
+
+func (l *SkipList) String() string {
+	if l.size == 0 {
+		return strEmptyList
+	}
+
+	// Maximal key width
+	kw := 0
+	for n := l.head.next[0]; n != nil; n = n.next[0] {
+		if w := utf8.RuneCountInString(n.key.String()); w > kw {
+			kw = w
+		}
+	}
+
+	// Node output format
+	nFmt := fmt.Sprintf("%%-%ds", kw)
+	// Cell width - connector to the node + key
+	cellWidth := len(" ") + len(strArrow) + kw
+	// Cell of the level that does not contain the node
+	skipCell := strings.Repeat("-", cellWidth)
+
+	out := strings.Builder{}
+
+	// Draw levels from the highest to the lowest, so towers of nodes go up
+	for lvl := l.level - 1; lvl >= 0; lvl-- {
+		out.WriteString(strHead)
+
+		// Whether the previous cell contains the node
+		prevNode := true
+		for n := l.head.next[0]; n != nil; n = n.next[0] {
+			if len(n.next) <= lvl {
+				// The node is skipped on this level, separate the line from the previous key
+				if prevNode {
+					out.WriteString(" " + skipCell[1:])
+				} else {
+					out.WriteString(skipCell)
+				}
+				prevNode = false
+				continue
+			}
+
+			out.WriteString(stringConnector(prevNode) + fmt.Sprintf(nFmt, n.key))
+			prevNode = true
+		}
+
+		out.WriteString(stringConnector(prevNode) + strNil + "\n")
+	}
+
+	return out.String()
+}
+
+// stringConnector returns the arrow to the node, it continues the line of
+// dashes if the previous cell does not contain the node
+func stringConnector(prevNode bool) string {
+	if prevNode {
+		return " " + strArrow
+	}
+
+	return "-" + strArrow
+}
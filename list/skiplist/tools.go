@@ -0,0 +1,66 @@
+package skiplist
+
+import "fmt"
+
+// SelfTest performs a self-test of the skip list and returns the number of levels
+// in use, and a description of the problem if detected. If an issue is detected,
+// the number of levels is zero.
+func (l *SkipList) SelfTest() (int, error) {
+	if l.level > l.maxLevel {
+		return 0, fmt.Errorf("v#1: number of levels in use %d exceeds the maximum %d", l.level, l.maxLevel)
+	}
+
+	// Check the lowest level, that contains all nodes
+	count := 0
+	var prev *SLNode
+	for n := l.head.next[0]; n != nil; prev, n = n, n.next[0] {
+		if prev != nil && prev.key >= n.key {
+			return 0, fmt.Errorf("v#2: node %v follows node %v, keys are not in ascending order", n, prev)
+		}
+
+		if n.prev != prev {
+			return 0, fmt.Errorf("v#3: node %v has backward pointer to %v, want - %v", n, n.prev, prev)
+		}
+
+		if len(n.next) < 1 || len(n.next) > l.level {
+			return 0, fmt.Errorf("v#4: node %v has %d levels, must be 1..%d", n, len(n.next), l.level)
+		}
+
+		count++
+	}
+
+	if count != l.size {
+		return 0, fmt.Errorf("v#5: list contains %d nodes, but its size is %d", count, l.size)
+	}
+
+	// Check the higher levels, each level must contain exactly the nodes of
+	// the lower level that have enough levels, in the same order
+	for lvl := 1; lvl < l.maxLevel; lvl++ {
+		n := l.head.next[lvl]
+		for low := l.head.next[0]; low != nil; low = low.next[0] {
+			if len(low.next) <= lvl {
+				continue
+			}
+
+			if n != low {
+				return 0, fmt.Errorf("v#6: level %d contains node %v, want - %v", lvl+1, n, low)
+			}
+			n = n.next[lvl]
+		}
+
+		if n != nil {
+			return 0, fmt.Errorf("v#6: level %d contains unexpected node %v", lvl+1, n)
+		}
+
+		if lvl >= l.level && l.head.next[lvl] != nil {
+			return 0, fmt.Errorf("v#7: unused level %d is not empty", lvl+1)
+		}
+	}
+
+	if l.level > 0 && l.head.next[l.level-1] == nil {
+		return 0, fmt.Errorf("v#7: the highest level in use %d is empty", l.level)
+	}
+
+	// OK
+	return l.level, nil
+}
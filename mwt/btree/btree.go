@@ -0,0 +1,313 @@
+/*
+Package btree provides an example of a B-tree and B+tree implementation.
+
+Unlike binary search trees, each node of a B-tree holds up to 2*t-1 items
+stored contiguously in memory, where t is the minimum degree of the tree. It
+makes the tree shallow and reduces the number of pointers to follow during a
+search, which is friendly to the CPU cache.
+
+It supports standard tree procedures, such as: inserting and deleting items,
+finding items by given arbitrary key, finding maximum and minimum items,
+finding the floor and the ceiling of a key and iterating over ranges of keys.
+
+In the B+tree mode all items are stored in the leaves, internal nodes contain
+only separator keys and leaves are linked to each other, so range scans are
+performed by walking the list of leaves.
+
+It supports output of graphical representation of the tree using ASCII
+graphics. For example, a B-tree with minimum degree 2 and the keys 20, 10, 30,
+5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27 added sequentially will look like
+this:
+
+	                        [30]
+	               _______________________
+	              /                       \
+	           [10 20]                  [35]
+	    ______________________          _____
+	   /          |           \        /     \
+	[2 5 8]  [13 15 17]  [23 25 27]  [33]  [37]
+*/
+package btree
+
+import "fmt"
+
+// MinDegree is the minimal allowed degree of the tree
+const MinDegree = 2
+
+// BTree implements a B-tree or a B+tree.
+type BTree struct {
+	root	*node
+
+	// Minimum degree - each node except the root contains degree-1..2*degree-1 items
+	degree	int
+	// B+tree mode
+	plus	bool
+	// Number of items in the tree
+	size	int
+}
+
+// NewBTree returns new empty B-tree with minimum degree t. It panics if t is less than MinDegree.
+func NewBTree(t int) *BTree {
+	return newTree(t, false)
+}
+
+// NewBPlusTree returns new empty B+tree with minimum degree t. It panics if t is less than MinDegree.
+func NewBPlusTree(t int) *BTree {
+	return newTree(t, true)
+}
+
+func newTree(t int, plus bool) *BTree {
+	if t < MinDegree {
+		panic(fmt.Sprintf("Invalid B-tree degree %d, must be at least %d", t, MinDegree))
+	}
+
+	return &BTree{degree: t, plus: plus}
+}
+
+// Degree returns the minimum degree of the tree.
+func (t *BTree) Degree() int {
+	return t.degree
+}
+
+// Plus returns true if the tree works in B+tree mode.
+func (t *BTree) Plus() bool {
+	return t.plus
+}
+
+// Len returns the number of items in the tree.
+func (t *BTree) Len() int {
+	return t.size
+}
+
+// maxItems returns the maximal number of items in the node
+func (t *BTree) maxItems() int {
+	return 2*t.degree - 1
+}
+
+// minItems returns the minimal number of items in the non-root node
+func (t *BTree) minItems() int {
+	return t.degree - 1
+}
+
+// Search returns the item with key k and true, or an empty item and false if there is no such item.
+func (t *BTree) Search(k KeyType) (Item, bool) {
+	if t.root == nil {
+		return Item{}, false
+	}
+
+	if t.plus {
+		// All items are stored in leaves
+		n := t.findLeaf(k)
+		if i := n.lowerBound(k); i < len(n.items) && n.items[i].key == k {
+			return n.items[i], true
+		}
+
+		return Item{}, false
+	}
+
+	for n := t.root; n != nil; {
+		i := n.lowerBound(k)
+		if i < len(n.items) && n.items[i].key == k {
+			return n.items[i], true
+		}
+
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+
+	return Item{}, false
+}
+
+// findLeaf returns the B+tree leaf that contains or should contain key k
+func (t *BTree) findLeaf(k KeyType) *node {
+	n := t.root
+	for !n.leaf() {
+		// Keys equal to separator are stored in the right subtree
+		n = n.children[n.upperBound(k)]
+	}
+
+	return n
+}
+
+// Min returns the item with the minimum key and true, or an empty item and false if the tree is empty.
+func (t *BTree) Min() (Item, bool) {
+	if t.root == nil {
+		return Item{}, false
+	}
+
+	return t.root.first().items[0], true
+}
+
+// Max returns the item with the maximum key and true, or an empty item and false if the tree is empty.
+func (t *BTree) Max() (Item, bool) {
+	if t.root == nil {
+		return Item{}, false
+	}
+
+	n := t.root.last()
+	return n.items[len(n.items)-1], true
+}
+
+// Floor returns the item with the greatest key less than or equal to k and true,
+// or an empty item and false if there is no such item.
+func (t *BTree) Floor(k KeyType) (Item, bool) {
+	if t.root == nil {
+		return Item{}, false
+	}
+
+	if t.plus {
+		n := t.findLeaf(k)
+		if i := n.upperBound(k); i > 0 {
+			return n.items[i-1], true
+		}
+
+		// All keys of the leaf are greater than k - floor is the last item of the previous leaf
+		if n.prev != nil {
+			return n.prev.items[len(n.prev.items)-1], true
+		}
+
+		return Item{}, false
+	}
+
+	// Each next candidate found deeper is greater than the previous one
+	var found bool
+	var floor Item
+	for n := t.root; n != nil; {
+		i := n.upperBound(k)
+		if i > 0 {
+			floor, found = n.items[i-1], true
+			if floor.key == k {
+				// Exact match
+				break
+			}
+		}
+
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+
+	return floor, found
+}
+
+// Ceiling returns the item with the least key greater than or equal to k and true,
+// or an empty item and false if there is no such item.
+func (t *BTree) Ceiling(k KeyType) (Item, bool) {
+	if t.root == nil {
+		return Item{}, false
+	}
+
+	if t.plus {
+		n := t.findLeaf(k)
+		if i := n.lowerBound(k); i < len(n.items) {
+			return n.items[i], true
+		}
+
+		// All keys of the leaf are less than k - ceiling is the first item of the next leaf
+		if n.next != nil {
+			return n.next.items[0], true
+		}
+
+		return Item{}, false
+	}
+
+	// Each next candidate found deeper is less than the previous one
+	var found bool
+	var ceil Item
+	for n := t.root; n != nil; {
+		i := n.lowerBound(k)
+		if i < len(n.items) {
+			ceil, found = n.items[i], true
+			if ceil.key == k {
+				// Exact match
+				break
+			}
+		}
+
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+
+	return ceil, found
+}
+
+// Ascend calls f for each item of the tree in ascending order of keys until f returns false.
+func (t *BTree) Ascend(f func(Item) bool) {
+	if t.root == nil {
+		return
+	}
+
+	if t.plus {
+		t.scanLeaves(t.root.first(), 0, nil, f)
+		return
+	}
+
+	t.root.ascend(nil, nil, f)
+}
+
+// Range calls f for each item with key in the range [lo, hi] in ascending order
+// of keys until f returns false.
+func (t *BTree) Range(lo, hi KeyType, f func(Item) bool) {
+	if t.root == nil || lo > hi {
+		return
+	}
+
+	if t.plus {
+		n := t.findLeaf(lo)
+		t.scanLeaves(n, n.lowerBound(lo), &hi, f)
+		return
+	}
+
+	t.root.ascend(&lo, &hi, f)
+}
+
+// scanLeaves walks the list of B+tree leaves starting from the item i of leaf n
+// and calls f for each item until the key exceeds hi (if not nil) or f returns false
+func (t *BTree) scanLeaves(n *node, i int, hi *KeyType, f func(Item) bool) {
+	for ; n != nil; n, i = n.next, 0 {
+		for ; i < len(n.items); i++ {
+			if hi != nil && n.items[i].key > *hi {
+				return
+			}
+			if !f(n.items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ascend does in-order walk of the B-tree subtree with root n, lo and hi limit the keys if not nil.
+// It returns false if the walk was stopped by f.
+func (n *node) ascend(lo, hi *KeyType, f func(Item) bool) bool {
+	// Skip items less than lo
+	i := 0
+	if lo != nil {
+		i = n.lowerBound(*lo)
+	}
+
+	for ; i < len(n.items); i++ {
+		if !n.leaf() && !n.children[i].ascend(lo, hi, f) {
+			return false
+		}
+
+		if hi != nil && n.items[i].key > *hi {
+			// All next items are out of range
+			return false
+		}
+
+		if !f(n.items[i]) {
+			return false
+		}
+	}
+
+	if !n.leaf() {
+		return n.children[i].ascend(lo, hi, f)
+	}
+
+	return true
+}
@@ -0,0 +1,431 @@
+package btree
+
+import (
+	"fmt"
+	"testing"
+	"math/rand"
+	"sort"
+
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Number of keys makes trees of the maximal tested degree 3 levels high
+	keysCount	=	4096
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2026
+
+	// Self-test walks the whole tree, so it is run once per selfTestStep deletions
+	selfTestStep	=	64
+)
+
+// testTrees returns constructors of trees of different modes and degrees
+func testTrees() map[string]func() *BTree {
+	trees := map[string]func() *BTree{}
+	for _, degree := range []int{MinDegree, 3, 16} {
+		degree := degree
+		trees[fmt.Sprintf("B-tree(%d)", degree)] = func() *BTree { return NewBTree(degree) }
+		trees[fmt.Sprintf("B+tree(%d)", degree)] = func() *BTree { return NewBPlusTree(degree) }
+	}
+
+	return trees
+}
+
+func TestKeyType(t *testing.T) {
+	for i, test := range []struct {
+		kv		KeyType
+		want	string
+	} {
+		{ FakeKey,  strFakeKey},
+		{ -10, strInvalidKey },
+		{ 1234, "1234" },
+	} {
+		if v := test.kv.String(); v != test.want {
+			t.Errorf("[%d] KeyType.String() on %d, want - %q, got - %q", i, test.kv, test.want, v)
+		}
+	}
+}
+
+func TestInvalidDegree(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewBTree(%d) did not panic", MinDegree-1)
+		}
+	}()
+
+	NewBTree(MinDegree - 1)
+}
+
+func TestEmpty(t *testing.T) {
+	for name, newTree := range testTrees() {
+		tree := newTree()
+
+		if it, ok := tree.Min(); ok {
+			t.Errorf("[%s] Min returned %v on empty tree", name, it)
+		}
+
+		if it, ok := tree.Max(); ok {
+			t.Errorf("[%s] Max returned %v on empty tree", name, it)
+		}
+
+		if it, ok := tree.Search(1); ok {
+			t.Errorf("[%s] Search returned %v on empty tree", name, it)
+		}
+
+		if it, ok := tree.Delete(1); ok {
+			t.Errorf("[%s] Delete returned %v on empty tree", name, it)
+		}
+
+		if h, err := tree.SelfTest(); h != 0 || err != nil {
+			t.Errorf("[%s] SelfTest returned %d, %v on empty tree, want - 0, nil", name, h, err)
+		}
+	}
+}
+
+func TestInsert(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	for name, newTree := range testTrees() {
+		tree := newTree()
+
+		for i, k := range testKeys {
+			if !tree.Insert(k, k*2) {
+				t.Errorf("[%s:%d] BTree.Insert returned false on unique key %v", name, i, k)
+				t.FailNow()
+			}
+		}
+
+		if tree.Len() != len(testKeys) {
+			t.Errorf("[%s] BTree.Len returned %d, want - %d", name, tree.Len(), len(testKeys))
+		}
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%s] B-tree structure issue: %v", name, err)
+		}
+	}
+}
+
+func TestInsertDupes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	for name, newTree := range testTrees() {
+		tree, _ := newTreeSortedKeys(newTree, testKeys)
+
+		for i, k := range testKeys {
+			if tree.Insert(k, nil) {
+				t.Errorf("[%s:%d] BTree.Insert returned true, want - false," +
+					" because item with key %v should be already inserted", name, i, k)
+				t.FailNow()
+			}
+		}
+
+		if tree.Len() != len(testKeys) {
+			t.Errorf("[%s] BTree.Len returned %d, want - %d", name, tree.Len(), len(testKeys))
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	for name, newTree := range testTrees() {
+		tree, _ := newTreeSortedKeys(newTree, testKeys)
+
+		for _, k := range testKeys {
+			it, ok := tree.Search(k)
+			if !ok {
+				t.Errorf("[%s] key %v was added but not found in the tree", name, k)
+				t.FailNow()
+			}
+
+			if it.Key() != k || it.Value() != k*2 {
+				t.Errorf("[%s] Search(%v) returned item %v with value %v, want - %v", name, k, it, it.Value(), k*2)
+				t.FailNow()
+			}
+		}
+
+		// Search non-existing keys
+		for _, k := range []KeyType{MaxItem + 1, MaxItem * 2} {
+			if it, ok := tree.Search(k); ok {
+				t.Errorf("[%s] Search(%v) returned %v, but the key was not inserted", name, k, it)
+			}
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	for name, newTree := range testTrees() {
+		tree, sKeys := newTreeSortedKeys(newTree, testKeys)
+
+		if it, _ := tree.Min(); it.Key() != sKeys[0] {
+			t.Errorf("[%s] Min returned %v, want - %v", name, it, sKeys[0])
+		}
+
+		if it, _ := tree.Max(); it.Key() != sKeys[len(sKeys)-1] {
+			t.Errorf("[%s] Max returned %v, want - %v", name, it, sKeys[len(sKeys)-1])
+		}
+	}
+}
+
+func TestFloorCeiling(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	for name, newTree := range testTrees() {
+		tree, sKeys := newTreeSortedKeys(newTree, testKeys)
+
+		for k := KeyType(0); k <= MaxItem + 1; k += 7 {
+			// Index of the first key greater or equal to k
+			i := sort.Search(len(sKeys), func(i int) bool { return sKeys[i] >= k })
+
+			// Expected ceiling
+			it, ok := tree.Ceiling(k)
+			switch {
+			case i == len(sKeys) && ok:
+				t.Errorf("[%s] Ceiling(%v) returned %v, want - nothing", name, k, it)
+			case i < len(sKeys) && (!ok || it.Key() != sKeys[i]):
+				t.Errorf("[%s] Ceiling(%v) returned %v (%t), want - %v", name, k, it, ok, sKeys[i])
+			}
+
+			// Expected floor
+			if i == len(sKeys) || sKeys[i] != k {
+				i--
+			}
+			it, ok = tree.Floor(k)
+			switch {
+			case i < 0 && ok:
+				t.Errorf("[%s] Floor(%v) returned %v, want - nothing", name, k, it)
+			case i >= 0 && (!ok || it.Key() != sKeys[i]):
+				t.Errorf("[%s] Floor(%v) returned %v (%t), want - %v", name, k, it, ok, sKeys[i])
+			}
+		}
+	}
+}
+
+func TestAscend(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	for name, newTree := range testTrees() {
+		tree, sKeys := newTreeSortedKeys(newTree, testKeys)
+
+		i := 0
+		tree.Ascend(func(it Item) bool {
+			if i == len(sKeys) || it.Key() != sKeys[i] {
+				t.Errorf("[%s:%d] Ascend returned key %v, want - %v", name, i, it, sKeys[i])
+				return false
+			}
+			i++
+			return true
+		})
+
+		if i != len(sKeys) {
+			t.Errorf("[%s] number of walked items is %d, want - %d", name, i, len(sKeys))
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	for name, newTree := range testTrees() {
+		tree, sKeys := newTreeSortedKeys(newTree, testKeys)
+
+		for _, test := range []struct {
+			lo, hi	KeyType
+			limit	int
+		} {
+			{ 0, MaxItem, -1 },
+			{ sKeys[10], sKeys[200], -1 },
+			{ sKeys[10] + 1, sKeys[200] - 1, -1 },
+			{ sKeys[500], sKeys[500], -1 },
+			{ sKeys[100], sKeys[1000], 10 },
+			{ 200, 100, -1 },
+			{ MaxItem + 1, MaxItem + 100, -1 },
+		} {
+			// Expected keys
+			var want []KeyType
+			for _, k := range sKeys {
+				if k >= test.lo && k <= test.hi && (test.limit < 0 || len(want) < test.limit) {
+					want = append(want, k)
+				}
+			}
+
+			var got []KeyType
+			tree.Range(test.lo, test.hi, func(it Item) bool {
+				got = append(got, it.Key())
+				return test.limit < 0 || len(got) < test.limit
+			})
+
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("[%s] Range(%v, %v) limited by %d returned %d keys %v, want - %d keys %v",
+					name, test.lo, test.hi, test.limit, len(got), got, len(want), want)
+			}
+		}
+	}
+}
+
+func TestDelRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	for name, newTree := range testTrees() {
+		tree, sKeys := newTreeSortedKeys(newTree, testKeys)
+
+		for i := 0; len(sKeys) != 0; i++ {
+			// Get the random element from the sKeys
+			idx := rnd.Int() % len(sKeys)
+			k := sKeys[idx]
+			// Remove k from keys slice
+			sKeys = append(sKeys[:idx], sKeys[idx+1:]...)
+
+			it, ok := tree.Delete(k)
+			if !ok || it.Key() != k {
+				t.Errorf("[%s:%d] Delete(%v) returned %v (%t), want - %v", name, i, k, it, ok, k)
+				t.FailNow()
+			}
+
+			// Repeated deletion should fail
+			if it, ok := tree.Delete(k); ok {
+				t.Errorf("[%s:%d] repeated Delete(%v) returned %v", name, i, k, it)
+				t.FailNow()
+			}
+
+			if i % selfTestStep != 0 && len(sKeys) > selfTestStep {
+				continue
+			}
+
+			if _, err := tree.SelfTest(); err != nil {
+				t.Errorf("[%s:%d] B-tree structure issue after deletion of %v: %v", name, i, k, err)
+				t.FailNow()
+			}
+		}
+
+		if tree.Len() != 0 {
+			t.Errorf("[%s] tree must be empty, but its length is %d", name, tree.Len())
+		}
+	}
+}
+
+func TestDelMinMax(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	for name, newTree := range testTrees() {
+		tree, sKeys := newTreeSortedKeys(newTree, testKeys)
+
+		for i := 0; len(sKeys) != 0; i++ {
+			// Delete minimum and maximum alternately
+			var want KeyType
+			var it Item
+			if i % 2 == 0 {
+				it, _ = tree.Min()
+				want, sKeys = sKeys[0], sKeys[1:]
+			} else {
+				it, _ = tree.Max()
+				want, sKeys = sKeys[len(sKeys)-1], sKeys[:len(sKeys)-1]
+			}
+
+			if it.Key() != want {
+				t.Errorf("[%s:%d] Min/Max returned %v, want - %v", name, i, it, want)
+				t.FailNow()
+			}
+
+			if _, ok := tree.Delete(want); !ok {
+				t.Errorf("[%s:%d] Delete(%v) returned false", name, i, want)
+				t.FailNow()
+			}
+		}
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Errorf("[%s] B-tree structure issue: %v", name, err)
+		}
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, 100, KeyType(MaxItem))
+
+	for i, test := range treeBreakers() {
+		tree, _ := newTreeSortedKeys(test.newTree, testKeys)
+
+		// Apply test to tree
+		test.breaker(tree)
+
+		// Run self-testing
+		h, err := tree.SelfTest()
+
+		// Check for tree structure problem
+		switch {
+		// Check for no errors
+		case err == nil:
+			t.Errorf("[%d] self-test does not return expected issue", i)
+
+		// Check for non-zero height
+		case h != 0:
+			t.Errorf("returned height of the invalid tree is not zero - %d", h)
+
+		// Ok, just print error for information
+		default:
+			t.Log("Expected self-test error:", err)
+		}
+	}
+}
+
+func treeBreakers() []struct{newTree func() *BTree; breaker func(t *BTree)} {
+	newBTree := func() *BTree { return NewBTree(MinDegree) }
+	newBPlusTree := func() *BTree { return NewBPlusTree(MinDegree) }
+
+	return []struct{newTree func() *BTree; breaker func(t *BTree)} {
+		// Wrong size
+		{ newBTree, func(t *BTree) { t.size++ } },
+		// Wrong order of keys
+		{ newBTree, func(t *BTree) {
+			n := t.root.first()
+			n.items[0], n.items[1] = n.items[1], n.items[0]
+		} },
+		// Key out of bounds
+		{ newBTree, func(t *BTree) {
+			n := t.root.first()
+			n.items[len(n.items)-1].key = t.root.items[0].key + 1
+		} },
+		// Underflowed node
+		{ newBTree, func(t *BTree) { t.root.first().truncate(0) } },
+		// Different depth of leaves
+		{ newBTree, func(t *BTree) {
+			n := t.root.last()
+			n.children = []*node{newNode(t.degree), newNode(t.degree)}
+			n.truncate(1)
+			n.children[0].items = append(n.children[0].items, Item{key: n.items[0].key - 1})
+			n.children[1].items = append(n.children[1].items, Item{key: n.items[0].key + 1})
+			t.size += 2
+		} },
+		// Broken list of leaves
+		{ newBPlusTree, func(t *BTree) { t.root.first().next = nil } },
+	}
+}
+
+func newTreeSortedKeys(newTree func() *BTree, keys []KeyType) (*BTree, []KeyType) {
+	tree := newTree()
+
+	// Insert all keys with doubled keys as values
+	for _, k := range keys {
+		tree.Insert(k, k*2)
+	}
+
+	// Make sorted copy of keys
+	sKeys := make([]KeyType, len(keys))
+	copy(sKeys, keys)
+	sort.Slice(sKeys, func(i, j int) bool { return sKeys[i] < sKeys[j] } )
+
+	return tree, sKeys
+}
@@ -0,0 +1,183 @@
+package btree
+
+// Delete deletes the item with key k from the tree keeping the properties of the
+// B-tree. It returns the deleted item and true, or an empty item and false if
+// there is no such item.
+func (t *BTree) Delete(k KeyType) (Item, bool) {
+	if t.root == nil {
+		return Item{}, false
+	}
+
+	it, deleted := t.delete(t.root, k)
+	if !deleted {
+		return Item{}, false
+	}
+	t.size--
+
+	// Check for the root became empty
+	if len(t.root.items) == 0 {
+		if t.root.leaf() {
+			// Tree is empty now
+			t.root = nil
+		} else {
+			// Root has a single child, the tree shrinks
+			t.root = t.root.children[0]
+		}
+	}
+
+	return it, true
+}
+
+// delete deletes the item with key k from the subtree with root n. It does not
+// fix the underflow of n, it is the responsibility of the caller.
+func (t *BTree) delete(n *node, k KeyType) (Item, bool) {
+	if t.plus && !n.leaf() {
+		// Separators are not real items, continue searching in the subtree.
+		// Separator equal to k remains a valid bound after deletion, so keep it as is
+		i := n.upperBound(k)
+		it, deleted := t.delete(n.children[i], k)
+		if deleted {
+			t.fixChild(n, i)
+		}
+
+		return it, deleted
+	}
+
+	i := n.lowerBound(k)
+	found := i < len(n.items) && n.items[i].key == k
+
+	switch {
+	// Leaf contains k
+	case n.leaf() && found:
+		return n.removeItem(i), true
+
+	// Leaf does not contain k - nothing to delete
+	case n.leaf():
+		return Item{}, false
+
+	// Internal node contains k
+	case found:
+		it := n.items[i]
+		// Replace the item by its predecessor - the maximum of the left subtree
+		n.items[i] = t.deleteMax(n.children[i])
+		t.fixChild(n, i)
+
+		return it, true
+
+	// k can be only in the subtree
+	default:
+		it, deleted := t.delete(n.children[i], k)
+		if deleted {
+			t.fixChild(n, i)
+		}
+
+		return it, deleted
+	}
+}
+
+// deleteMax deletes the item with the maximum key from the B-tree subtree with root n and returns it
+func (t *BTree) deleteMax(n *node) Item {
+	if n.leaf() {
+		return n.removeItem(len(n.items) - 1)
+	}
+
+	i := len(n.children) - 1
+	it := t.deleteMax(n.children[i])
+	t.fixChild(n, i)
+
+	return it
+}
+
+// fixChild restores the minimal number of items in the child i of node n
+// by borrowing an item from a sibling or merging with it
+func (t *BTree) fixChild(n *node, i int) {
+	if len(n.children[i].items) >= t.minItems() {
+		// No underflow
+		return
+	}
+
+	switch {
+	// Left sibling can lend an item
+	case i > 0 && len(n.children[i-1].items) > t.minItems():
+		t.borrowLeft(n, i)
+
+	// Right sibling can lend an item
+	case i < len(n.children)-1 && len(n.children[i+1].items) > t.minItems():
+		t.borrowRight(n, i)
+
+	// Siblings have minimal number of items - merge with one of them
+	case i > 0:
+		t.merge(n, i-1)
+	default:
+		t.merge(n, i)
+	}
+}
+
+// borrowLeft moves the last item of the left sibling of the child i through the parent n to the child
+func (t *BTree) borrowLeft(n *node, i int) {
+	child, left := n.children[i], n.children[i-1]
+
+	if t.plus && child.leaf() {
+		// Move the item directly and update the separator
+		child.insertItem(0, left.removeItem(len(left.items)-1))
+		n.items[i-1] = Item{key: child.items[0].key}
+
+		return
+	}
+
+	// Rotate the item through the parent
+	child.insertItem(0, n.items[i-1])
+	n.items[i-1] = left.removeItem(len(left.items) - 1)
+
+	if !child.leaf() {
+		child.insertChild(0, left.removeChild(len(left.children)-1))
+	}
+}
+
+// borrowRight moves the first item of the right sibling of the child i through the parent n to the child
+func (t *BTree) borrowRight(n *node, i int) {
+	child, right := n.children[i], n.children[i+1]
+
+	if t.plus && child.leaf() {
+		// Move the item directly and update the separator
+		child.items = append(child.items, right.removeItem(0))
+		n.items[i] = Item{key: right.items[0].key}
+
+		return
+	}
+
+	// Rotate the item through the parent
+	child.items = append(child.items, n.items[i])
+	n.items[i] = right.removeItem(0)
+
+	if !child.leaf() {
+		child.children = append(child.children, right.removeChild(0))
+	}
+}
+
+// merge merges the children i and i+1 of node n into the child i
+func (t *BTree) merge(n *node, i int) {
+	left, right := n.children[i], n.children[i+1]
+
+	// Remove separator and pointer to the right node from the parent
+	sep := n.removeItem(i)
+	n.removeChild(i + 1)
+
+	if t.plus && left.leaf() {
+		// Separator is not a real item, just join items and exclude right from the list of leaves
+		left.items = append(left.items, right.items...)
+		left.next = right.next
+		if right.next != nil {
+			right.next.prev = left
+		}
+
+		return
+	}
+
+	// Separator goes down between items of merged nodes
+	left.items = append(left.items, sep)
+	left.items = append(left.items, right.items...)
+	if !left.leaf() {
+		left.children = append(left.children, right.children...)
+	}
+}
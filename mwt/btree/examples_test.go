@@ -0,0 +1,108 @@
+package btree
+
+import "fmt"
+
+//nolint:testableexamples
+func Example_treeCreation() {
+	// Create B-tree with minimum degree 2
+	tree := NewBTree(MinDegree)
+
+	// Insert keys and data
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	// Print graphical representation of the tree
+	fmt.Print(tree)
+}
+
+func Example_treeSearch() {
+	// Tree creation
+	tree := NewBTree(MinDegree)
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	// Set of keys for search
+	lookups := []KeyType{183, 30, 8, 92, 37, 99, 0, 15}
+
+	for _, k := range lookups {
+		if it, ok := tree.Search(k); !ok {
+			fmt.Println("Key not found:", k)
+		} else {
+			fmt.Println("Found key", k, "value:", it.Value())
+		}
+	}
+
+	// Output:
+	// Key not found: 183
+	// Found key 30 value: Value for key 30
+	// Found key 8 value: Value for key 8
+	// Key not found: 92
+	// Found key 37 value: Value for key 37
+	// Key not found: 99
+	// Key not found: 0
+	// Found key 15 value: Value for key 15
+}
+
+func Example_treeFloorCeiling() {
+	// Tree creation
+	tree := NewBTree(MinDegree)
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	for _, k := range []KeyType{1, 12, 25, 40} {
+		fmt.Print("Key: ", k)
+		if floor, ok := tree.Floor(k); ok {
+			fmt.Print(" floor: ", floor)
+		}
+		if ceil, ok := tree.Ceiling(k); ok {
+			fmt.Print(" ceiling: ", ceil)
+		}
+		fmt.Println()
+	}
+
+	// Output:
+	// Key: 1 ceiling: 2
+	// Key: 12 floor: 10 ceiling: 13
+	// Key: 25 floor: 25 ceiling: 25
+	// Key: 40 floor: 37
+}
+
+func Example_treeRangeScan() {
+	// Create B+tree with minimum degree 2
+	tree := NewBPlusTree(MinDegree)
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	// Walk through keys in the range [9, 30] using the list of leaves
+	tree.Range(9, 30, func(it Item) bool {
+		fmt.Print(it.Key(), " ")
+		return true
+	})
+
+	fmt.Println()
+
+	// Output:
+	// 10 13 15 17 20 23 25 27 30
+}
+
+//nolint:testableexamples
+func Example_treeDelete() {
+	// Tree creation
+	tree := NewBPlusTree(MinDegree)
+	keys := []KeyType{20, 10, 30, 5, 15, 25, 35}
+	for _, k := range keys {
+		tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	fmt.Println("Created tree:\n", tree)
+
+	for _, k := range keys {
+		fmt.Println("Delete key:", k)
+		tree.Delete(k)
+		fmt.Println(tree)
+	}
+}
@@ -0,0 +1,119 @@
+package btree
+
+// Insert inserts the item with key k and associated data into the tree keeping
+// the properties of the B-tree. It returns false if an item with key k already
+// exists in the tree, in this case the tree is not modified.
+func (t *BTree) Insert(k KeyType, data any) bool {
+	it := Item{key: k, data: data}
+
+	// Check for empty tree
+	if t.root == nil {
+		t.root = newNode(t.degree)
+		t.root.items = append(t.root.items, it)
+		t.size++
+
+		return true
+	}
+
+	inserted, median, right := t.insert(t.root, it)
+	if !inserted {
+		// Already exists
+		return false
+	}
+	t.size++
+
+	if right != nil {
+		// Root was split, the tree grows up
+		root := newNode(t.degree)
+		root.items = append(root.items, median)
+		root.children = make([]*node, 0, 2*t.degree+1)
+		root.children = append(root.children, t.root, right)
+		t.root = root
+	}
+
+	return true
+}
+
+// insert inserts item it into the subtree with root n. If the node n was split,
+// it returns the median item and the new right sibling of n that should be
+// inserted into the parent of n.
+func (t *BTree) insert(n *node, it Item) (bool, Item, *node) {
+	var i int
+	if t.plus && !n.leaf() {
+		// Keys equal to separator are stored in the right subtree
+		i = n.upperBound(it.key)
+	} else {
+		i = n.lowerBound(it.key)
+		if i < len(n.items) && n.items[i].key == it.key {
+			// Already exists
+			return false, Item{}, nil
+		}
+	}
+
+	if n.leaf() {
+		n.insertItem(i, it)
+	} else {
+		inserted, median, right := t.insert(n.children[i], it)
+		if !inserted {
+			return false, Item{}, nil
+		}
+
+		if right == nil {
+			// Child was not split, nothing to do
+			return true, Item{}, nil
+		}
+
+		// Attach the right part of the split child to n
+		n.insertItem(i, median)
+		n.insertChild(i+1, right)
+	}
+
+	if len(n.items) <= t.maxItems() {
+		// No overflow
+		return true, Item{}, nil
+	}
+
+	// Overflow, need to split the node
+	median, right := t.split(n)
+
+	return true, median, right
+}
+
+// split splits the overflowed node n into two nodes, n keeps the left half of
+// items. It returns the item that should be moved to the parent and the right node.
+func (t *BTree) split(n *node) (Item, *node) {
+	// Overflowed node contains 2*degree items
+	m := t.degree
+
+	right := newNode(t.degree)
+
+	if t.plus && n.leaf() {
+		// B+tree leaf keeps all items, copy of the first key of the
+		// right node is used as separator in the parent
+		right.items = append(right.items, n.items[m:]...)
+		n.truncate(m)
+
+		// Insert the right node to the list of leaves
+		right.next = n.next
+		if n.next != nil {
+			n.next.prev = right
+		}
+		right.prev = n
+		n.next = right
+
+		return Item{key: right.items[0].key}, right
+	}
+
+	// Median item moves to the parent
+	median := n.items[m]
+	right.items = append(right.items, n.items[m+1:]...)
+
+	if !n.leaf() {
+		right.children = make([]*node, 0, 2*t.degree+1)
+		right.children = append(right.children, n.children[m+1:]...)
+	}
+
+	n.truncate(m)
+
+	return median, right
+}
@@ -0,0 +1,166 @@
+package btree
+
+import "fmt"
+
+// KeyType represents the key type of a B-tree item
+type KeyType int
+
+const (
+	FakeKey = KeyType(-1)
+
+	strFakeKey		=	`<>`
+	strInvalidKey	=	`<invalid>`
+)
+
+func (k KeyType) String() string {
+	switch {
+	case k == FakeKey:
+		return strFakeKey
+	case k < 0:
+		return strInvalidKey
+	default:
+		return fmt.Sprintf("%d", k)
+	}
+}
+
+// Item is a key with the associated data stored in the tree
+type Item struct {
+	key		KeyType
+	data	any
+}
+
+func (it Item) String() string {
+	return it.key.String()
+}
+
+// Key returns the key value of the item
+func (it Item) Key() KeyType {
+	return it.key
+}
+
+// Value returns the data associated with the item
+func (it Item) Value() any {
+	return it.data
+}
+
+// node implements a B-tree node. Items of the node are stored in a single
+// preallocated slice to keep them close to each other in memory.
+type node struct {
+	// B-tree: all items of the node
+	// B+tree: items of a leaf or separator keys of an internal node
+	items		[]Item
+
+	// Children of the node, nil for leaves
+	children	[]*node
+
+	// Only for leaves of B+tree - links to neighbour leaves
+	prev		*node
+	next		*node
+}
+
+func newNode(degree int) *node {
+	// Node can contain up to 2*degree - 1 items, one more is reserved to hold overflow before split
+	return &node{items: make([]Item, 0, 2*degree)}
+}
+
+func (n *node) leaf() bool {
+	return n.children == nil
+}
+
+// lowerBound returns the index of the first item with key greater or equal to k
+func (n *node) lowerBound(k KeyType) int {
+	lo, hi := 0, len(n.items)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if n.items[m].key < k {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+
+	return lo
+}
+
+// upperBound returns the index of the first item with key greater than k
+func (n *node) upperBound(k KeyType) int {
+	lo, hi := 0, len(n.items)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if n.items[m].key <= k {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+
+	return lo
+}
+
+// insertItem inserts item it to position i
+func (n *node) insertItem(i int, it Item) {
+	n.items = append(n.items, Item{})
+	copy(n.items[i+1:], n.items[i:])
+	n.items[i] = it
+}
+
+// removeItem removes the item at position i and returns it
+func (n *node) removeItem(i int) Item {
+	it := n.items[i]
+	copy(n.items[i:], n.items[i+1:])
+	// Clear the last item to release references to the data
+	n.items[len(n.items)-1] = Item{}
+	n.items = n.items[:len(n.items)-1]
+
+	return it
+}
+
+// insertChild inserts child c to position i
+func (n *node) insertChild(i int, c *node) {
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = c
+}
+
+// removeChild removes the child at position i and returns it
+func (n *node) removeChild(i int) *node {
+	c := n.children[i]
+	copy(n.children[i:], n.children[i+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+
+	return c
+}
+
+// truncate cuts the node items (and children) to the first nItems items
+func (n *node) truncate(nItems int) {
+	for i := nItems; i < len(n.items); i++ {
+		n.items[i] = Item{}
+	}
+	n.items = n.items[:nItems]
+
+	if n.children == nil {
+		return
+	}
+
+	for i := nItems + 1; i < len(n.children); i++ {
+		n.children[i] = nil
+	}
+	n.children = n.children[:nItems+1]
+}
+
+// first returns the leftmost leaf of the subtree with root n
+func (n *node) first() *node {
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n
+}
+
+// last returns the rightmost leaf of the subtree with root n
+func (n *node) last() *node {
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n
+}
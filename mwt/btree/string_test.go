@@ -0,0 +1,58 @@
+package btree
+
+import (
+	"testing"
+)
+
+func TestStringFilled(t *testing.T) {
+	for _, test := range []struct {
+		tree	*BTree
+		want	string
+	} {
+		{
+			NewBTree(MinDegree),
+`                        [30]
+               _______________________
+              /                       \
+           [10 20]                  [35]
+    ______________________          _____
+   /          |           \        /     \
+[2 5 8]  [13 15 17]  [23 25 27]  [33]  [37]
+`,
+		}, {
+			NewBPlusTree(MinDegree),
+`                                   [20 30]
+               ________________________________________________
+              /                          \                     \
+           [10 15]                     [25]                  [35]
+    _____________________            __________            __________
+   /          |          \          /          \          /          \
+[2 5 8] -> [10 13] -> [15 17] -> [20 23] -> [25 27] -> [30 33] -> [35 37]
+`,
+		}, {
+			NewBTree(3),
+`[5 10 15 20 30]
+`,
+		},
+	} {
+		keys := []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27}
+		if test.tree.Degree() == 3 {
+			keys = keys[:5]
+		}
+		for _, k := range keys {
+			test.tree.Insert(k, nil)
+		}
+
+		// Compare
+		if tStr := test.tree.String(); tStr != test.want {
+			t.Errorf("BTree.String() returned:\n---\n%s\n---\nWant:\n---\n%s\n---\n", tStr, test.want)
+		}
+	}
+}
+
+func TestStringEmpty(t *testing.T) {
+	tree := NewBTree(MinDegree)
+	if tStr := tree.String(); tStr != strEmptyTree {
+		t.Errorf("BTree.String() returned:\n---\n%s\n---\nWant:\n---\n%s\n---\n", tStr, strEmptyTree)
+	}
+}
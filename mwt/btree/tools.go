@@ -0,0 +1,143 @@
+package btree
+
+import "fmt"
+
+// SelfTest performs a self-test of the B-tree and returns the height of the tree,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero.
+func (t *BTree) SelfTest() (int, error) {
+	if t.root == nil {
+		if t.size != 0 {
+			return 0, fmt.Errorf("v#1: tree is empty but its size is %d", t.size)
+		}
+
+		return 0, nil
+	}
+
+	if len(t.root.items) == 0 {
+		return 0, fmt.Errorf("v#2: tree root has no items")
+	}
+
+	height, count, err := t.test(t.root, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if count != t.size {
+		return 0, fmt.Errorf("v#3: tree contains %d items, but its size is %d", count, t.size)
+	}
+
+	if t.plus {
+		if err := t.testLeaves(); err != nil {
+			return 0, err
+		}
+	}
+
+	// OK
+	return height, nil
+}
+
+// test checks the subtree with root n, all keys of which must be in the range
+// (lo, hi) for B-tree or [lo, hi) for B+tree. It returns the height of the
+// subtree and the number of real items in it.
+func (t *BTree) test(n *node, lo, hi *KeyType) (int, int, error) {
+	// Check the number of items
+	if n != t.root && len(n.items) < t.minItems() {
+		return 0, 0, fmt.Errorf("v#4: node %v has %d items, minimum is %d", n, len(n.items), t.minItems())
+	}
+	if len(n.items) > t.maxItems() {
+		return 0, 0, fmt.Errorf("v#4: node %v has %d items, maximum is %d", n, len(n.items), t.maxItems())
+	}
+
+	// Check the order and bounds of keys
+	for i, it := range n.items {
+		if i > 0 && n.items[i-1].key >= it.key {
+			return 0, 0, fmt.Errorf("v#5: node %v - keys are not in ascending order", n)
+		}
+
+		if lo != nil && (it.key < *lo || !t.plus && it.key == *lo) {
+			return 0, 0, fmt.Errorf("v#6: node %v - key %v is out of lower bound %v", n, it.key, *lo)
+		}
+		if hi != nil && it.key >= *hi {
+			return 0, 0, fmt.Errorf("v#6: node %v - key %v is out of upper bound %v", n, it.key, *hi)
+		}
+	}
+
+	if n.leaf() {
+		return 1, len(n.items), nil
+	}
+
+	if len(n.children) != len(n.items)+1 {
+		return 0, 0, fmt.Errorf("v#7: node %v has %d items but %d children", n, len(n.items), len(n.children))
+	}
+
+	// Count of real items in the subtree
+	count := 0
+	if !t.plus {
+		count = len(n.items)
+	}
+
+	height := 0
+	for i, c := range n.children {
+		// Bounds of the child subtree
+		cLo, cHi := lo, hi
+		if i > 0 {
+			cLo = &n.items[i-1].key
+		}
+		if i < len(n.items) {
+			cHi = &n.items[i].key
+		}
+
+		h, cnt, err := t.test(c, cLo, cHi)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		// All leaves must have the same depth
+		if i != 0 && h != height {
+			return 0, 0, fmt.Errorf("v#8: node %v - height of child %v (%d) is not equal height of child %v (%d)",
+				n, c, h, n.children[0], height)
+		}
+
+		height = h
+		count += cnt
+	}
+
+	// OK
+	return height + 1, count, nil
+}
+
+// testLeaves checks the list of leaves of the B+tree
+func (t *BTree) testLeaves() error {
+	// Collect leaves in order of the tree walking
+	var leaves []*node
+	var collect func(n *node)
+	collect = func(n *node) {
+		if n.leaf() {
+			leaves = append(leaves, n)
+			return
+		}
+		for _, c := range n.children {
+			collect(c)
+		}
+	}
+	collect(t.root)
+
+	for i, n := range leaves {
+		var prev, next *node
+		if i > 0 {
+			prev = leaves[i-1]
+		}
+		if i < len(leaves)-1 {
+			next = leaves[i+1]
+		}
+
+		if n.prev != prev || n.next != next {
+			return fmt.Errorf("v#9: leaf %v is incorrectly linked: prev %v (want %v), next %v (want %v)",
+				n, n.prev, prev, n.next, next)
+		}
+	}
+
+	// OK
+	return nil
+}
@@ -0,0 +1,173 @@
+package tree234
+
+import (
+	"fmt"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+)
+
+// FromRBTree converts the red-black tree rbt to the 2-3-4 tree. Each black node of rbt
+// merged with its red children forms a node of the 2-3-4 tree:
+//
+//	   black B                   [A B C]
+//	  /       \        =>       /  | |  \
+//	red A    red C            a1  a2 c1  c2
+//	/  \     /  \
+//	a1 a2   c1  c2
+//
+// Keys and associated data are copied, rbt is not modified. An error is returned if
+// rbt violates the properties of the red-black tree, so that the conversion is impossible.
+func FromRBTree(rbt *rbtree.RBTree) (*Tree234, error) {
+	t := NewTree234()
+
+	root := rbt.Root()
+	if root == nil {
+		// Empty tree
+		return t, nil
+	}
+
+	if root.Color() != rbtree.Black {
+		return nil, fmt.Errorf("root of the red-black tree (%v) is NOT black", root)
+	}
+
+	var err error
+	if t.root, t.size, _, err = fromRBNode(root); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// fromRBNode converts the subtree with black root b and returns the node of 2-3-4 tree,
+// the number of keys and the height of the subtree (equal to its black-height)
+func fromRBNode(b *rbtree.RBNode) (*Node234, int, int, error) {
+	n := &Node234{}
+	// Red-black subtrees that will be children of the 2-3-4 node
+	var children []*rbtree.RBNode
+
+	// Merge red left child, if any
+	if l := b.Left(); l.Color() == rbtree.Red {
+		n.insertKey(n.n, l.Key(), l.Value(), nil)
+		children = append(children, l.Left(), l.Right())
+	} else {
+		children = append(children, l)
+	}
+
+	n.insertKey(n.n, b.Key(), b.Value(), nil)
+
+	// Merge red right child, if any
+	if r := b.Right(); r.Color() == rbtree.Red {
+		n.insertKey(n.n, r.Key(), r.Value(), nil)
+		children = append(children, r.Left(), r.Right())
+	} else {
+		children = append(children, r)
+	}
+
+	size := n.n
+
+	// Count children that are leaves of the red-black tree
+	nils := 0
+	for _, c := range children {
+		switch {
+		case c == nil:
+			nils++
+		case c.Color() != rbtree.Black:
+			// Red node is a child of red node
+			return nil, 0, 0, fmt.Errorf("red node %v has red child %v", c.Parent(), c)
+		}
+	}
+
+	switch nils {
+	// Node of the 2-3-4 tree is a leaf
+	case len(children):
+		return n, size, 1, nil
+	// All children are not leaves
+	case 0:
+	default:
+		return nil, 0, 0, fmt.Errorf("node %v has children with different black-height", b)
+	}
+
+	height := 0
+	for i, c := range children {
+		child, cSize, cHeight, err := fromRBNode(c)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		// Subtrees deeper than one level can also differ in black-height
+		if i != 0 && cHeight != height {
+			return nil, 0, 0, fmt.Errorf("node %v has children with different black-height (%d and %d)",
+				b, height, cHeight)
+		}
+		height = cHeight
+
+		n.children[i] = child
+		size += cSize
+	}
+
+	return n, size, height + 1, nil
+}
+
+// ToRBTree converts the 2-3-4 tree to the red-black tree. Each node of the
+// 2-3-4 tree becomes a black node with red children for the other keys, in
+// case of 3-node the smaller key becomes the red left child:
+//
+//	[A B]                  black B
+//	/ | \        =>       /       \
+//	a b c               red A      c
+//	                    /  \
+//	                   a    b
+//
+// Keys and associated data are copied, t is not modified.
+func ToRBTree(t *Tree234) *rbtree.RBTree {
+	if t.root == nil {
+		return rbtree.NewRBTree()
+	}
+
+	return rbtree.NewRBTreeFromRoot(toRBNode(t.root))
+}
+
+// toRBNode converts the subtree with root n and returns its black root
+func toRBNode(n *Node234) *rbtree.RBNode {
+	// Convert children first
+	var children [maxKeys+1]*rbtree.RBNode
+	if !n.Leaf() {
+		for i := 0; i <= n.n; i++ {
+			children[i] = toRBNode(n.children[i])
+		}
+	}
+
+	newNode := func(i int, color rbtree.ColorType) *rbtree.RBNode {
+		rbn := rbtree.NewRBNode(n.keys[i], n.data[i])
+		rbn.SetColor(color)
+		return rbn
+	}
+
+	switch n.n {
+	// 2-node - single black node
+	case 1:
+		b := newNode(0, rbtree.Black)
+		b.SetChildren(children[0], children[1])
+
+		return b
+
+	// 3-node - black node with red left child
+	case 2:
+		l, b := newNode(0, rbtree.Red), newNode(1, rbtree.Black)
+		l.SetChildren(children[0], children[1])
+		b.SetChildren(l, children[2])
+
+		return b
+
+	// 4-node - black node with two red children
+	case maxKeys:
+		l, b, r := newNode(0, rbtree.Red), newNode(1, rbtree.Black), newNode(2, rbtree.Red)
+		l.SetChildren(children[0], children[1])
+		r.SetChildren(children[2], children[3])
+		b.SetChildren(l, r)
+
+		return b
+	}
+
+	panic(fmt.Sprintf("Unexpected number of keys in the node %v: %d", n, n.n))
+}
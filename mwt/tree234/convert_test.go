@@ -0,0 +1,254 @@
+package tree234
+
+import (
+	"fmt"
+	"testing"
+	"math/rand"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+// rbKeys returns keys of the red-black tree in ascending order
+func rbKeys(rbt *rbtree.RBTree) []KeyType {
+	var keys []KeyType
+	for n := rbt.Min(); n != nil; n = rbt.Successor(n) {
+		keys = append(keys, n.Key())
+	}
+
+	return keys
+}
+
+func TestFromRBTree(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	rbt := rbtree.NewRBTree()
+	// Number of keys in the tree
+	size := 0
+
+	// Check conversion on each tree state during insertions and deletions
+	check := func(step string) {
+		// Only trees passed the self-test are considered
+		bh, err := rbt.SelfTest()
+		if err != nil {
+			t.Errorf("[%s] red-black tree structure issue: %v", step, err)
+			t.FailNow()
+		}
+
+		tree, err := FromRBTree(rbt)
+		if err != nil {
+			t.Errorf("[%s] FromRBTree returned error: %v", step, err)
+			t.FailNow()
+		}
+
+		h, err := tree.SelfTest()
+		if err != nil {
+			t.Errorf("[%s] 2-3-4 tree converted from red-black tree is invalid: %v", step, err)
+			t.FailNow()
+		}
+
+		// Height of 2-3-4 tree is equal to black-height of red-black tree
+		if h != bh {
+			t.Errorf("[%s] height of 2-3-4 tree is %d, want - %d (black-height)", step, h, bh)
+			t.FailNow()
+		}
+
+		if tree.Len() != size || fmt.Sprint(tree.Keys()) != fmt.Sprint(rbKeys(rbt)) {
+			t.Errorf("[%s] keys of 2-3-4 tree are not equal to the keys of red-black tree", step)
+			t.FailNow()
+		}
+	}
+
+	check("empty")
+
+	const checkStep = 97
+	for i, k := range testKeys {
+		rbt.Insert(rbtree.NewRBNode(k, k*2))
+		size++
+
+		if i % checkStep == 0 {
+			check(fmt.Sprintf("insert:%d", i))
+		}
+	}
+	check("filled")
+
+	for i, k := range testKeys {
+		rbt.Delete(rbt.Search(k))
+		size--
+
+		if i % checkStep == 0 {
+			check(fmt.Sprintf("delete:%d", i))
+		}
+	}
+	check("cleared")
+}
+
+func TestFromRBTreeInvalid(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, 100, KeyType(MaxItem))
+
+	for i, breaker := range []func(rbt *rbtree.RBTree) {
+		// Red root
+		func(rbt *rbtree.RBTree) { rbt.Root().SetColor(rbtree.Red) },
+		// Red node with red child
+		func(rbt *rbtree.RBTree) {
+			for n := rbt.Min(); n != nil; n = rbt.Successor(n) {
+				if n.Color() == rbtree.Red && n.Left() == nil {
+					child := rbtree.NewRBNode(MaxItem + 1, nil)
+					child.SetColor(rbtree.Red)
+					n.SetChildren(child, n.Right())
+					return
+				}
+			}
+			panic("No red nodes without left child were found")
+		},
+		// Black-height violation
+		func(rbt *rbtree.RBTree) {
+			n := rbt.Max()
+			n.SetChildren(n.Left(), rbtree.NewRBNode(MaxItem + 1, nil))
+		},
+	} {
+		rbt := rbtree.NewRBTree()
+		for _, k := range testKeys {
+			rbt.Insert(rbtree.NewRBNode(k, nil))
+		}
+
+		// Apply test to tree
+		breaker(rbt)
+
+		if _, err := rbt.SelfTest(); err == nil {
+			t.Errorf("[%d] red-black tree is not broken", i)
+		}
+
+		if tree, err := FromRBTree(rbt); err == nil {
+			t.Errorf("[%d] FromRBTree does not return expected error, tree:\n%v", i, tree)
+		} else {
+			t.Log("Expected conversion error:", err)
+		}
+	}
+
+	// Black-height mismatch below the children of the root: B20 has the leaf B10 as
+	// the left child and B30 with children B25 and B35 as the right child
+	nodes := map[KeyType]*rbtree.RBNode{}
+	for _, k := range []KeyType{10, 20, 25, 30, 35} {
+		nodes[k] = rbtree.NewRBNode(k, nil)
+		nodes[k].SetColor(rbtree.Black)
+	}
+	nodes[30].SetChildren(nodes[25], nodes[35])
+	nodes[20].SetChildren(nodes[10], nodes[30])
+	rbt := rbtree.NewRBTreeFromRoot(nodes[20])
+
+	if _, err := rbt.SelfTest(); err == nil {
+		t.Errorf("[deep] red-black tree is not broken")
+	}
+
+	if tree, err := FromRBTree(rbt); err == nil {
+		t.Errorf("[deep] FromRBTree does not return expected error, tree:\n%v", tree)
+	} else {
+		t.Log("Expected conversion error:", err)
+	}
+}
+
+func TestToRBTree(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := NewTree234()
+	for i, k := range testKeys {
+		tree.Insert(k, k*2)
+
+		// Check not each tree state, it takes too long
+		if i % 97 != 0 && i != len(testKeys) - 1 {
+			continue
+		}
+
+		rbt := ToRBTree(tree)
+
+		bh, err := rbt.SelfTest()
+		if err != nil {
+			t.Errorf("[%d] red-black tree converted from 2-3-4 tree is invalid: %v", i, err)
+			t.FailNow()
+		}
+
+		h, _ := tree.SelfTest()
+		if h != bh {
+			t.Errorf("[%d] black-height of red-black tree is %d, want - %d (height of 2-3-4 tree)", i, bh, h)
+			t.FailNow()
+		}
+
+		if fmt.Sprint(rbKeys(rbt)) != fmt.Sprint(tree.Keys()) {
+			t.Errorf("[%d] keys of red-black tree are not equal to the keys of 2-3-4 tree", i)
+			t.FailNow()
+		}
+
+		// Check for data
+		for n := rbt.Min(); n != nil; n = rbt.Successor(n) {
+			if n.Value() != n.Key()*2 {
+				t.Errorf("[%d] node %v has value %v, want - %v", i, n, n.Value(), n.Key()*2)
+				t.FailNow()
+			}
+		}
+
+		// Converted tree should work as usual red-black tree
+		rbt.Insert(rbtree.NewRBNode(MaxItem + 1, nil))
+		rbt.Delete(rbt.Root())
+		if _, err := rbt.SelfTest(); err != nil {
+			t.Errorf("[%d] converted red-black tree became invalid after modification: %v", i, err)
+			t.FailNow()
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	// Shuffle keys to get a tree different from the tree created by the other tests
+	keys := make([]KeyType, len(testKeys))
+	copy(keys, testKeys)
+	rnd.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+	tree, _ := newTreeSortedKeys(keys)
+
+	// 2-3-4 => red-black => 2-3-4 gives exactly the same tree
+	back, err := FromRBTree(ToRBTree(tree))
+	if err != nil {
+		t.Errorf("FromRBTree returned error: %v", err)
+		t.FailNow()
+	}
+
+	if back.String() != tree.String() {
+		t.Errorf("2-3-4 tree converted to red-black tree and back is not equal to the source tree")
+	}
+
+	// red-black => 2-3-4 => red-black preserves keys, values and black-height
+	rbt := rbtree.NewRBTree()
+	for _, k := range keys {
+		rbt.Insert(rbtree.NewRBNode(k, k*2))
+	}
+
+	tree, err = FromRBTree(rbt)
+	if err != nil {
+		t.Errorf("FromRBTree returned error: %v", err)
+		t.FailNow()
+	}
+
+	rbtBack := ToRBTree(tree)
+	if fmt.Sprint(rbKeys(rbtBack)) != fmt.Sprint(rbKeys(rbt)) {
+		t.Errorf("red-black tree converted to 2-3-4 tree and back has different keys")
+	}
+
+	bh, _ := rbt.SelfTest()
+	if bhBack, err := rbtBack.SelfTest(); err != nil || bhBack != bh {
+		t.Errorf("red-black tree converted to 2-3-4 tree and back has black-height %d (error: %v), want - %d",
+			bhBack, err, bh)
+	}
+
+	for n := rbtBack.Min(); n != nil; n = rbtBack.Successor(n) {
+		if n.Value() != n.Key()*2 {
+			t.Errorf("node %v has value %v, want - %v", n, n.Value(), n.Key()*2)
+			t.FailNow()
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package tree234
+
+import (
+	"fmt"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+)
+
+//nolint:testableexamples
+func Example_treeCreation() {
+	// Create tree
+	tree := NewTree234()
+
+	// Insert keys and data
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	// Print graphical representation of the tree
+	fmt.Print(tree)
+}
+
+func Example_fromRBTree() {
+	// Create red-black tree
+	rbt := rbtree.NewRBTree()
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		rbt.Insert(rbtree.NewRBNode(k, fmt.Sprintf("Value for key %v", k)))
+	}
+
+	// Convert it to the 2-3-4 tree
+	tree, err := FromRBTree(rbt)
+	if err != nil {
+		panic(err)
+	}
+
+	// Print graphical representation of the 2-3-4 tree
+	fmt.Print(tree)
+
+	// Output:
+	// [10 20 30]
+	//     __________________________________
+	//    /          /           \           \
+	// [2 5 8]  [13 15 17]  [23 25 27]  [33 35 37]
+}
+
+//nolint:testableexamples
+func Example_toRBTree() {
+	// Create 2-3-4 tree
+	tree := NewTree234()
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(k, fmt.Sprintf("Value for key %v", k))
+	}
+
+	// Convert it to the red-black tree and print
+	fmt.Print(ToRBTree(tree))
+}
@@ -0,0 +1,105 @@
+package tree234
+
+import (
+	"strings"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+)
+
+// KeyType represents the key type of a 2-3-4 tree node, it is the same as
+// the key type of the red-black tree to make conversions between them possible
+type KeyType = rbtree.KeyType
+
+// maxKeys is the maximal number of keys in the node of 2-3-4 tree
+const maxKeys = 3
+
+// Node234 implements a 2-3-4 tree node, that contains 1, 2 or 3 keys
+// and 2, 3 or 4 children (if it is not a leaf) respectively
+type Node234 struct {
+	keys		[maxKeys]KeyType
+	data		[maxKeys]any
+	children	[maxKeys+1]*Node234
+
+	// Number of keys in the node
+	n			int
+}
+
+func (n *Node234) String() string {
+	if n == nil {
+		return "<nil>"
+	}
+
+	keys := make([]string, 0, n.n)
+	for _, k := range n.Keys() {
+		keys = append(keys, k.String())
+	}
+
+	return "[" + strings.Join(keys, " ") + "]"
+}
+
+// Keys returns the keys of the node in ascending order
+func (n *Node234) Keys() []KeyType {
+	if n == nil {
+		return nil
+	}
+
+	keys := make([]KeyType, n.n)
+	copy(keys, n.keys[:n.n])
+
+	return keys
+}
+
+// Values returns the data associated with the keys of the node
+func (n *Node234) Values() []any {
+	if n == nil {
+		return nil
+	}
+
+	data := make([]any, n.n)
+	copy(data, n.data[:n.n])
+
+	return data
+}
+
+// Children returns the children of the node or nil if the node is a leaf
+func (n *Node234) Children() []*Node234 {
+	if n == nil || n.Leaf() {
+		return nil
+	}
+
+	children := make([]*Node234, n.n+1)
+	copy(children, n.children[:n.n+1])
+
+	return children
+}
+
+// Leaf returns true if the node has no children
+func (n *Node234) Leaf() bool {
+	return n.children[0] == nil
+}
+
+// Kind returns the kind of the node: 2, 3 or 4 for 2-node, 3-node and 4-node respectively
+func (n *Node234) Kind() int {
+	return n.n + 1
+}
+
+// find returns the index of the first key greater or equal to k and true if the key is equal to k
+func (n *Node234) find(k KeyType) (int, bool) {
+	i := 0
+	for i < n.n && n.keys[i] < k {
+		i++
+	}
+
+	return i, i < n.n && n.keys[i] == k
+}
+
+// insertKey inserts key k with data to the position i of the node, child
+// is attached as the right neighbour of the key
+func (n *Node234) insertKey(i int, k KeyType, data any, child *Node234) {
+	copy(n.keys[i+1:n.n+1], n.keys[i:n.n])
+	copy(n.data[i+1:n.n+1], n.data[i:n.n])
+	copy(n.children[i+2:n.n+2], n.children[i+1:n.n+1])
+
+	n.keys[i], n.data[i], n.children[i+1] = k, data, child
+	n.n++
+}
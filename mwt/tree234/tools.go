@@ -0,0 +1,99 @@
+package tree234
+
+import "fmt"
+
+// SelfTest performs a self-test of the 2-3-4 tree and returns the height of the tree,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero.
+func (t *Tree234) SelfTest() (int, error) {
+	if t.root == nil {
+		if t.size != 0 {
+			return 0, fmt.Errorf("v#1: tree is empty but its size is %d", t.size)
+		}
+
+		return 0, nil
+	}
+
+	height, count, err := t.root.test(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if count != t.size {
+		return 0, fmt.Errorf("v#2: tree contains %d keys, but its size is %d", count, t.size)
+	}
+
+	// OK
+	return height, nil
+}
+
+// test checks the subtree with root n, all keys of which must be in the range (lo, hi).
+// It returns the height of the subtree and the number of keys in it.
+func (n *Node234) test(lo, hi *KeyType) (int, int, error) {
+	if n.n < 1 || n.n > maxKeys {
+		return 0, 0, fmt.Errorf("v#3: node %v has %d keys, must be 1..%d", n, n.n, maxKeys)
+	}
+
+	// Check the order and bounds of keys
+	for i, k := range n.keys[:n.n] {
+		if i > 0 && n.keys[i-1] >= k {
+			return 0, 0, fmt.Errorf("v#4: node %v - keys are not in ascending order", n)
+		}
+
+		if lo != nil && k <= *lo || hi != nil && k >= *hi {
+			return 0, 0, fmt.Errorf("v#5: node %v - key %v is out of the parent's bounds", n, k)
+		}
+	}
+
+	if n.Leaf() {
+		// Leaf must not have children at all
+		for _, c := range n.children {
+			if c != nil {
+				return 0, 0, fmt.Errorf("v#6: leaf %v has non-nil child %v", n, c)
+			}
+		}
+
+		return 1, n.n, nil
+	}
+
+	count := n.n
+	height := 0
+	for i, c := range n.children {
+		if i > n.n {
+			if c != nil {
+				return 0, 0, fmt.Errorf("v#6: node %v has unexpected child %v", n, c)
+			}
+			continue
+		}
+
+		if c == nil {
+			return 0, 0, fmt.Errorf("v#6: node %v with %d keys has nil child #%d", n, n.n, i)
+		}
+
+		// Bounds of the child subtree
+		cLo, cHi := lo, hi
+		if i > 0 {
+			cLo = &n.keys[i-1]
+		}
+		if i < n.n {
+			cHi = &n.keys[i]
+		}
+
+		h, cnt, err := c.test(cLo, cHi)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		// All leaves must have the same depth
+		if i != 0 && h != height {
+			return 0, 0, fmt.Errorf("v#7: node %v - height of child %v (%d) is not equal height of child %v (%d)",
+				n, c, h, n.children[0], height)
+		}
+
+		height = h
+		count += cnt
+	}
+
+	// OK
+	return height + 1, count, nil
+}
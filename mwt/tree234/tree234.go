@@ -0,0 +1,166 @@
+/*
+Package tree234 provides an example of a 2-3-4 tree implementation.
+
+A 2-3-4 tree is a B-tree of minimum degree 2: each node contains 1, 2 or 3
+keys and 2, 3 or 4 children respectively, all leaves have the same depth.
+
+Every red-black tree corresponds to a 2-3-4 tree: each black node merged with
+its red children forms a node of 2-3-4 tree. The package provides conversions
+in both directions, see FromRBTree and ToRBTree.
+
+It supports inserting nodes, finding nodes by given arbitrary key, walking
+through keys in ascending order and output of graphical representation of the
+tree using ASCII graphics. For example, a tree with the keys 20, 10, 30, 5, 15,
+25, 35, 8, 17, 37, 33, 13, 2, 23, 27 added sequentially will look like this:
+
+	                  [20]
+	         _______________________
+	        /                       \
+	      [10]                    [30]
+	    __________             ___________
+	   /          \           /           \
+	[2 5 8]  [13 15 17]  [23 25 27]  [33 35 37]
+*/
+package tree234
+
+// Tree234 implements a 2-3-4 tree.
+type Tree234 struct {
+	root	*Node234
+	size	int
+}
+
+// NewTree234 returns new empty 2-3-4 tree.
+func NewTree234() *Tree234 {
+	return &Tree234{}
+}
+
+// Root returns the root node of the tree, or nil if the tree is empty.
+func (t *Tree234) Root() *Node234 {
+	return t.root
+}
+
+// Len returns the number of keys in the tree.
+func (t *Tree234) Len() int {
+	return t.size
+}
+
+// Search returns the data associated with key k and true, or nil and false if there is no such key.
+func (t *Tree234) Search(k KeyType) (any, bool) {
+	for n := t.root; n != nil; {
+		i, found := n.find(k)
+		if found {
+			return n.data[i], true
+		}
+
+		if n.Leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+
+	return nil, false
+}
+
+// Insert inserts key k with associated data into the tree. It returns false if key k
+// already exists in the tree, in this case the tree is not modified.
+func (t *Tree234) Insert(k KeyType, data any) bool {
+	if _, found := t.Search(k); found {
+		// Already exists
+		return false
+	}
+	t.size++
+
+	// Check for empty tree
+	if t.root == nil {
+		t.root = &Node234{}
+		t.root.insertKey(0, k, data, nil)
+
+		return true
+	}
+
+	// Split 4-node root, the tree grows up
+	if t.root.n == maxKeys {
+		root := &Node234{}
+		root.children[0] = t.root
+		splitChild(root, 0)
+		t.root = root
+	}
+
+	// Go down splitting all 4-nodes on the way, so the
+	// leaf always has a free place for the new key
+	n := t.root
+	for !n.Leaf() {
+		i, _ := n.find(k)
+		if n.children[i].n == maxKeys {
+			splitChild(n, i)
+
+			// Middle key of the split child moved to position i, select correct side
+			if k > n.keys[i] {
+				i++
+			}
+		}
+
+		n = n.children[i]
+	}
+
+	i, _ := n.find(k)
+	n.insertKey(i, k, data, nil)
+
+	return true
+}
+
+// splitChild splits 4-node child i of node p into two 2-nodes, the middle key moves up to p
+func splitChild(p *Node234, i int) {
+	c := p.children[i]
+
+	// The right part of the 4-node
+	right := &Node234{n: 1}
+	right.keys[0], right.data[0] = c.keys[2], c.data[2]
+	right.children[0], right.children[1] = c.children[2], c.children[3]
+
+	// Move the middle key up
+	p.insertKey(i, c.keys[1], c.data[1], right)
+
+	// c keeps only the left part
+	c.keys[1], c.keys[2] = 0, 0
+	c.data[1], c.data[2] = nil, nil
+	c.children[2], c.children[3] = nil, nil
+	c.n = 1
+}
+
+// Ascend calls f for each key of the tree and associated data in ascending order
+// of keys until f returns false.
+func (t *Tree234) Ascend(f func(k KeyType, data any) bool) {
+	if t.root != nil {
+		t.root.ascend(f)
+	}
+}
+
+// Keys returns all keys of the tree in ascending order.
+func (t *Tree234) Keys() []KeyType {
+	keys := make([]KeyType, 0, t.size)
+	t.Ascend(func(k KeyType, _ any) bool {
+		keys = append(keys, k)
+		return true
+	})
+
+	return keys
+}
+
+func (n *Node234) ascend(f func(k KeyType, data any) bool) bool {
+	for i := 0; i < n.n; i++ {
+		if !n.Leaf() && !n.children[i].ascend(f) {
+			return false
+		}
+
+		if !f(n.keys[i], n.data[i]) {
+			return false
+		}
+	}
+
+	if !n.Leaf() {
+		return n.children[n.n].ascend(f)
+	}
+
+	return true
+}
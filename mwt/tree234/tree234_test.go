@@ -0,0 +1,185 @@
+package tree234
+
+import (
+	"fmt"
+	"testing"
+	"math/rand"
+	"sort"
+
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Number of keys gives nodes of all types on 6 to 12 levels
+	keysCount	=	4096
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2027
+)
+
+func TestEmpty(t *testing.T) {
+	tree := NewTree234()
+
+	if n := tree.Root(); n != nil {
+		t.Errorf("Root returned non-nil value %v on empty tree", n)
+	}
+
+	if v, ok := tree.Search(1); ok {
+		t.Errorf("Search returned %v on empty tree", v)
+	}
+
+	if h, err := tree.SelfTest(); h != 0 || err != nil {
+		t.Errorf("SelfTest returned %d, %v on empty tree, want - 0, nil", h, err)
+	}
+
+	if s := tree.String(); s != strEmptyTree {
+		t.Errorf("String returned %q on empty tree, want - %q", s, strEmptyTree)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := NewTree234()
+
+	for i, k := range testKeys {
+		if !tree.Insert(k, nil) {
+			t.Errorf("[%d] Tree234.Insert returned false on unique key %v", i, k)
+			t.FailNow()
+		}
+	}
+
+	if tree.Len() != len(testKeys) {
+		t.Errorf("Tree234.Len returned %d, want - %d", tree.Len(), len(testKeys))
+	}
+
+	if _, err := tree.SelfTest(); err != nil {
+		t.Errorf("2-3-4 tree structure issue: %v", err)
+	}
+}
+
+func TestInsertDupes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, _ := newTreeSortedKeys(testKeys)
+
+	for i, k := range testKeys {
+		if tree.Insert(k, nil) {
+			t.Errorf("[%d] Tree234.Insert returned true, want - false," +
+				" because key %v should be already inserted", i, k)
+			t.FailNow()
+		}
+	}
+
+	if tree.Len() != len(testKeys) {
+		t.Errorf("Tree234.Len returned %d, want - %d", tree.Len(), len(testKeys))
+	}
+}
+
+func TestSearch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, _ := newTreeSortedKeys(testKeys)
+
+	for _, k := range testKeys {
+		v, ok := tree.Search(k)
+		if !ok {
+			t.Errorf("key %v was added but not found in the tree", k)
+			t.FailNow()
+		}
+
+		if v != k*2 {
+			t.Errorf("Search(%v) returned value %v, want - %v", k, v, k*2)
+			t.FailNow()
+		}
+	}
+
+	if v, ok := tree.Search(MaxItem + 1); ok {
+		t.Errorf("Search(%v) returned %v, but the key was not inserted", MaxItem + 1, v)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree, sKeys := newTreeSortedKeys(testKeys)
+
+	if keys := tree.Keys(); fmt.Sprint(keys) != fmt.Sprint(sKeys) {
+		t.Errorf("Keys returned %d keys not equal to %d sorted inserted keys", len(keys), len(sKeys))
+	}
+}
+
+func TestNodeKinds(t *testing.T) {
+	tree := NewTree234()
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(k, nil)
+	}
+
+	root := tree.Root()
+	if root.Kind() != 2 || root.Leaf() || len(root.Children()) != 2 {
+		t.Errorf("root %v has kind %d and %d children, want - 2-node with 2 children",
+			root, root.Kind(), len(root.Children()))
+	}
+
+	leaf := root.Children()[0].Children()[0]
+	if !leaf.Leaf() || leaf.Kind() != 4 || leaf.Children() != nil {
+		t.Errorf("leaf %v has kind %d and %d children, want - 4-node leaf", leaf, leaf.Kind(), len(leaf.Children()))
+	}
+
+	if keys := fmt.Sprint(leaf.Keys()); keys != "[2 5 8]" {
+		t.Errorf("leaf has keys %s, want - [2 5 8]", keys)
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, 100, KeyType(MaxItem))
+
+	for i, breaker := range []func(t *Tree234) {
+		// Wrong size
+		func(t *Tree234) { t.size-- },
+		// Wrong order of keys
+		func(t *Tree234) { t.root.keys[0], t.root.children[0].keys[0] = t.root.children[0].keys[0], t.root.keys[0] },
+		// Empty node
+		func(t *Tree234) { t.root.children[0].n = 0 },
+		// Different depth of leaves
+		func(t *Tree234) { t.root.children[0] = t.root.children[0].children[0] },
+	} {
+		tree, _ := newTreeSortedKeys(testKeys)
+
+		// Apply test to tree
+		breaker(tree)
+
+		// Run self-testing
+		h, err := tree.SelfTest()
+
+		switch {
+		case err == nil:
+			t.Errorf("[%d] self-test does not return expected issue", i)
+		case h != 0:
+			t.Errorf("returned height of the invalid tree is not zero - %d", h)
+		default:
+			t.Log("Expected self-test error:", err)
+		}
+	}
+}
+
+func newTreeSortedKeys(keys []KeyType) (*Tree234, []KeyType) {
+	tree := NewTree234()
+
+	// Insert all keys with doubled keys as values
+	for _, k := range keys {
+		tree.Insert(k, k*2)
+	}
+
+	// Make sorted copy of keys
+	sKeys := make([]KeyType, len(keys))
+	copy(sKeys, keys)
+	sort.Slice(sKeys, func(i, j int) bool { return sKeys[i] < sKeys[j] } )
+
+	return tree, sKeys
+}
@@ -0,0 +1,284 @@
+/*
+Package art provides an example of an adaptive radix tree (ART) implementation.
+
+The adaptive radix tree is a radix tree that uses bytes of keys to choose the
+children of nodes. To keep memory usage low, the inner nodes adapt their layout
+to the number of children:
+
+	Node4   - up to 4 children, keys are searched linearly
+	Node16  - up to 16 children, keys are searched using the binary search
+	Node48  - up to 48 children and the 256-bytes index of them
+	Node256 - up to 256 children directly indexed by the key byte
+
+The common parts of keys are compressed into the prefixes of inner nodes, so
+the height of the tree does not exceed the length of the longest key. Search
+takes O(len(key)) time and does not depend on the number of keys, which makes
+the tree faster than balanced binary search trees on large data sets.
+
+Keys are byte slices compared lexicographically, so iteration over the tree,
+finding the minimum and the maximum and range scans use the lexicographic order.
+Integer keys can be stored using the IntKey function that encodes them preserving
+the order.
+*/
+package art
+
+import "bytes"
+
+// ART implements an adaptive radix tree.
+type ART struct {
+	root	node
+	size	int
+}
+
+// NewART returns new empty adaptive radix tree.
+func NewART() *ART {
+	return &ART{}
+}
+
+// Len returns the number of keys in the tree.
+func (t *ART) Len() int {
+	return t.size
+}
+
+// Search returns the data associated with key k and true, or nil and false if there is no such key.
+func (t *ART) Search(k []byte) (any, bool) {
+	n, depth := t.root, 0
+	for n != nil {
+		if l, ok := n.(*leaf); ok {
+			if bytes.Equal(l.key, k) {
+				return l.data, true
+			}
+			return nil, false
+		}
+
+		in := n.(*inner)	//nolint:forcetypeassert
+		if in.prefixMismatch(k, depth) != len(in.prefix) {
+			return nil, false
+		}
+		depth += len(in.prefix)
+
+		if depth == len(k) {
+			if in.term == nil {
+				return nil, false
+			}
+			return in.term.data, true
+		}
+
+		ref := in.findChild(k[depth])
+		if ref == nil {
+			return nil, false
+		}
+		n, depth = *ref, depth + 1
+	}
+
+	return nil, false
+}
+
+// Insert inserts key k with associated data into the tree. It returns false if the key
+// already exists in the tree, in this case the tree is not modified. The key is copied.
+func (t *ART) Insert(k []byte, data any) bool {
+	l := &leaf{key: append([]byte(nil), k...), data: data}
+	if !insert(&t.root, l, 0) {
+		return false
+	}
+
+	t.size++
+
+	return true
+}
+
+// insert inserts the leaf l into the subtree referred by ref, depth is the number of
+// bytes of the key consumed before the subtree. It returns false if the key already exists.
+func insert(ref *node, l *leaf, depth int) bool {
+	key := l.key
+
+	switch n := (*ref).(type) {
+	case nil:
+		*ref = l
+		return true
+
+	case *leaf:
+		if bytes.Equal(n.key, key) {
+			// Already exists
+			return false
+		}
+
+		// Replace the leaf by the inner node with the common part of keys as prefix
+		lcp := depth
+		for lcp < len(key) && lcp < len(n.key) && key[lcp] == n.key[lcp] {
+			lcp++
+		}
+
+		in := newInner(append([]byte(nil), key[depth:lcp]...))
+		in.place(n, lcp)
+		in.place(l, lcp)
+		*ref = in
+
+		return true
+
+	case *inner:
+		if p := n.prefixMismatch(key, depth); p != len(n.prefix) {
+			// The key diverges inside the prefix - split the prefix by the new node
+			in := newInner(n.prefix[:p:p])
+			in.addChild(n.prefix[p], n)
+			n.prefix = n.prefix[p+1:]
+			in.place(l, depth + p)
+			*ref = in
+
+			return true
+		}
+		depth += len(n.prefix)
+
+		if depth == len(key) {
+			if n.term != nil {
+				// Already exists
+				return false
+			}
+			n.term = l
+
+			return true
+		}
+
+		if child := n.findChild(key[depth]); child != nil {
+			return insert(child, l, depth + 1)
+		}
+		n.addChild(key[depth], l)
+
+		return true
+	}
+
+	panic("unexpected node type")
+}
+
+// place places the leaf l to the node n, depth is the number of bytes of the key consumed before the leaf
+func (n *inner) place(l *leaf, depth int) {
+	if len(l.key) == depth {
+		n.term = l
+		return
+	}
+
+	n.addChild(l.key[depth], l)
+}
+
+// Delete deletes key k from the tree and returns the data associated with the key
+// and true, or nil and false if there is no such key.
+func (t *ART) Delete(k []byte) (any, bool) {
+	l := remove(&t.root, k, 0)
+	if l == nil {
+		return nil, false
+	}
+
+	t.size--
+
+	return l.data, true
+}
+
+// remove removes the key from the subtree referred by ref and returns the removed
+// leaf or nil if there is no such key. The subtree is collapsed if required.
+func remove(ref *node, key []byte, depth int) *leaf {
+	switch n := (*ref).(type) {
+	case *leaf:
+		if !bytes.Equal(n.key, key) {
+			return nil
+		}
+		*ref = nil
+
+		return n
+
+	case *inner:
+		if n.prefixMismatch(key, depth) != len(n.prefix) {
+			return nil
+		}
+		depth += len(n.prefix)
+
+		var l *leaf
+		if depth == len(key) {
+			l, n.term = n.term, nil
+		} else if child := n.findChild(key[depth]); child != nil {
+			if l = remove(child, key, depth + 1); l != nil && *child == nil {
+				n.removeChild(key[depth])
+			}
+		}
+
+		if l != nil {
+			n.collapse(ref)
+		}
+
+		return l
+	}
+
+	return nil
+}
+
+// collapse replaces the node referred by ref by its single child or leaf if it is not required anymore
+func (n *inner) collapse(ref *node) {
+	switch {
+	case n.count == 0:
+		// Only the key that ends on the node is left, if any
+		if n.term == nil {
+			*ref = nil
+		} else {
+			*ref = n.term
+		}
+
+	case n.count == 1 && n.term == nil:
+		b, c := n.firstChild()
+		if in, ok := c.(*inner); ok {
+			// Merge prefixes of the node and its child
+			prefix := make([]byte, 0, len(n.prefix) + 1 + len(in.prefix))
+			prefix = append(append(append(prefix, n.prefix...), b), in.prefix...)
+			in.prefix = prefix
+		}
+		*ref = c
+	}
+}
+
+// Min returns the minimal key, associated data and true, or nil, nil and false if the tree is empty.
+// The returned key must not be modified.
+func (t *ART) Min() ([]byte, any, bool) {
+	if t.root == nil {
+		return nil, nil, false
+	}
+
+	l := t.root.minimum()
+	return l.key, l.data, true
+}
+
+// Max returns the maximal key, associated data and true, or nil, nil and false if the tree is empty.
+// The returned key must not be modified.
+func (t *ART) Max() ([]byte, any, bool) {
+	if t.root == nil {
+		return nil, nil, false
+	}
+
+	l := t.root.maximum()
+	return l.key, l.data, true
+}
+
+// Ascend calls f for each key of the tree in ascending order until f returns false.
+// Keys passed to f must not be modified.
+func (t *ART) Ascend(f func(k []byte, data any) bool) {
+	if t.root == nil {
+		return
+	}
+
+	ascend(t.root, nil, nil, nil, f)
+}
+
+// Range calls f for each key in the range [lo, hi] in ascending order until f returns false.
+// Keys passed to f must not be modified.
+func (t *ART) Range(lo, hi []byte, f func(k []byte, data any) bool) {
+	if t.root == nil || bytes.Compare(lo, hi) > 0 {
+		return
+	}
+
+	// Nil bounds are the same as empty keys
+	if lo == nil {
+		lo = []byte{}
+	}
+	if hi == nil {
+		hi = []byte{}
+	}
+
+	ascend(t.root, nil, lo, hi, f)
+}
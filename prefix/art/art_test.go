@@ -0,0 +1,539 @@
+package art
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Number of keys gives inner nodes with a few children and with hundreds of children
+	keysCount	=	4096
+	MaxItem		=	99999
+	// Seed of random sources of tests and benchmarks
+	testSeed	=	2034
+
+	// Self-test walks the whole tree, so it is run once per selfTestStep deletions
+	selfTestStep	=	64
+)
+
+// testStrings contains keys that are prefixes of each other
+//nolint:gochecknoglobals
+var testStrings = []string{
+	"", "a", "ab", "abc", "abcd", "abd", "b", "ba", "romane", "romanus", "romulus",
+	"rubens", "ruber", "rubicon", "rubicundus", "rub", "\x00", "\x00\x00", "\xff", "\xff\x00",
+}
+
+func sortedKeys(testKeys []KeyType) []KeyType {
+	keys := make([]KeyType, len(testKeys))
+	copy(keys, testKeys)
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	return keys
+}
+
+func newTreeTestKeys(t *testing.T, testKeys []KeyType) *ART {
+	t.Helper()
+
+	tree := NewART()
+	for i, k := range testKeys {
+		if !tree.Insert(IntKey(k), k) {
+			t.Fatalf("[%d] Insert(%d) returned false", i, k)
+		}
+	}
+
+	if _, err := tree.SelfTest(); err != nil {
+		t.Fatalf("Self-test of the tree failed: %v", err)
+	}
+
+	return tree
+}
+
+func TestKind(t *testing.T) {
+	for i, test := range []struct {
+		kind	Kind
+		want	string
+	} {
+		{ Node4, "Node4" },
+		{ Node16, "Node16" },
+		{ Node48, "Node48" },
+		{ Node256, "Node256" },
+	} {
+		if v := test.kind.String(); v != test.want {
+			t.Errorf("[%d] Kind.String() on %d, want - %q, got - %q", i, test.kind, test.want, v)
+		}
+	}
+}
+
+func TestIntKey(t *testing.T) {
+	keys := []KeyType{-1 << 63, -100, -1, 0, 1, 100, 1<<63 - 1}
+	for i, k := range keys {
+		if v := KeyInt(IntKey(k)); v != k {
+			t.Errorf("[%d] KeyInt(IntKey(%d)) returned %d", i, k, v)
+		}
+
+		if i > 0 && bytes.Compare(IntKey(keys[i-1]), IntKey(k)) >= 0 {
+			t.Errorf("[%d] IntKey(%d) is not less than IntKey(%d)", i, keys[i-1], k)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("KeyInt() did not panic on invalid key length")
+		}
+	}()
+
+	KeyInt([]byte{1, 2, 3})
+}
+
+func TestEmpty(t *testing.T) {
+	tree := NewART()
+
+	if data, ok := tree.Search(nil); ok {
+		t.Errorf("Search() on empty tree returned %v, true", data)
+	}
+
+	if data, ok := tree.Delete([]byte("abc")); ok {
+		t.Errorf("Delete() on empty tree returned %v, true", data)
+	}
+
+	if k, data, ok := tree.Min(); ok {
+		t.Errorf("Min() on empty tree returned %q, %v, true", k, data)
+	}
+
+	if k, data, ok := tree.Max(); ok {
+		t.Errorf("Max() on empty tree returned %q, %v, true", k, data)
+	}
+
+	tree.Ascend(func([]byte, any) bool {
+		t.Errorf("Ascend() on empty tree called the function")
+		return true
+	})
+
+	if height, err := tree.SelfTest(); err != nil || height != 0 {
+		t.Errorf("SelfTest() of empty tree returned %d, %v", height, err)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := newTreeTestKeys(t, testKeys)
+
+	if tree.Len() != len(testKeys) {
+		t.Errorf("Tree has size %d, want - %d", tree.Len(), len(testKeys))
+	}
+
+	for i, k := range testKeys {
+		if data, ok := tree.Search(IntKey(k)); !ok || data != k {
+			t.Fatalf("[%d] Search(%d) returned %v, %t", i, k, data, ok)
+		}
+	}
+
+	// Keys that do not exist
+	for i, k := range []KeyType{-1, MaxItem + 1, 1 << 40} {
+		if data, ok := tree.Search(IntKey(k)); ok {
+			t.Errorf("[%d] Search(%d) returned %v, true", i, k, data)
+		}
+	}
+}
+
+func TestInsertDupes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := newTreeTestKeys(t, testKeys)
+
+	for i, k := range testKeys {
+		if tree.Insert(IntKey(k), nil) {
+			t.Fatalf("[%d] Insert() of existing key %d returned true", i, k)
+		}
+	}
+
+	if tree.Len() != len(testKeys) {
+		t.Errorf("Tree has size %d after inserting dupes, want - %d", tree.Len(), len(testKeys))
+	}
+}
+
+func TestInsertStrings(t *testing.T) {
+	tree := NewART()
+	for i, k := range testStrings {
+		if !tree.Insert([]byte(k), k) {
+			t.Fatalf("[%d] Insert(%q) returned false", i, k)
+		}
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Fatalf("[%d] Self-test failed after Insert(%q): %v", i, k, err)
+		}
+	}
+
+	for i, k := range testStrings {
+		if tree.Insert([]byte(k), nil) {
+			t.Fatalf("[%d] Insert() of existing key %q returned true", i, k)
+		}
+
+		if data, ok := tree.Search([]byte(k)); !ok || data != k {
+			t.Fatalf("[%d] Search(%q) returned %v, %t", i, k, data, ok)
+		}
+	}
+
+	for i, k := range []string{"abcde", "ac", "roman", "c", "\x00\x00\x00", "\xfe"} {
+		if data, ok := tree.Search([]byte(k)); ok {
+			t.Errorf("[%d] Search(%q) returned %v, true", i, k, data)
+		}
+	}
+
+	// Keys are walked in lexicographic order
+	want := make([]string, len(testStrings))
+	copy(want, testStrings)
+	sort.Strings(want)
+
+	var got []string
+	tree.Ascend(func(k []byte, _ any) bool {
+		got = append(got, string(k))
+		return true
+	})
+
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Ascend() walked keys %q, want - %q", got, want)
+	}
+}
+
+func TestInsertCopiesKey(t *testing.T) {
+	tree := NewART()
+
+	key := []byte("abc")
+	tree.Insert(key, nil)
+	key[0] = 'x'
+
+	if _, ok := tree.Search([]byte("abc")); !ok {
+		t.Errorf("Modification of the inserted key changed the tree")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := newTreeTestKeys(t, testKeys)
+
+	// Delete keys in random order
+	keys := make([]KeyType, len(testKeys))
+	copy(keys, testKeys)
+	rnd.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+	for i, k := range keys {
+		if data, ok := tree.Delete(IntKey(k)); !ok || data != k {
+			t.Fatalf("[%d] Delete(%d) returned %v, %t", i, k, data, ok)
+		}
+
+		if data, ok := tree.Delete(IntKey(k)); ok {
+			t.Fatalf("[%d] Second Delete(%d) returned %v, true", i, k, data)
+		}
+
+		if data, ok := tree.Search(IntKey(k)); ok {
+			t.Fatalf("[%d] Search(%d) returned %v, true after deletion", i, k, data)
+		}
+
+		if i % selfTestStep == 0 {
+			if _, err := tree.SelfTest(); err != nil {
+				t.Fatalf("[%d] Self-test failed after Delete(%d): %v", i, k, err)
+			}
+		}
+	}
+
+	if tree.Len() != 0 || tree.root != nil {
+		t.Errorf("Tree is not empty after deleting all keys, size - %d", tree.Len())
+	}
+}
+
+func TestDeleteStrings(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	// Try different orders of deletion
+	for n := 0; n < 100; n++ {
+		tree := NewART()
+		for _, k := range testStrings {
+			tree.Insert([]byte(k), k)
+		}
+
+		keys := make([]string, len(testStrings))
+		copy(keys, testStrings)
+		rnd.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+		for i, k := range keys {
+			if data, ok := tree.Delete([]byte(k)); !ok || data != k {
+				t.Fatalf("[%d/%d] Delete(%q) returned %v, %t", n, i, k, data, ok)
+			}
+
+			if _, err := tree.SelfTest(); err != nil {
+				t.Fatalf("[%d/%d] Self-test failed after Delete(%q): %v", n, i, k, err)
+			}
+
+			// The rest keys are still in the tree
+			for _, rest := range keys[i+1:] {
+				if _, ok := tree.Search([]byte(rest)); !ok {
+					t.Fatalf("[%d/%d] Key %q is lost after Delete(%q)", n, i, rest, k)
+				}
+			}
+		}
+	}
+}
+
+func TestNodeKinds(t *testing.T) {
+	tree := NewART()
+
+	// All keys have the same prefix and differ by the last byte
+	key := func(b int) []byte { return []byte{'k', byte(b)} }
+
+	for b := 0; b < 256; b++ {
+		tree.Insert(key(b), b)
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Fatalf("[%d] Self-test failed after Insert(): %v", b, err)
+		}
+
+		want := Node4
+		switch {
+		case b >= 48:
+			want = Node256
+		case b >= 16:
+			want = Node48
+		case b >= 4:
+			want = Node16
+		}
+
+		if in, ok := tree.root.(*inner); b > 0 && (!ok || in.kind != want) {
+			t.Fatalf("[%d] Root is %T (%v), want - %v", b, tree.root, in, want)
+		}
+	}
+
+	for b := 255; b > 0; b-- {
+		tree.Delete(key(b))
+
+		if _, err := tree.SelfTest(); err != nil {
+			t.Fatalf("[%d] Self-test failed after Delete(): %v", b, err)
+		}
+
+		// Check that all the rest keys are available
+		for r := 0; r < b; r++ {
+			if data, ok := tree.Search(key(r)); !ok || data != r {
+				t.Fatalf("[%d] Search(%d) returned %v, %t", b, r, data, ok)
+			}
+		}
+	}
+
+	// Single leaf is left
+	if _, ok := tree.root.(*leaf); !ok {
+		t.Errorf("Root is %T, want - leaf", tree.root)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := newTreeTestKeys(t, testKeys)
+	keys := sortedKeys(testKeys)
+
+	if k, data, ok := tree.Min(); !ok || KeyInt(k) != keys[0] || data != keys[0] {
+		t.Errorf("Min() returned %x, %v, %t, want - %d", k, data, ok, keys[0])
+	}
+
+	if k, data, ok := tree.Max(); !ok || KeyInt(k) != keys[len(keys)-1] || data != keys[len(keys)-1] {
+		t.Errorf("Max() returned %x, %v, %t, want - %d", k, data, ok, keys[len(keys)-1])
+	}
+
+	// Key that ends on the inner node is the minimum of its subtree
+	tree = NewART()
+	for _, k := range testStrings {
+		tree.Insert([]byte(k), nil)
+	}
+	if k, _, _ := tree.Min(); string(k) != "" {
+		t.Errorf("Min() returned %q, want - empty key", k)
+	}
+	if k, _, _ := tree.Max(); string(k) != "\xff\x00" {
+		t.Errorf("Max() returned %q, want - %q", k, "\xff\x00")
+	}
+}
+
+func TestAscend(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := newTreeTestKeys(t, testKeys)
+	keys := sortedKeys(testKeys)
+
+	i := 0
+	tree.Ascend(func(k []byte, data any) bool {
+		if KeyInt(k) != keys[i] || data != keys[i] {
+			t.Fatalf("[%d] Ascend() passed %d, %v, want - %d", i, KeyInt(k), data, keys[i])
+		}
+		i++
+		return true
+	})
+
+	if i != len(keys) {
+		t.Errorf("Ascend() walked %d keys, want - %d", i, len(keys))
+	}
+
+	// Stop walking
+	count := 0
+	tree.Ascend(func([]byte, any) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 {
+		t.Errorf("Ascend() was not stopped, called %d times", count)
+	}
+}
+
+func TestRange(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	tree := newTreeTestKeys(t, testKeys)
+	keys := sortedKeys(testKeys)
+
+	for i, test := range []struct {
+		lo, hi	KeyType
+	} {
+		{ 0, MaxItem },
+		{ -100, 100 },
+		{ keys[100], keys[200] },
+		{ keys[100] + 1, keys[200] - 1 },
+		{ 50000, 50000 },
+		{ keys[500], keys[500] },
+		{ MaxItem + 1, MaxItem + 100 },
+		{ 100, 10 },
+	} {
+		var want []KeyType
+		for _, k := range keys {
+			if k >= test.lo && k <= test.hi {
+				want = append(want, k)
+			}
+		}
+
+		var got []KeyType
+		tree.Range(IntKey(test.lo), IntKey(test.hi), func(k []byte, _ any) bool {
+			got = append(got, KeyInt(k))
+			return true
+		})
+
+		if len(got) != len(want) {
+			t.Errorf("[%d] Range(%d, %d) returned %d keys, want - %d", i, test.lo, test.hi, len(got), len(want))
+			continue
+		}
+		for j := range want {
+			if got[j] != want[j] {
+				t.Errorf("[%d] Range(%d, %d) returned %d at position %d, want - %d",
+					i, test.lo, test.hi, got[j], j, want[j])
+				break
+			}
+		}
+	}
+}
+
+func TestRangeStrings(t *testing.T) {
+	tree := NewART()
+	for _, k := range testStrings {
+		tree.Insert([]byte(k), nil)
+	}
+
+	sorted := make([]string, len(testStrings))
+	copy(sorted, testStrings)
+	sort.Strings(sorted)
+
+	for i, test := range []struct {
+		lo, hi	string
+	} {
+		{ "", "\xff\xff" },
+		{ "a", "b" },
+		{ "ab", "abz" },
+		{ "abc", "abc" },
+		{ "r", "rubicon" },
+		{ "roman", "romb" },
+		{ "c", "q" },
+		{ "", "" },
+	} {
+		var want []string
+		for _, k := range sorted {
+			if k >= test.lo && k <= test.hi {
+				want = append(want, k)
+			}
+		}
+
+		var got []string
+		tree.Range([]byte(test.lo), []byte(test.hi), func(k []byte, _ any) bool {
+			got = append(got, string(k))
+			return true
+		})
+
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("[%d] Range(%q, %q) returned %q, want - %q", i, test.lo, test.hi, got, want)
+		}
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	for i, test := range []struct {
+		breaker	func(tree *ART)
+		want	string
+	} {
+		{
+			breaker:	func(tree *ART) { tree.size++ },
+			want:		"v#1: tree contains",
+		},
+		{
+			breaker:	func(tree *ART) { tree.root.(*inner).prefix = []byte{0xff} },
+			want:		"v#2: key",
+		},
+		{
+			breaker:	func(tree *ART) { tree.root.(*inner).count++ },
+			want:		"v#3: ",
+		},
+		{
+			breaker:	func(tree *ART) { tree.root.(*inner).kind = Node4 },
+			want:		"v#4: Node4 node",
+		},
+		{
+			breaker:	func(tree *ART) {
+				// Wrap the root by the node with a single child
+				in := newInner(nil)
+				in.addChild(0, tree.root)
+				tree.root = in
+			},
+			want:		"v#5: node",
+		},
+		{
+			breaker:	func(tree *ART) {
+				in := tree.root.(*inner)
+				in.keys[0], in.keys[1] = in.keys[1], in.keys[0]
+			},
+			want:		"v#6: keys",
+		},
+	} {
+		tree := NewART()
+		for _, k := range testStrings {
+			tree.Insert([]byte(k), nil)
+		}
+		test.breaker(tree)
+
+		height, err := tree.SelfTest()
+		if err == nil {
+			t.Errorf("[%d] SelfTest() did not detect the problem", i)
+			continue
+		}
+		if height != 0 {
+			t.Errorf("[%d] SelfTest() returned non-zero height %d with error", i, height)
+		}
+		if !strings.HasPrefix(err.Error(), test.want) {
+			t.Errorf("[%d] SelfTest() returned %q, want prefix - %q", i, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package art
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+func newRBTreeTestKeys(testKeys []KeyType) *rbtree.RBTree {
+	tree := rbtree.NewRBTree()
+	for _, k := range testKeys {
+		tree.Insert(rbtree.NewRBNode(k, k))
+	}
+
+	return tree
+}
+
+func encodedTestKeys(testKeys []KeyType) [][]byte {
+	keys := make([][]byte, 0, len(testKeys))
+	for _, k := range testKeys {
+		keys = append(keys, IntKey(k))
+	}
+
+	return keys
+}
+
+func BenchmarkInsert(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	keys := encodedTestKeys(testKeys)
+
+	b.Run("ART", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			tree := NewART()
+			for i, k := range keys {
+				tree.Insert(k, testKeys[i])
+			}
+		}
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			newRBTreeTestKeys(testKeys)
+		}
+	})
+}
+
+func BenchmarkSearch(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	keys := encodedTestKeys(testKeys)
+
+	b.Run("ART", func(b *testing.B) {
+		tree := NewART()
+		for i, k := range keys {
+			tree.Insert(k, testKeys[i])
+		}
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			for _, k := range keys {
+				tree.Search(k)
+			}
+		}
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		tree := newRBTreeTestKeys(testKeys)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			for _, k := range testKeys {
+				tree.Search(k)
+			}
+		}
+	})
+}
+
+func BenchmarkAscend(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	keys := encodedTestKeys(testKeys)
+
+	b.Run("ART", func(b *testing.B) {
+		tree := NewART()
+		for i, k := range keys {
+			tree.Insert(k, testKeys[i])
+		}
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			tree.Ascend(func([]byte, any) bool { return true })
+		}
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		tree := newRBTreeTestKeys(testKeys)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			for nd := tree.Min(); nd != nil; nd = tree.Successor(nd) {
+				// Just walk through all nodes
+			}
+		}
+	})
+}
+
+func BenchmarkRange(b *testing.B) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := randkeys.Unique(rnd, keysCount, KeyType(MaxItem))
+
+	keys := encodedTestKeys(testKeys)
+	// About 10% of keys
+	lo, hi := KeyType(40000), KeyType(50000)
+
+	b.Run("ART", func(b *testing.B) {
+		tree := NewART()
+		for i, k := range keys {
+			tree.Insert(k, testKeys[i])
+		}
+		loKey, hiKey := IntKey(lo), IntKey(hi)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			tree.Range(loKey, hiKey, func([]byte, any) bool { return true })
+		}
+	})
+
+	b.Run("RBTree", func(b *testing.B) {
+		tree := newRBTreeTestKeys(testKeys)
+		b.ResetTimer()
+
+		for n := 0; n < b.N; n++ {
+			// Find the first node in the range by walking from the root
+			var first *rbtree.RBNode
+			for nd := tree.Root(); nd != nil; {
+				if nd.Key() >= lo {
+					first, nd = nd, nd.Left()
+				} else {
+					nd = nd.Right()
+				}
+			}
+
+			for nd := first; nd != nil && nd.Key() <= hi; nd = tree.Successor(nd) {
+				// Just walk through the range
+			}
+		}
+	})
+}
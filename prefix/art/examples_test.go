@@ -0,0 +1,46 @@
+package art
+
+import "fmt"
+
+func Example_intKeys() {
+	tree := NewART()
+
+	// Insert integer keys encoded with the order preserving
+	for _, k := range []KeyType{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27} {
+		tree.Insert(IntKey(k), fmt.Sprintf("Value for key %v", k))
+	}
+
+	// Walk the range of keys
+	tree.Range(IntKey(10), IntKey(25), func(k []byte, data any) bool {
+		fmt.Println(KeyInt(k), "-", data)
+		return true
+	})
+
+	// Output:
+	// 10 - Value for key 10
+	// 13 - Value for key 13
+	// 15 - Value for key 15
+	// 17 - Value for key 17
+	// 20 - Value for key 20
+	// 23 - Value for key 23
+	// 25 - Value for key 25
+}
+
+func Example_byteKeys() {
+	tree := NewART()
+	for _, k := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"} {
+		tree.Insert([]byte(k), len(k))
+	}
+
+	minKey, _, _ := tree.Min()
+	maxKey, _, _ := tree.Max()
+	fmt.Printf("min: %s, max: %s\n", minKey, maxKey)
+
+	if data, ok := tree.Search([]byte("rubicon")); ok {
+		fmt.Println("rubicon:", data)
+	}
+
+	// Output:
+	// min: romane, max: rubicundus
+	// rubicon: 7
+}
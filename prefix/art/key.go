@@ -0,0 +1,33 @@
+package art
+
+import (
+	"encoding/binary"
+
+	"github.com/r-che/algorithms/bst/rbtree"
+)
+
+// KeyType is the integer key type, the same as the key type of
+// the red-black tree to make comparisons between the trees possible
+type KeyType = rbtree.KeyType
+
+// intKeyLen is the length of encoded integer keys
+const intKeyLen = 8
+
+// IntKey encodes the integer key k to the byte key with the same order, i.e. for any keys
+// a < b the encoded key of a is lexicographically less than the encoded key of b.
+func IntKey(k KeyType) []byte {
+	key := make([]byte, intKeyLen)
+	// Flip the sign bit to place negative numbers before positive ones
+	binary.BigEndian.PutUint64(key, uint64(k) ^ (1 << 63))
+
+	return key
+}
+
+// KeyInt decodes the integer key encoded by IntKey. It panics if the length of key is not 8 bytes.
+func KeyInt(key []byte) KeyType {
+	if len(key) != intKeyLen {
+		panic("invalid length of the integer key")
+	}
+
+	return KeyType(binary.BigEndian.Uint64(key) ^ (1 << 63))
+}
@@ -0,0 +1,397 @@
+package art
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// Kind is the kind of the inner node of the tree
+type Kind int
+const (
+	// Node4 stores up to 4 children with sorted keys
+	Node4 = Kind(iota)
+	// Node16 stores up to 16 children with sorted keys
+	Node16
+	// Node48 stores up to 48 children and the 256-bytes index of them
+	Node48
+	// Node256 stores up to 256 children directly indexed by key byte
+	Node256
+)
+
+func (k Kind) String() string {
+	switch k {
+		case Node4:		return "Node4"
+		case Node16:	return "Node16"
+		case Node48:	return "Node48"
+		case Node256:	return "Node256"
+	}
+
+	panic(fmt.Sprintf("Unexpected node kind value: %d", k))
+}
+
+// capacity returns the maximal number of children of the node kind
+func (k Kind) capacity() int {
+	switch k {
+		case Node4:		return 4
+		case Node16:	return 16
+		case Node48:	return 48
+		case Node256:	return 256
+	}
+
+	panic(fmt.Sprintf("Unexpected node kind value: %d", k))
+}
+
+// shrinkLimit returns the number of children when the node should be shrunk to the
+// smaller kind. It is less than the capacity of the smaller kind to avoid shrinking
+// and growing of the node when a child is inserted and deleted repeatedly.
+func (k Kind) shrinkLimit() int {
+	switch k {
+		case Node16:	return 3
+		case Node48:	return 12
+		case Node256:	return 37
+	}
+
+	return -1
+}
+
+// node is either the *leaf or the *inner node of the tree
+type node interface {
+	// minimum returns the leaf with the minimal key in the subtree
+	minimum() *leaf
+	// maximum returns the leaf with the maximal key in the subtree
+	maximum() *leaf
+}
+
+// leaf holds the key and associated data
+type leaf struct {
+	key		[]byte
+	data	any
+}
+
+func (l *leaf) minimum() *leaf {
+	return l
+}
+
+func (l *leaf) maximum() *leaf {
+	return l
+}
+
+// inner implements the inner node of the tree. The layout of keys and children depends on the kind:
+//
+//	Node4, Node16 - keys and children are stored at the same positions, keys are sorted
+//	Node48        - keys is the 256-bytes index, keys[b] is the position of the child for the byte b plus one
+//	Node256       - keys is not used, children[b] is the child for the byte b
+type inner struct {
+	kind		Kind
+	// Compressed path - bytes of keys that are the same for all keys in the subtree
+	prefix		[]byte
+	// Leaf with the key that ends on this node, if any
+	term		*leaf
+
+	// Number of children
+	count		int
+	keys		[]byte
+	children	[]node
+}
+
+func newInner(prefix []byte) *inner {
+	return &inner{
+		kind:		Node4,
+		prefix:		prefix,
+		keys:		make([]byte, 0, Node4.capacity()),
+		children:	make([]node, 0, Node4.capacity()),
+	}
+}
+
+func (n *inner) minimum() *leaf {
+	if n.term != nil {
+		return n.term
+	}
+
+	_, c := n.firstChild()
+	return c.minimum()
+}
+
+func (n *inner) maximum() *leaf {
+	if n.count == 0 {
+		return n.term
+	}
+
+	_, c := n.lastChild()
+	return c.maximum()
+}
+
+// prefixMismatch returns the number of bytes of the node prefix that match key starting from depth
+func (n *inner) prefixMismatch(key []byte, depth int) int {
+	i := 0
+	for i < len(n.prefix) && depth + i < len(key) && n.prefix[i] == key[depth+i] {
+		i++
+	}
+
+	return i
+}
+
+// sortedPos returns the position of the key b in the sorted keys of Node4 or Node16 and true if it exists
+func (n *inner) sortedPos(b byte) (int, bool) {
+	var i int
+	if n.kind == Node4 {
+		// Linear search is faster on few keys
+		for i < n.count && n.keys[i] < b {
+			i++
+		}
+	} else {
+		i = sort.Search(n.count, func(i int) bool { return n.keys[i] >= b })
+	}
+
+	return i, i < n.count && n.keys[i] == b
+}
+
+// findChild returns the reference to the child for the byte b or nil if there is no such child
+func (n *inner) findChild(b byte) *node {
+	switch n.kind {
+	case Node4, Node16:
+		if i, ok := n.sortedPos(b); ok {
+			return &n.children[i]
+		}
+	case Node48:
+		if i := n.keys[b]; i != 0 {
+			return &n.children[i-1]
+		}
+	case Node256:
+		if n.children[b] != nil {
+			return &n.children[b]
+		}
+	}
+
+	return nil
+}
+
+// addChild adds the child c for the byte b, the node grows to the larger kind if it is full
+func (n *inner) addChild(b byte, c node) {
+	if n.count == n.kind.capacity() {
+		n.grow()
+	}
+
+	switch n.kind {
+	case Node4, Node16:
+		i, _ := n.sortedPos(b)
+		n.keys = append(n.keys, 0)
+		n.children = append(n.children, nil)
+		copy(n.keys[i+1:], n.keys[i:])
+		copy(n.children[i+1:], n.children[i:])
+		n.keys[i], n.children[i] = b, c
+	case Node48:
+		// Find a free slot
+		i := 0
+		for n.children[i] != nil {
+			i++
+		}
+		n.children[i] = c
+		n.keys[b] = byte(i + 1)
+	case Node256:
+		n.children[b] = c
+	}
+
+	n.count++
+}
+
+// removeChild removes the child for the byte b, the node shrinks to the smaller kind if it has few children
+func (n *inner) removeChild(b byte) {
+	switch n.kind {
+	case Node4, Node16:
+		i, _ := n.sortedPos(b)
+		copy(n.keys[i:], n.keys[i+1:])
+		copy(n.children[i:], n.children[i+1:])
+		n.children[n.count-1] = nil
+		n.keys = n.keys[:n.count-1]
+		n.children = n.children[:n.count-1]
+	case Node48:
+		n.children[n.keys[b]-1] = nil
+		n.keys[b] = 0
+	case Node256:
+		n.children[b] = nil
+	}
+
+	n.count--
+
+	if n.count <= n.kind.shrinkLimit() {
+		n.shrink()
+	}
+}
+
+// grow converts the node to the next larger kind
+func (n *inner) grow() {
+	switch n.kind {
+	case Node4:
+		keys := make([]byte, n.count, Node16.capacity())
+		children := make([]node, n.count, Node16.capacity())
+		copy(keys, n.keys)
+		copy(children, n.children)
+		n.kind, n.keys, n.children = Node16, keys, children
+
+	case Node16:
+		keys := make([]byte, 256)
+		children := make([]node, Node48.capacity())
+		for i := 0; i < n.count; i++ {
+			keys[n.keys[i]] = byte(i + 1)
+			children[i] = n.children[i]
+		}
+		n.kind, n.keys, n.children = Node48, keys, children
+
+	case Node48:
+		children := make([]node, Node256.capacity())
+		for b, i := range n.keys {
+			if i != 0 {
+				children[b] = n.children[i-1]
+			}
+		}
+		n.kind, n.keys, n.children = Node256, nil, children
+
+	case Node256:
+		panic("Node256 cannot grow")
+	}
+}
+
+// shrink converts the node to the next smaller kind
+func (n *inner) shrink() {
+	switch n.kind {
+	case Node16:
+		keys := make([]byte, n.count, Node4.capacity())
+		children := make([]node, n.count, Node4.capacity())
+		copy(keys, n.keys)
+		copy(children, n.children)
+		n.kind, n.keys, n.children = Node4, keys, children
+
+	case Node48:
+		keys := make([]byte, 0, Node16.capacity())
+		children := make([]node, 0, Node16.capacity())
+		for b, i := range n.keys {
+			if i != 0 {
+				keys = append(keys, byte(b))
+				children = append(children, n.children[i-1])
+			}
+		}
+		n.kind, n.keys, n.children = Node16, keys, children
+
+	case Node256:
+		keys := make([]byte, 256)
+		children := make([]node, 0, Node48.capacity())
+		for b, c := range n.children {
+			if c != nil {
+				children = append(children, c)
+				keys[b] = byte(len(children))
+			}
+		}
+		n.kind, n.keys, n.children = Node48, keys, children[:Node48.capacity()]
+
+	case Node4:
+		panic("Node4 cannot shrink")
+	}
+}
+
+// eachChild calls f for each child in ascending order of bytes until f returns false.
+// It returns false if the iteration was stopped by f.
+func (n *inner) eachChild(f func(b byte, c node) bool) bool {
+	switch n.kind {
+	case Node4, Node16:
+		for i := range n.children {
+			if !f(n.keys[i], n.children[i]) {
+				return false
+			}
+		}
+	case Node48:
+		for b, i := range n.keys {
+			if i != 0 && !f(byte(b), n.children[i-1]) {
+				return false
+			}
+		}
+	case Node256:
+		for b, c := range n.children {
+			if c != nil && !f(byte(b), c) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// firstChild returns the child with the least byte
+func (n *inner) firstChild() (byte, node) {
+	var fb byte
+	var fc node
+	n.eachChild(func(b byte, c node) bool {
+		fb, fc = b, c
+		return false
+	})
+
+	return fb, fc
+}
+
+// lastChild returns the child with the greatest byte
+func (n *inner) lastChild() (byte, node) {
+	switch n.kind {
+	case Node4, Node16:
+		return n.keys[n.count-1], n.children[n.count-1]
+	case Node48:
+		for b := len(n.keys) - 1; b >= 0; b-- {
+			if i := n.keys[b]; i != 0 {
+				return byte(b), n.children[i-1]
+			}
+		}
+	case Node256:
+		for b := len(n.children) - 1; b >= 0; b-- {
+			if n.children[b] != nil {
+				return byte(b), n.children[b]
+			}
+		}
+	}
+
+	return 0, nil
+}
+
+// ascend does in-order walk of the subtree with root n, path is the bytes of keys
+// before the subtree. Subtrees with all keys out of the range [lo, hi] are skipped,
+// lo and hi are not checked if nil. It returns false if the walk was stopped.
+func ascend(n node, path, lo, hi []byte, f func([]byte, any) bool) bool {
+	if l, ok := n.(*leaf); ok {
+		if lo != nil && bytes.Compare(l.key, lo) < 0 {
+			return true
+		}
+		if hi != nil && bytes.Compare(l.key, hi) > 0 {
+			// All next keys are out of range
+			return false
+		}
+
+		return f(l.key, l.data)
+	}
+
+	in := n.(*inner)	//nolint:forcetypeassert
+	path = append(path, in.prefix...)
+
+	// All keys of the subtree start with path
+	if lo != nil && bytes.Compare(path, truncate(lo, len(path))) < 0 {
+		return true
+	}
+	if hi != nil && bytes.Compare(path, truncate(hi, len(path))) > 0 {
+		return false
+	}
+
+	if in.term != nil && !ascend(in.term, path, lo, hi, f) {
+		return false
+	}
+
+	return in.eachChild(func(b byte, c node) bool {
+		return ascend(c, append(path, b), lo, hi, f)
+	})
+}
+
+// truncate returns the first n bytes of key or the whole key if it is shorter
+func truncate(key []byte, n int) []byte {
+	if len(key) > n {
+		return key[:n]
+	}
+
+	return key
+}
@@ -0,0 +1,102 @@
+package art
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// SelfTest performs a self-test of the tree and returns the height of the tree,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero.
+func (t *ART) SelfTest() (int, error) {
+	if t.root == nil {
+		if t.size != 0 {
+			return 0, fmt.Errorf("v#1: tree is empty but its size is %d", t.size)
+		}
+
+		return 0, nil
+	}
+
+	count := 0
+	height, err := check(t.root, nil, &count)
+	if err != nil {
+		return 0, err
+	}
+
+	if count != t.size {
+		return 0, fmt.Errorf("v#1: tree contains %d keys but its size is %d", count, t.size)
+	}
+
+	return height, nil
+}
+
+// check checks the subtree with root n, path is the bytes of keys before the
+// subtree. It counts keys of the subtree and returns its height.
+func check(n node, path []byte, count *int) (int, error) {
+	if l, ok := n.(*leaf); ok {
+		if !bytes.HasPrefix(l.key, path) {
+			return 0, fmt.Errorf("v#2: key %x is placed on the path %x", l.key, path)
+		}
+		*count++
+
+		return 1, nil
+	}
+
+	in := n.(*inner)	//nolint:forcetypeassert
+	path = append(path, in.prefix...)
+
+	if in.term != nil && !bytes.Equal(in.term.key, path) {
+		return 0, fmt.Errorf("v#2: key %x ends on the path %x", in.term.key, path)
+	}
+
+	// Count children and check the kind of the node
+	children := 0
+	in.eachChild(func(byte, node) bool {
+		children++
+		return true
+	})
+	if children != in.count {
+		return 0, fmt.Errorf("v#3: %v node on the path %x has %d children but its count is %d",
+			in.kind, path, children, in.count)
+	}
+	if in.count > in.kind.capacity() || in.count <= in.kind.shrinkLimit() {
+		return 0, fmt.Errorf("v#4: %v node on the path %x has %d children", in.kind, path, in.count)
+	}
+
+	// Inner node must have at least one child and one more child or the key that ends on it
+	if in.count == 0 || (in.term == nil && in.count < 2) {
+		return 0, fmt.Errorf("v#5: node on the path %x is not required and should be collapsed", path)
+	}
+
+	// Sorted kinds must have ascending keys
+	if in.kind == Node4 || in.kind == Node16 {
+		for i := 1; i < in.count; i++ {
+			if in.keys[i-1] >= in.keys[i] {
+				return 0, fmt.Errorf("v#6: keys %x and %x of the %v node on the path %x are not in ascending order",
+					in.keys[i-1], in.keys[i], in.kind, path)
+			}
+		}
+	}
+
+	if in.term != nil {
+		*count++
+	}
+
+	height := 0
+	var err error
+	in.eachChild(func(b byte, c node) bool {
+		var ch int
+		if ch, err = check(c, append(path, b), count); err != nil {
+			return false
+		}
+		if ch > height {
+			height = ch
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return height + 1, nil
+}
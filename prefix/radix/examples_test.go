@@ -0,0 +1,42 @@
+package radix
+
+import "fmt"
+
+func Example_routeTable() {
+	// Routing table - prefixes of addresses and gateways
+	routes := NewRadixTree()
+	routes.Insert("10.", "gw-private")
+	routes.Insert("10.1.", "gw-office")
+	routes.Insert("10.1.2.", "gw-lab")
+	routes.Insert("", "gw-default")
+
+	for _, addr := range []string{"10.1.2.3", "10.1.7.1", "10.9.9.9", "192.168.0.1"} {
+		prefix, gw, _ := routes.LongestPrefix(addr)
+		fmt.Printf("%-12s -> %-10v (prefix %q)\n", addr, gw, prefix)
+	}
+
+	// Output:
+	// 10.1.2.3     -> gw-lab     (prefix "10.1.2.")
+	// 10.1.7.1     -> gw-office  (prefix "10.1.")
+	// 10.9.9.9     -> gw-private (prefix "10.")
+	// 192.168.0.1  -> gw-default (prefix "")
+}
+
+func Example_prefixIteration() {
+	tree := NewRadixTree()
+	for _, k := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"} {
+		tree.Insert(k, len(k))
+	}
+
+	// Walk all keys that start with "rub" in lexicographic order
+	tree.WalkPrefix("rub", func(k string, data any) bool {
+		fmt.Println(k, data)
+		return true
+	})
+
+	// Output:
+	// rubens 6
+	// ruber 5
+	// rubicon 7
+	// rubicundus 10
+}
@@ -0,0 +1,105 @@
+package radix
+
+import (
+	"sort"
+	"strconv"
+)
+
+// node implements a node of the radix tree, each node corresponds to a non-empty part of the key
+type node struct {
+	prefix		string
+	// Children in ascending order of the first bytes of their prefixes
+	children	[]*node
+
+	// The path from the root to the node is a key stored in the tree
+	terminal	bool
+	data		any
+}
+
+func (n *node) String() string {
+	if n == nil {
+		return "<nil>"
+	}
+
+	// Quote non-printable characters
+	label := strconv.Quote(n.prefix)
+	label = label[1:len(label)-1]
+
+	if n.terminal {
+		return label + "$"
+	}
+
+	return label
+}
+
+// child returns the index of the child with prefix started from the byte b and true,
+// or the index where the child with such prefix should be inserted and false
+func (n *node) child(b byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].prefix[0] >= b })
+
+	return i, i < len(n.children) && n.children[i].prefix[0] == b
+}
+
+// insertChild inserts the child c at the position i
+func (n *node) insertChild(i int, c *node) {
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = c
+}
+
+// removeChild removes the child at the position i
+func (n *node) removeChild(i int) {
+	copy(n.children[i:], n.children[i+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
+
+// split splits the prefix of the node at the position i, the node keeps the first
+// part of the prefix and gets the single child with the rest of the prefix
+func (n *node) split(i int) {
+	c := &node{
+		prefix:		n.prefix[i:],
+		children:	n.children,
+		terminal:	n.terminal,
+		data:		n.data,
+	}
+
+	n.prefix = n.prefix[:i]
+	n.children = []*node{c}
+	n.terminal, n.data = false, nil
+}
+
+// mergeChild merges the node with its single child
+func (n *node) mergeChild() {
+	c := n.children[0]
+
+	n.prefix += c.prefix
+	n.children = c.children
+	n.terminal, n.data = c.terminal, c.data
+}
+
+// walk calls f for each key of the subtree with root n in lexicographic order,
+// key is the path to n including its prefix. It returns false if the walk was stopped by f.
+func (n *node) walk(key []byte, f func(string, any) bool) bool {
+	if n.terminal && !f(string(key), n.data) {
+		return false
+	}
+
+	for _, c := range n.children {
+		if !c.walk(append(key, c.prefix...), f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// commonPrefix returns the length of the common prefix of a and b
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return i
+}
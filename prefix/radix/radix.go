@@ -0,0 +1,229 @@
+/*
+Package radix provides an example of a radix tree (compressed trie)
+implementation for string keys.
+
+Unlike the trie, where each node corresponds to a single byte of a key, the
+chains of nodes with a single child are compressed into one node that holds
+the whole part of the key. It significantly reduces the number of nodes and
+the memory usage when keys are long and sparse, e.g. URL paths or domain names.
+The tree supports prefix-based lookups, such as finding the longest stored
+prefix of a string (e.g. in routing tables) and iterating over all keys with a
+given prefix.
+
+It supports output of graphical representation of the tree using ASCII
+graphics. The root is printed as ^ and the nodes that terminate keys are
+marked by $. For example, a tree with the keys "romane", "romanus", "romulus",
+"rubens", "ruber", "rubicon", "rubicundus" will look like this:
+
+	            ^
+
+	            |
+	            r
+	      _____________
+	     /             \
+	     om            ub
+	   ______       _______
+	  /      \     /       \
+	  an   ulus$   e       ic
+	 ___           __     ____
+	/   \         /  \   /    \
+	e$ us$       ns$ r$ on$ undus$
+*/
+package radix
+
+// RadixTree implements a radix tree.
+type RadixTree struct {
+	root	*node
+	size	int
+}
+
+// NewRadixTree returns new empty radix tree.
+func NewRadixTree() *RadixTree {
+	return &RadixTree{root: &node{}}
+}
+
+// Len returns the number of keys in the tree.
+func (t *RadixTree) Len() int {
+	return t.size
+}
+
+// Insert inserts key k with associated data into the tree. It returns false if the
+// key already exists in the tree, in this case the tree is not modified.
+func (t *RadixTree) Insert(k string, data any) bool {
+	n := t.root
+	for len(k) != 0 {
+		ci, ok := n.child(k[0])
+		if !ok {
+			// No suitable child - add new leaf with the rest of the key
+			n.insertChild(ci, &node{prefix: k, terminal: true, data: data})
+			t.size++
+
+			return true
+		}
+
+		c := n.children[ci]
+		cp := commonPrefix(c.prefix, k)
+		if cp < len(c.prefix) {
+			// The key diverges in the middle of the child prefix
+			c.split(cp)
+		}
+
+		n, k = c, k[cp:]
+	}
+
+	if n.terminal {
+		// Already exists
+		return false
+	}
+
+	n.terminal, n.data = true, data
+	t.size++
+
+	return true
+}
+
+// Get returns the data associated with key k and true, or nil and false if there is no such key.
+func (t *RadixTree) Get(k string) (any, bool) {
+	n := t.root
+	for len(k) != 0 {
+		ci, ok := n.child(k[0])
+		if !ok {
+			return nil, false
+		}
+
+		c := n.children[ci]
+		if len(k) < len(c.prefix) || k[:len(c.prefix)] != c.prefix {
+			return nil, false
+		}
+
+		n, k = c, k[len(c.prefix):]
+	}
+
+	if !n.terminal {
+		return nil, false
+	}
+
+	return n.data, true
+}
+
+// Delete deletes key k from the tree and returns the data associated with the key
+// and true, or nil and false if there is no such key. The tree is kept compressed.
+func (t *RadixTree) Delete(k string) (any, bool) {
+	var parent *node
+	var ci int
+
+	n := t.root
+	for len(k) != 0 {
+		i, ok := n.child(k[0])
+		if !ok {
+			return nil, false
+		}
+
+		c := n.children[i]
+		if len(k) < len(c.prefix) || k[:len(c.prefix)] != c.prefix {
+			return nil, false
+		}
+
+		parent, ci = n, i
+		n, k = c, k[len(c.prefix):]
+	}
+
+	if !n.terminal {
+		return nil, false
+	}
+
+	data := n.data
+	n.terminal, n.data = false, nil
+	t.size--
+
+	if n == t.root {
+		// Root is never removed or merged
+		return data, true
+	}
+
+	switch len(n.children) {
+	case 0:
+		// The node is not required anymore
+		parent.removeChild(ci)
+
+		// Parent may become the non-terminal node with single child
+		if parent != t.root && !parent.terminal && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		// The node is just a part of the path to its child
+		n.mergeChild()
+	}
+
+	return data, true
+}
+
+// LongestPrefix returns the longest key stored in the tree that is a prefix of s,
+// its associated data and true, or an empty string, nil and false if there is no such key.
+func (t *RadixTree) LongestPrefix(s string) (string, any, bool) {
+	var found bool
+	var key string
+	var data any
+
+	n, pos := t.root, 0
+	for {
+		if n.terminal {
+			key, data, found = s[:pos], n.data, true
+		}
+
+		if pos == len(s) {
+			break
+		}
+
+		ci, ok := n.child(s[pos])
+		if !ok {
+			break
+		}
+
+		c := n.children[ci]
+		if rest := s[pos:]; len(rest) < len(c.prefix) || rest[:len(c.prefix)] != c.prefix {
+			break
+		}
+
+		n, pos = c, pos + len(c.prefix)
+	}
+
+	return key, data, found
+}
+
+// WalkPrefix calls f for each key that starts with prefix in lexicographic order until
+// f returns false. An empty prefix means all keys of the tree.
+func (t *RadixTree) WalkPrefix(prefix string, f func(k string, data any) bool) {
+	n, key := t.root, []byte{}
+	for rest := prefix; len(rest) != 0; {
+		ci, ok := n.child(rest[0])
+		if !ok {
+			return
+		}
+
+		c := n.children[ci]
+		cp := commonPrefix(c.prefix, rest)
+		if cp < len(rest) && cp < len(c.prefix) {
+			// The prefix diverges from the child prefix
+			return
+		}
+
+		// Otherwise, the prefix continues after the child prefix or ends inside
+		// it, in the latter case all keys of the child subtree start with the prefix
+
+		n, key, rest = c, append(key, c.prefix...), rest[cp:]
+	}
+
+	n.walk(key, f)
+}
+
+// Keys returns all keys of the tree in lexicographic order.
+func (t *RadixTree) Keys() []string {
+	keys := make([]string, 0, t.size)
+	t.WalkPrefix("", func(k string, _ any) bool {
+		keys = append(keys, k)
+		return true
+	})
+
+	return keys
+}
@@ -0,0 +1,39 @@
+package radix
+
+import (
+	"testing"
+)
+
+func TestStringFilled(t *testing.T) {
+	tr := NewRadixTree()
+	for _, k := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "\x01"} {
+		tr.Insert(k, nil)
+	}
+
+	want :=
+`          ^
+   _______________
+  /               \
+\x01$             r
+            _____________
+           /             \
+           om            ub
+         ______       _______
+        /      \     /       \
+        an   ulus$   e       ic
+       ___           __     ____
+      /   \         /  \   /    \
+      e$ us$       ns$ r$ on$ undus$
+`
+
+	if tStr := tr.String(); tStr != want {
+		t.Errorf("RadixTree.String() returned:\n---\n%s\n---\nWant:\n---\n%s\n---\n", tStr, want)
+	}
+}
+
+func TestStringEmpty(t *testing.T) {
+	tr := NewRadixTree()
+	if tStr := tr.String(); tStr != strEmptyTree {
+		t.Errorf("RadixTree.String() returned:\n---\n%s\n---\nWant:\n---\n%s\n---\n", tStr, strEmptyTree)
+	}
+}
@@ -0,0 +1,65 @@
+package radix
+
+import "fmt"
+
+// SelfTest performs a self-test of the tree and returns the height of the tree,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero.
+func (t *RadixTree) SelfTest() (int, error) {
+	if t.root.prefix != "" {
+		return 0, fmt.Errorf("v#2: root has non-empty prefix %q", t.root.prefix)
+	}
+
+	count := 0
+	height, err := t.root.check(true, &count)
+	if err != nil {
+		return 0, err
+	}
+
+	if count != t.size {
+		return 0, fmt.Errorf("v#1: tree contains %d keys but its size is %d", count, t.size)
+	}
+
+	return height, nil
+}
+
+// check checks the subtree with root n, counts its keys and returns its height
+func (n *node) check(root bool, count *int) (int, error) {
+	if n.terminal {
+		*count++
+	}
+
+	if !root {
+		switch {
+		case n.prefix == "":
+			return 0, fmt.Errorf("v#2: non-root node has empty prefix")
+		case !n.terminal && len(n.children) == 0:
+			return 0, fmt.Errorf("v#3: node %v does not lead to any key", n)
+		case !n.terminal && len(n.children) == 1:
+			return 0, fmt.Errorf("v#4: non-terminal node %v with single child is not compressed", n)
+		}
+	}
+
+	height := 0
+	for i, c := range n.children {
+		if c.prefix != "" && i > 0 && n.children[i-1].prefix[0] >= c.prefix[0] {
+			return 0, fmt.Errorf("v#5: children %v and %v of node %v are not in ascending order",
+				n.children[i-1], c, n)
+		}
+
+		ch, err := c.check(false, count)
+		if err != nil {
+			return 0, err
+		}
+		if ch > height {
+			height = ch
+		}
+	}
+
+	if root {
+		// Root does not correspond to any part of keys
+		return height, nil
+	}
+
+	return height + 1, nil
+}
@@ -0,0 +1,77 @@
+package trie
+
+import (
+	"fmt"
+	"sort"
+)
+
+// node implements a node of the trie, each node corresponds to a single byte of the key
+type node struct {
+	label		byte
+	// Children in ascending order of labels
+	children	[]*node
+
+	// The path from the root to the node is a key stored in the trie
+	terminal	bool
+	data		any
+}
+
+func (n *node) String() string {
+	if n == nil {
+		return "<nil>"
+	}
+
+	label := string(n.label)
+	if n.label < ' ' || n.label > '~' {
+		// Non-printable byte
+		label = fmt.Sprintf(`\x%02x`, n.label)
+	}
+
+	if n.terminal {
+		return label + "$"
+	}
+
+	return label
+}
+
+// child returns the index of the child with label b and true, or the index
+// where the child with such label should be inserted and false
+func (n *node) child(b byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label >= b })
+
+	return i, i < len(n.children) && n.children[i].label == b
+}
+
+// addChild inserts the new child with label b at the position i and returns it
+func (n *node) addChild(i int, b byte) *node {
+	c := &node{label: b}
+
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = c
+
+	return c
+}
+
+// removeChild removes the child at the position i
+func (n *node) removeChild(i int) {
+	copy(n.children[i:], n.children[i+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
+
+// walk calls f for each key of the subtree with root n in lexicographic order,
+// key is the path to n. It returns false if the walk was stopped by f.
+func (n *node) walk(key []byte, f func(string, any) bool) bool {
+	if n.terminal && !f(string(key), n.data) {
+		return false
+	}
+
+	for _, c := range n.children {
+		if !c.walk(append(key, c.label), f) {
+			return false
+		}
+	}
+
+	return true
+}
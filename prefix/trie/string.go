@@ -0,0 +1,173 @@
+package trie
+
+import (
+	"strings"
+)
+
+const (
+	// Empty trie stub
+	strEmptyTrie	= `<trie-is-empty>`
+	// Label of the root node
+	strRoot			= `^`
+
+	// Gap between neighbour nodes on the same level
+	nodesGap		=	1
+)
+
+// nodePos describes a position of the node in the output matrix
+type nodePos struct {
+	start	int	// first column of the node label
+	center	int	// column to connect branches from the parent
+	label	string
+}
+
+func (t *Trie) String() string {
+	if t.size == 0 {
+		return strEmptyTrie
+	}
+
+	// Get a list of nodes separated by levels and a map with positions of nodes
+	levels, positions, width := stringPrepareData(t)
+
+	// Create output matrix
+	const linesPerLevel = 3	// each output matrix level contains 3 lines, for:
+							// * node labels
+							// * horizontal part of edges from parent
+							// * final slanting part of the edges
+	oMatrix := make([][]rune, len(levels) * linesPerLevel)
+	for i := range oMatrix {
+		oMatrix[i] = []rune(strings.Repeat(" ", width))
+	}
+
+	for level, nodes := range levels {
+		oLine := level * linesPerLevel
+		for _, n := range nodes {
+			pos := positions[n]
+
+			// Write node label to the output matrix
+			copy(oMatrix[oLine][pos.start:], []rune(pos.label))
+
+			if len(n.children) == 0 {
+				continue
+			}
+
+			// Draw horizontal part of edges over all children
+			first, last := positions[n.children[0]].center, positions[n.children[len(n.children)-1]].center
+			for col := first + 1; col < last; col++ {
+				oMatrix[oLine+1][col] = '_'
+			}
+
+			// Draw final parts of the edges
+			for _, c := range n.children {
+				cc := positions[c].center
+				switch {
+				case cc < pos.center:
+					oMatrix[oLine+2][cc] = '/'
+				case cc > pos.center:
+					oMatrix[oLine+2][cc] = '\\'
+				default:
+					oMatrix[oLine+2][cc] = '|'
+				}
+			}
+		}
+	}
+
+	return stringMakeOutput(oMatrix, linesPerLevel)
+}
+
+// stringPrepareData source data to create string representation of the trie. It returns:
+// levels - set of levels (starting from the root - 0), each of that level
+//          contains list of corresponding nodes in lexicographic order
+// positions - map of node<=>position of the node in the output matrix
+// width - width of the output matrix
+func stringPrepareData(t *Trie) ([][]*node, map[*node]nodePos, int) {
+	// Collect all nodes into the levels matrix
+	levels := [][]*node{{t.root}}
+	for {
+		var next []*node
+		for _, n := range levels[len(levels)-1] {
+			next = append(next, n.children...)
+		}
+		if len(next) == 0 {
+			break
+		}
+		levels = append(levels, next)
+	}
+
+	positions := map[*node]nodePos{}
+	width := t.root.layout(0, strRoot, positions)
+
+	return levels, positions, width
+}
+
+// layout places the subtree with root n starting from column x0 and returns width of the subtree
+func (n *node) layout(x0 int, label string, positions map[*node]nodePos) int {
+	lw := len(label)
+
+	if len(n.children) == 0 {
+		positions[n] = nodePos{start: x0, center: x0 + (lw-1)/2, label: label}
+		return lw
+	}
+
+	// Calculate width of children subtrees placed one by one
+	cw := 0
+	for i, c := range n.children {
+		if i != 0 {
+			cw += nodesGap
+		}
+		cw += c.layout(x0 + cw, c.String(), positions)
+	}
+
+	width := cw
+	if lw > cw {
+		// Label is wider than children, shift them to center under the label
+		width = lw
+		for _, c := range n.children {
+			c.shift((lw - cw) / 2, positions)
+		}
+	}
+
+	// Center the node between the first and the last children
+	center := (positions[n.children[0]].center + positions[n.children[len(n.children)-1]].center) / 2
+
+	// Label should not go out of the subtree bounds
+	start := center - (lw-1)/2
+	if start < x0 {
+		start = x0
+	}
+	if start + lw > x0 + width {
+		start = x0 + width - lw
+	}
+
+	positions[n] = nodePos{start: start, center: center, label: label}
+
+	return width
+}
+
+// shift moves the subtree with root n by dx columns
+func (n *node) shift(dx int, positions map[*node]nodePos) {
+	pos := positions[n]
+	pos.start += dx
+	pos.center += dx
+	positions[n] = pos
+
+	for _, c := range n.children {
+		c.shift(dx, positions)
+	}
+}
+
+// stringMakeOutput converts matrix-representation of the trie to the multiline string value
+func stringMakeOutput(matrix [][]rune, linesPerLevel int) string {
+	// Remove last lines of edges from matrix - they always empty
+	matrix = matrix[:len(matrix)-linesPerLevel+1]
+
+	// Make output buffer
+	out := strings.Builder{}
+
+	for _, line := range matrix {
+		out.WriteString(strings.TrimRight(string(line), " "))
+		out.WriteString("\n")
+	}
+
+	return out.String()
+}
@@ -0,0 +1,36 @@
+package trie
+
+import (
+	"testing"
+)
+
+func TestStringFilled(t *testing.T) {
+	tr := NewTrie()
+	for _, k := range []string{"to", "tea", "ted", "ten", "i", "in", "inn", "\x01"} {
+		tr.Insert(k, nil)
+	}
+
+	want :=
+`        ^
+   ____________
+  /   /        \
+\x01$ i$       t
+             _____
+      |     /     \
+      n$    e     o$
+          _____
+      |  /  |  \
+      n$ a$ d$ n$
+`
+
+	if tStr := tr.String(); tStr != want {
+		t.Errorf("Trie.String() returned:\n---\n%s\n---\nWant:\n---\n%s\n---\n", tStr, want)
+	}
+}
+
+func TestStringEmpty(t *testing.T) {
+	tr := NewTrie()
+	if tStr := tr.String(); tStr != strEmptyTrie {
+		t.Errorf("Trie.String() returned:\n---\n%s\n---\nWant:\n---\n%s\n---\n", tStr, strEmptyTrie)
+	}
+}
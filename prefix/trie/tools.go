@@ -0,0 +1,54 @@
+package trie
+
+import "fmt"
+
+// SelfTest performs a self-test of the trie and returns the height of the trie,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero.
+func (t *Trie) SelfTest() (int, error) {
+	count := 0
+	height, err := t.root.check(true, &count)
+	if err != nil {
+		return 0, err
+	}
+
+	if count != t.size {
+		return 0, fmt.Errorf("v#1: trie contains %d keys but its size is %d", count, t.size)
+	}
+
+	return height, nil
+}
+
+// check checks the subtree with root n, counts its keys and returns its height
+func (n *node) check(root bool, count *int) (int, error) {
+	if n.terminal {
+		*count++
+	}
+
+	if !root && !n.terminal && len(n.children) == 0 {
+		return 0, fmt.Errorf("v#2: node %v does not lead to any key", n)
+	}
+
+	height := 0
+	for i, c := range n.children {
+		if i > 0 && n.children[i-1].label >= c.label {
+			return 0, fmt.Errorf("v#3: children %v and %v of node %v are not in ascending order",
+				n.children[i-1], c, n)
+		}
+
+		ch, err := c.check(false, count)
+		if err != nil {
+			return 0, err
+		}
+		if ch > height {
+			height = ch
+		}
+	}
+
+	if root {
+		// Root does not correspond to any byte of keys
+		return height, nil
+	}
+
+	return height + 1, nil
+}
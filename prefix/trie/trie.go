@@ -0,0 +1,177 @@
+/*
+Package trie provides an example of a trie (prefix tree) implementation for
+string keys.
+
+Each node of the trie corresponds to a single byte of a key, so the keys with
+common prefixes share the path from the root. It makes the trie suitable for
+prefix-based lookups, such as finding the longest stored prefix of a string
+(e.g. in routing tables) and iterating over all keys with a given prefix. The
+time of all operations is O(len(key)) and does not depend on the number of keys.
+
+It supports output of graphical representation of the trie using ASCII
+graphics. The root is printed as ^ and the nodes that terminate keys are
+marked by $. For example, a trie with the keys "to", "tea", "ted", "ten",
+"i", "in", "inn" will look like this:
+
+	    ^
+	 ________
+	/        \
+	i$       t
+	       _____
+	|     /     \
+	n$    e     o$
+	    _____
+	|  /  |  \
+	n$ a$ d$ n$
+*/
+package trie
+
+// Trie implements a trie.
+type Trie struct {
+	root	*node
+	size	int
+}
+
+// NewTrie returns new empty trie.
+func NewTrie() *Trie {
+	return &Trie{root: &node{}}
+}
+
+// Len returns the number of keys in the trie.
+func (t *Trie) Len() int {
+	return t.size
+}
+
+// Insert inserts key k with associated data into the trie. It returns false if the
+// key already exists in the trie, in this case the trie is not modified.
+func (t *Trie) Insert(k string, data any) bool {
+	n := t.root
+	for i := 0; i < len(k); i++ {
+		ci, ok := n.child(k[i])
+		if ok {
+			n = n.children[ci]
+		} else {
+			n = n.addChild(ci, k[i])
+		}
+	}
+
+	if n.terminal {
+		// Already exists
+		return false
+	}
+
+	n.terminal, n.data = true, data
+	t.size++
+
+	return true
+}
+
+// Get returns the data associated with key k and true, or nil and false if there is no such key.
+func (t *Trie) Get(k string) (any, bool) {
+	n := t.find(k)
+	if n == nil || !n.terminal {
+		return nil, false
+	}
+
+	return n.data, true
+}
+
+// find returns the node that corresponds to the path k or nil if there is no such node
+func (t *Trie) find(k string) *node {
+	n := t.root
+	for i := 0; i < len(k); i++ {
+		ci, ok := n.child(k[i])
+		if !ok {
+			return nil
+		}
+		n = n.children[ci]
+	}
+
+	return n
+}
+
+// Delete deletes key k from the trie and returns the data associated with the key
+// and true, or nil and false if there is no such key. Nodes that are no longer
+// required are removed.
+func (t *Trie) Delete(k string) (any, bool) {
+	// Path from the root to the node of the key
+	path := make([]*node, 0, len(k) + 1)
+	path = append(path, t.root)
+	for i := 0; i < len(k); i++ {
+		ci, ok := path[i].child(k[i])
+		if !ok {
+			return nil, false
+		}
+		path = append(path, path[i].children[ci])
+	}
+
+	n := path[len(path)-1]
+	if !n.terminal {
+		return nil, false
+	}
+
+	data := n.data
+	n.terminal, n.data = false, nil
+	t.size--
+
+	// Remove the nodes that do not lead to any key, going up to the root
+	for i := len(path) - 1; i > 0; i-- {
+		if n := path[i]; n.terminal || len(n.children) != 0 {
+			break
+		}
+
+		ci, _ := path[i-1].child(k[i-1])
+		path[i-1].removeChild(ci)
+	}
+
+	return data, true
+}
+
+// LongestPrefix returns the longest key stored in the trie that is a prefix of s,
+// its associated data and true, or an empty string, nil and false if there is no such key.
+func (t *Trie) LongestPrefix(s string) (string, any, bool) {
+	var found bool
+	var key string
+	var data any
+
+	n := t.root
+	for i := 0; ; i++ {
+		if n.terminal {
+			key, data, found = s[:i], n.data, true
+		}
+
+		if i == len(s) {
+			break
+		}
+
+		ci, ok := n.child(s[i])
+		if !ok {
+			break
+		}
+		n = n.children[ci]
+	}
+
+	return key, data, found
+}
+
+// WalkPrefix calls f for each key that starts with prefix in lexicographic order until
+// f returns false. An empty prefix means all keys of the trie.
+func (t *Trie) WalkPrefix(prefix string, f func(k string, data any) bool) {
+	n := t.find(prefix)
+	if n == nil {
+		return
+	}
+
+	n.walk([]byte(prefix), f)
+}
+
+// Keys returns all keys of the trie in lexicographic order.
+func (t *Trie) Keys() []string {
+	keys := make([]string, 0, t.size)
+	t.WalkPrefix("", func(k string, _ any) bool {
+		keys = append(keys, k)
+		return true
+	})
+
+	return keys
+}
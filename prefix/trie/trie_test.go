@@ -0,0 +1,306 @@
+package trie
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/r-che/algorithms/internal/randkeys"
+)
+
+const (
+	// Number of keys fills all branches of the first two levels of the trie
+	keysCount	=	4096
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2033
+
+	// Self-test walks the whole trie, so it is run once per selfTestStep deletions
+	selfTestStep	=	64
+)
+
+// decimalKeys returns decimal representations of numbers, they have a lot of common prefixes
+func decimalKeys(nums []int) []string {
+	keys := make([]string, 0, len(nums))
+	for _, n := range nums {
+		keys = append(keys, strconv.Itoa(n))
+	}
+
+	return keys
+}
+
+func sortedKeys(testKeys []string) []string {
+	keys := make([]string, len(testKeys))
+	copy(keys, testKeys)
+	sort.Strings(keys)
+
+	return keys
+}
+
+func newTrieTestKeys(t *testing.T, testKeys []string) *Trie {
+	t.Helper()
+
+	tr := NewTrie()
+	for i, k := range testKeys {
+		if !tr.Insert(k, "value:" + k) {
+			t.Fatalf("[%d] Insert(%q) returned false", i, k)
+		}
+	}
+
+	if _, err := tr.SelfTest(); err != nil {
+		t.Fatalf("Self-test of the trie failed: %v", err)
+	}
+
+	return tr
+}
+
+func TestEmpty(t *testing.T) {
+	tr := NewTrie()
+
+	if data, ok := tr.Get(""); ok {
+		t.Errorf("Get(\"\") on empty trie returned %v, true", data)
+	}
+
+	if data, ok := tr.Delete("abc"); ok {
+		t.Errorf("Delete() on empty trie returned %v, true", data)
+	}
+
+	if k, data, ok := tr.LongestPrefix("abc"); ok {
+		t.Errorf("LongestPrefix() on empty trie returned %q, %v, true", k, data)
+	}
+
+	if keys := tr.Keys(); len(keys) != 0 {
+		t.Errorf("Keys() on empty trie returned %v", keys)
+	}
+
+	if height, err := tr.SelfTest(); err != nil || height != 0 {
+		t.Errorf("SelfTest() of empty trie returned %d, %v", height, err)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := decimalKeys(randkeys.Unique(rnd, keysCount, MaxItem))
+
+	tr := newTrieTestKeys(t, testKeys)
+
+	if tr.Len() != len(testKeys) {
+		t.Errorf("Trie has size %d, want - %d", tr.Len(), len(testKeys))
+	}
+
+	for i, k := range testKeys {
+		if data, ok := tr.Get(k); !ok || data != "value:" + k {
+			t.Fatalf("[%d] Get(%q) returned %v, %t", i, k, data, ok)
+		}
+	}
+
+	// Keys are returned in lexicographic order
+	want := sortedKeys(testKeys)
+	for i, k := range tr.Keys() {
+		if k != want[i] {
+			t.Fatalf("[%d] Keys() returned %q, want - %q", i, k, want[i])
+		}
+	}
+}
+
+func TestInsertDupes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := decimalKeys(randkeys.Unique(rnd, keysCount, MaxItem))
+
+	tr := newTrieTestKeys(t, testKeys)
+
+	for i, k := range testKeys {
+		if tr.Insert(k, nil) {
+			t.Fatalf("[%d] Insert() of existing key %q returned true", i, k)
+		}
+	}
+
+	if tr.Len() != len(testKeys) {
+		t.Errorf("Trie has size %d after inserting dupes, want - %d", tr.Len(), len(testKeys))
+	}
+}
+
+func TestInsertEmptyKey(t *testing.T) {
+	tr := NewTrie()
+
+	if !tr.Insert("", "empty") {
+		t.Fatalf("Insert() of the empty key returned false")
+	}
+
+	if data, ok := tr.Get(""); !ok || data != "empty" {
+		t.Errorf("Get(\"\") returned %v, %t", data, ok)
+	}
+
+	if k, data, ok := tr.LongestPrefix("abc"); !ok || k != "" || data != "empty" {
+		t.Errorf("LongestPrefix() returned %q, %v, %t, want - \"\", empty, true", k, data, ok)
+	}
+
+	if data, ok := tr.Delete(""); !ok || data != "empty" || tr.Len() != 0 {
+		t.Errorf("Delete(\"\") returned %v, %t, trie size - %d", data, ok, tr.Len())
+	}
+}
+
+func TestGetMissing(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := decimalKeys(randkeys.Unique(rnd, keysCount, MaxItem))
+
+	tr := newTrieTestKeys(t, testKeys)
+
+	for i, k := range testKeys {
+		// Keys with additional suffix that does not exist
+		if data, ok := tr.Get(k + "x"); ok {
+			t.Fatalf("[%d] Get(%q) returned %v, true", i, k + "x", data)
+		}
+	}
+}
+
+func TestDelete(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := decimalKeys(randkeys.Unique(rnd, keysCount, MaxItem))
+
+	tr := newTrieTestKeys(t, testKeys)
+
+	// Delete keys in random order
+	keys := make([]string, len(testKeys))
+	copy(keys, testKeys)
+	rnd.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+	for i, k := range keys {
+		if data, ok := tr.Delete(k); !ok || data != "value:" + k {
+			t.Fatalf("[%d] Delete(%q) returned %v, %t", i, k, data, ok)
+		}
+
+		if data, ok := tr.Delete(k); ok {
+			t.Fatalf("[%d] Second Delete(%q) returned %v, true", i, k, data)
+		}
+
+		if data, ok := tr.Get(k); ok {
+			t.Fatalf("[%d] Get(%q) returned %v, true after deletion", i, k, data)
+		}
+
+		if i % selfTestStep == 0 {
+			if _, err := tr.SelfTest(); err != nil {
+				t.Fatalf("[%d] Self-test failed after Delete(%q): %v", i, k, err)
+			}
+		}
+	}
+
+	if tr.Len() != 0 || len(tr.root.children) != 0 {
+		t.Errorf("Trie is not empty after deleting all keys, size - %d, root children - %d",
+			tr.Len(), len(tr.root.children))
+	}
+}
+
+func TestLongestPrefix(t *testing.T) {
+	tr := NewTrie()
+	for _, k := range []string{"10.", "10.1.", "10.1.2.", "192.168.", "192.168.0.1"} {
+		tr.Insert(k, k)
+	}
+
+	for i, test := range []struct {
+		s		string
+		want	string
+		found	bool
+	} {
+		{ "10.1.2.3", "10.1.2.", true },
+		{ "10.1.3.3", "10.1.", true },
+		{ "10.2.2.3", "10.", true },
+		{ "10.1.", "10.1.", true },
+		{ "10", "", false },
+		{ "192.168.0.1", "192.168.0.1", true },
+		{ "192.168.0.10", "192.168.0.1", true },
+		{ "192.168.1.1", "192.168.", true },
+		{ "172.16.0.1", "", false },
+		{ "", "", false },
+	} {
+		k, data, ok := tr.LongestPrefix(test.s)
+		if ok != test.found || k != test.want {
+			t.Errorf("[%d] LongestPrefix(%q) returned %q, %t, want - %q, %t", i, test.s, k, ok, test.want, test.found)
+			continue
+		}
+		if ok && data != k {
+			t.Errorf("[%d] LongestPrefix(%q) returned data %v, want - %q", i, test.s, data, k)
+		}
+	}
+}
+
+func TestWalkPrefix(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := decimalKeys(randkeys.Unique(rnd, keysCount, MaxItem))
+
+	tr := newTrieTestKeys(t, testKeys)
+
+	for _, prefix := range []string{"", "1", "12", "999", "5000", "x"} {
+		var want []string
+		for _, k := range sortedKeys(testKeys) {
+			if strings.HasPrefix(k, prefix) {
+				want = append(want, k)
+			}
+		}
+
+		var got []string
+		tr.WalkPrefix(prefix, func(k string, data any) bool {
+			if data != "value:" + k {
+				t.Errorf("WalkPrefix(%q) passed data %v for key %q", prefix, data, k)
+			}
+			got = append(got, k)
+			return true
+		})
+
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("WalkPrefix(%q) walked %d keys, want - %d", prefix, len(got), len(want))
+		}
+	}
+
+	// Stop walking
+	count := 0
+	tr.WalkPrefix("", func(string, any) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 {
+		t.Errorf("WalkPrefix() was not stopped, called %d times", count)
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testKeys := decimalKeys(randkeys.Unique(rnd, keysCount, MaxItem))
+
+	for i, test := range []struct {
+		breaker	func(tr *Trie)
+		want	string
+	} {
+		{
+			breaker:	func(tr *Trie) { tr.size++ },
+			want:		"v#1: trie contains",
+		},
+		{
+			breaker:	func(tr *Trie) { tr.root.addChild(0, '#') },
+			want:		"v#2: node # does not lead to any key",
+		},
+		{
+			breaker:	func(tr *Trie) {
+				tr.root.children[0], tr.root.children[1] = tr.root.children[1], tr.root.children[0]
+			},
+			want:		"v#3: children",
+		},
+	} {
+		tr := newTrieTestKeys(t, testKeys)
+		test.breaker(tr)
+
+		height, err := tr.SelfTest()
+		if err == nil {
+			t.Errorf("[%d] SelfTest() did not detect the problem", i)
+			continue
+		}
+		if height != 0 {
+			t.Errorf("[%d] SelfTest() returned non-zero height %d with error", i, height)
+		}
+		if !strings.HasPrefix(err.Error(), test.want) {
+			t.Errorf("[%d] SelfTest() returned %q, want prefix - %q", i, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package fenwick
+
+import "fmt"
+
+func Example_prefixSums() {
+	// Number of requests per minute
+	f := NewFenwickFrom([]int{5, 3, 0, 7, 2, 4})
+
+	// More requests in the 3rd minute
+	f.Add(2, 6)
+
+	fmt.Println("total:", f.PrefixSum(f.Len()))
+	fmt.Println("minutes 1-3:", f.RangeSum(1, 4))
+	// The minute when the 10th request was received
+	fmt.Println("10th request minute:", f.LowerBound(10))
+
+	// Output:
+	// total: 27
+	// minutes 1-3: 16
+	// 10th request minute: 2
+}
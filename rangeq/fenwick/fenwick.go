@@ -0,0 +1,136 @@
+/*
+Package fenwick provides an example of a Fenwick tree (binary indexed tree)
+implementation.
+
+A Fenwick tree stores a sequence of numbers in the array of partial sums, so
+that adding a value to an element and computing the sum of any prefix take
+O(log n) time. The tree uses only n+1 numbers of memory and is simpler and
+faster than the segment tree, but supports only invertible aggregates such as
+sums.
+
+If all elements are non-negative, the prefix sums are non-decreasing and the
+LowerBound method can find the shortest prefix with the sum not less than
+the given one in O(log n) time, e.g. to sample by weights or to find the k-th
+element of a multiset stored as counts of elements.
+
+The methods panic if an index is out of range, just like indexing of slices does.
+*/
+package fenwick
+
+import "fmt"
+
+// Number is the constraint of element types of the tree
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+	~float32 | ~float64
+}
+
+// Fenwick implements a Fenwick tree.
+type Fenwick[T Number] struct {
+	// Partial sums, tree[i] is the sum of elements (i - i&-i, i], 1-based
+	tree	[]T
+	// The greatest power of two not greater than n, used by LowerBound
+	mask	int
+}
+
+// NewFenwick returns new Fenwick tree with n zero elements.
+func NewFenwick[T Number](n int) *Fenwick[T] {
+	if n < 0 {
+		panic(fmt.Sprintf("Invalid number of elements %d", n))
+	}
+
+	mask := 1
+	for mask <= n {
+		mask <<= 1
+	}
+
+	return &Fenwick[T]{tree: make([]T, n+1), mask: mask >> 1}
+}
+
+// NewFenwickFrom returns new Fenwick tree built from values in O(n) time.
+func NewFenwickFrom[T Number](values []T) *Fenwick[T] {
+	f := NewFenwick[T](len(values))
+	copy(f.tree[1:], values)
+
+	// Propagate each partial sum to its parent
+	for i := 1; i < len(f.tree); i++ {
+		if p := i + i & -i; p < len(f.tree) {
+			f.tree[p] += f.tree[i]
+		}
+	}
+
+	return f
+}
+
+// Len returns the number of elements of the tree.
+func (f *Fenwick[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+// Add adds delta to the element with index i.
+func (f *Fenwick[T]) Add(i int, delta T) {
+	f.checkIndex(i)
+
+	for i++; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// PrefixSum returns the sum of elements with indexes [0, i).
+func (f *Fenwick[T]) PrefixSum(i int) T {
+	if i < 0 || i > f.Len() {
+		panic(fmt.Sprintf("prefix length %d out of range [0, %d]", i, f.Len()))
+	}
+
+	var s T
+	for ; i > 0; i -= i & -i {
+		s += f.tree[i]
+	}
+
+	return s
+}
+
+// RangeSum returns the sum of elements with indexes [l, r).
+func (f *Fenwick[T]) RangeSum(l, r int) T {
+	if l > r {
+		panic(fmt.Sprintf("invalid range [%d, %d)", l, r))
+	}
+
+	return f.PrefixSum(r) - f.PrefixSum(l)
+}
+
+// Get returns the element with index i.
+func (f *Fenwick[T]) Get(i int) T {
+	f.checkIndex(i)
+
+	return f.RangeSum(i, i + 1)
+}
+
+// Set sets the element with index i to v.
+func (f *Fenwick[T]) Set(i int, v T) {
+	f.Add(i, v - f.Get(i))
+}
+
+// LowerBound returns the least index i such that the sum of elements [0, i] is not
+// less than s, or Len() if there is no such index. All elements must be non-negative.
+func (f *Fenwick[T]) LowerBound(s T) int {
+	// Binary lifting - find the longest prefix with the sum less than s
+	pos := 0
+	for step := f.mask; step > 0; step >>= 1 {
+		if next := pos + step; next < len(f.tree) && f.tree[next] < s {
+			pos = next
+			s -= f.tree[next]
+		}
+	}
+
+	// The next element makes the sum not less than s
+	return pos
+}
+
+// checkIndex panics if the index i is out of range
+func (f *Fenwick[T]) checkIndex(i int) {
+	if i < 0 || i >= f.Len() {
+		panic(fmt.Sprintf("index %d out of range [0, %d)", i, f.Len()))
+	}
+}
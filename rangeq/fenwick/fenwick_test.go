@@ -0,0 +1,195 @@
+package fenwick
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+const (
+	valuesCount	=	1024
+	MaxItem		=	99999
+
+	// Seed of random sources of tests
+	testSeed	=	2036
+
+	// Number of random operations for each tree
+	opsCount	=	10240
+)
+
+// newTestValues returns n random values in the range [0, MaxItem] generated by rnd
+func newTestValues(rnd *rand.Rand, n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = rnd.Intn(MaxItem + 1)
+	}
+
+	return values
+}
+
+//nolint:gochecknoglobals
+var testSizes = []int{0, 1, 2, 3, 7, 64, 100, valuesCount}
+
+func naiveSum(values []int, l, r int) int {
+	s := 0
+	for _, v := range values[l:r] {
+		s += v
+	}
+
+	return s
+}
+
+func TestNewFenwick(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testValues := newTestValues(rnd, valuesCount)
+
+	for _, n := range testSizes {
+		f := NewFenwick[int](n)
+		for i, v := range testValues[:n] {
+			f.Add(i, v)
+		}
+
+		// Both ways to build the tree should give the same result
+		g := NewFenwickFrom(testValues[:n])
+		for i := range f.tree {
+			if f.tree[i] != g.tree[i] {
+				t.Fatalf("[%d] Partial sum %d of the tree built by Add() is %d, by NewFenwickFrom() - %d",
+					n, i, f.tree[i], g.tree[i])
+			}
+		}
+
+		if f.Len() != n {
+			t.Errorf("[%d] Len() returned %d", n, f.Len())
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewFenwick() did not panic on negative size")
+		}
+	}()
+
+	NewFenwick[int](-1)
+}
+
+func TestSums(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testValues := newTestValues(rnd, valuesCount)
+
+	for _, n := range testSizes[1:] {
+		values := make([]int, n)
+		copy(values, testValues)
+		f := NewFenwickFrom(values)
+
+		for op := 0; op < opsCount; op++ {
+			switch op % 4 {
+			case 0:
+				i, d := rnd.Intn(n), rnd.Intn(2 * MaxItem) - MaxItem
+				values[i] += d
+				f.Add(i, d)
+			case 1:
+				i, v := rnd.Intn(n), rnd.Intn(MaxItem)
+				values[i] = v
+				f.Set(i, v)
+			case 2:
+				i := rnd.Intn(n)
+				if g := f.Get(i); g != values[i] {
+					t.Fatalf("[%d/%d] Get(%d) returned %d, want - %d", n, op, i, g, values[i])
+				}
+			default:
+				l, r := rnd.Intn(n + 1), rnd.Intn(n + 1)
+				if l > r {
+					l, r = r, l
+				}
+				if got, want := f.RangeSum(l, r), naiveSum(values, l, r); got != want {
+					t.Fatalf("[%d/%d] RangeSum(%d, %d) returned %d, want - %d", n, op, l, r, got, want)
+				}
+				if got, want := f.PrefixSum(r), naiveSum(values, 0, r); got != want {
+					t.Fatalf("[%d/%d] PrefixSum(%d) returned %d, want - %d", n, op, r, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestLowerBound(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testValues := newTestValues(rnd, valuesCount)
+
+	for _, n := range testSizes {
+		values := make([]int, n)
+		copy(values, testValues)
+		// Add some zero elements
+		for i := 0; i < n; i += 3 {
+			values[i] = 0
+		}
+		f := NewFenwickFrom(values)
+
+		// Prefix sums [0, i]
+		prefix := make([]int, n)
+		s := 0
+		for i, v := range values {
+			s += v
+			prefix[i] = s
+		}
+
+		for op := 0; op < opsCount / 10; op++ {
+			target := rnd.Intn(s + 2) - 1
+			want := sort.SearchInts(prefix, target)
+			if got := f.LowerBound(target); got != want {
+				t.Fatalf("[%d/%d] LowerBound(%d) returned %d, want - %d", n, op, target, got, want)
+			}
+		}
+
+		if got := f.LowerBound(s + 1); got != n {
+			t.Errorf("[%d] LowerBound() of the sum greater than total returned %d, want - %d", n, got, n)
+		}
+	}
+}
+
+func TestFloat(t *testing.T) {
+	f := NewFenwickFrom([]float64{0.5, 0.25, 0.125, 0.125})
+
+	if s := f.PrefixSum(3); s != 0.875 {
+		t.Errorf("PrefixSum(3) returned %v, want - 0.875", s)
+	}
+
+	// Sampling by weights
+	for i, test := range []struct {
+		p		float64
+		want	int
+	} {
+		{ 0.1, 0 }, { 0.5, 0 }, { 0.6, 1 }, { 0.8, 2 }, { 0.9, 3 },
+	} {
+		if got := f.LowerBound(test.p); got != test.want {
+			t.Errorf("[%d] LowerBound(%v) returned %d, want - %d", i, test.p, got, test.want)
+		}
+	}
+}
+
+func TestInvalidRanges(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testValues := newTestValues(rnd, 10)
+
+	f := NewFenwickFrom(testValues)
+
+	for i, fn := range []func(){
+		func() { f.Get(-1) },
+		func() { f.Get(10) },
+		func() { f.Add(10, 1) },
+		func() { f.Set(-1, 1) },
+		func() { f.PrefixSum(11) },
+		func() { f.PrefixSum(-1) },
+		func() { f.RangeSum(5, 4) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("[%d] Invalid index or range did not cause panic", i)
+				}
+			}()
+
+			fn()
+		}()
+	}
+}
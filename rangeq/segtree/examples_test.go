@@ -0,0 +1,46 @@
+package segtree
+
+import "fmt"
+
+func Example_rangeMin() {
+	minInt := func(a, b int) int {
+		if a < b {
+			return a
+		}
+		return b
+	}
+
+	// Latencies of requests
+	tree := NewSegTree([]int{120, 80, 95, 300, 60, 110}, 1 << 31, minInt)
+
+	fmt.Println("min of [1, 4):", tree.Query(1, 4))
+
+	tree.Set(2, 40)
+	fmt.Println("min of [1, 4):", tree.Query(1, 4))
+	fmt.Println("min of all:", tree.All())
+
+	// Output:
+	// min of [1, 4): 80
+	// min of [1, 4): 40
+	// min of all: 40
+}
+
+func Example_rangeAdd() {
+	sum := func(a, b int) int { return a + b }
+
+	// Range additions with range sums
+	tree := NewLazySegTree([]int{1, 2, 3, 4, 5, 6, 7, 8}, 0, sum,
+		func(u, v, length int) int { return v + u * length },
+		sum)
+
+	// Add 10 to each value of [2, 6)
+	tree.Update(2, 6, 10)
+	fmt.Println("sum of [0, 8):", tree.Query(0, 8))
+	fmt.Println("sum of [4, 8):", tree.Query(4, 8))
+	fmt.Println("value 5:", tree.Get(5))
+
+	// Output:
+	// sum of [0, 8): 76
+	// sum of [4, 8): 46
+	// value 5: 16
+}
@@ -0,0 +1,193 @@
+package segtree
+
+// LazySegTree implements a segment tree with range updates using lazy propagation.
+// T is the type of values and aggregates, U is the type of updates.
+type LazySegTree[T, U any] struct {
+	// Number of values
+	n		int
+	// Aggregates of segments, the root is at index 1, children of the node i are 2*i and 2*i+1
+	tree	[]T
+	// Updates that are applied to the node aggregates but not to their children
+	lazy	[]U
+	pending	[]bool
+
+	identity	T
+	combine		func(a, b T) T
+	apply		func(u U, v T, length int) T
+	compose		func(newer, older U) U
+}
+
+// NewLazySegTree returns new segment tree with range updates built from values in O(n) time:
+//
+//	identity, combine - the identity value and the associative function of the aggregate
+//	apply             - returns the aggregate v of a segment of length values after applying update u to each value
+//	compose           - returns the single update equivalent to applying older and then newer updates
+//
+// For example, range additions with sums as aggregates are defined by
+// apply(u, v, length) = v + u*length and compose(newer, older) = newer + older.
+func NewLazySegTree[T, U any](values []T, identity T, combine func(a, b T) T,
+		apply func(u U, v T, length int) T, compose func(newer, older U) U) *LazySegTree[T, U] {
+	// The tree with n leaves needs at most 4*n nodes
+	size := 4 * len(values)
+	if size == 0 {
+		size = 1
+	}
+
+	t := &LazySegTree[T, U]{
+		n:			len(values),
+		tree:		make([]T, size),
+		lazy:		make([]U, size),
+		pending:	make([]bool, size),
+		identity:	identity,
+		combine:	combine,
+		apply:		apply,
+		compose:	compose,
+	}
+
+	if t.n != 0 {
+		t.build(1, 0, t.n, values)
+	}
+
+	return t
+}
+
+// build builds the subtree with root node that covers the segment [nl, nr)
+func (t *LazySegTree[T, U]) build(node, nl, nr int, values []T) {
+	if nr - nl == 1 {
+		t.tree[node] = values[nl]
+		return
+	}
+
+	mid := (nl + nr) / 2
+	t.build(2*node, nl, mid, values)
+	t.build(2*node+1, mid, nr, values)
+	t.tree[node] = t.combine(t.tree[2*node], t.tree[2*node+1])
+}
+
+// Len returns the number of values in the tree.
+func (t *LazySegTree[T, U]) Len() int {
+	return t.n
+}
+
+// Get returns the value with index i.
+func (t *LazySegTree[T, U]) Get(i int) T {
+	checkIndex(i, t.n)
+
+	return t.query(1, 0, t.n, i, i + 1)
+}
+
+// Set sets the value with index i to v and updates aggregates in O(log n) time.
+func (t *LazySegTree[T, U]) Set(i int, v T) {
+	checkIndex(i, t.n)
+
+	t.set(1, 0, t.n, i, v)
+}
+
+// Query returns the aggregate of values in the range [l, r) in O(log n) time.
+// The identity value is returned for the empty range.
+func (t *LazySegTree[T, U]) Query(l, r int) T {
+	checkRange(l, r, t.n)
+
+	if l == r {
+		return t.identity
+	}
+
+	return t.query(1, 0, t.n, l, r)
+}
+
+// Update applies the update u to each value in the range [l, r) in O(log n) time.
+func (t *LazySegTree[T, U]) Update(l, r int, u U) {
+	checkRange(l, r, t.n)
+
+	if l == r {
+		return
+	}
+
+	t.update(1, 0, t.n, l, r, u)
+}
+
+// applyNode applies the update u to the node that covers length values
+func (t *LazySegTree[T, U]) applyNode(node, length int, u U) {
+	t.tree[node] = t.apply(u, t.tree[node], length)
+
+	if length == 1 {
+		// Leaf has no children to push the update to
+		return
+	}
+
+	if t.pending[node] {
+		t.lazy[node] = t.compose(u, t.lazy[node])
+	} else {
+		t.lazy[node], t.pending[node] = u, true
+	}
+}
+
+// push pushes the pending update of the node that covers the segment [nl, nr) to its children
+func (t *LazySegTree[T, U]) push(node, nl, nr int) {
+	if !t.pending[node] {
+		return
+	}
+
+	mid := (nl + nr) / 2
+	t.applyNode(2*node, mid - nl, t.lazy[node])
+	t.applyNode(2*node+1, nr - mid, t.lazy[node])
+
+	var zero U
+	t.lazy[node], t.pending[node] = zero, false
+}
+
+func (t *LazySegTree[T, U]) query(node, nl, nr, l, r int) T {
+	if l <= nl && nr <= r {
+		// The segment is completely inside the range
+		return t.tree[node]
+	}
+
+	t.push(node, nl, nr)
+
+	mid := (nl + nr) / 2
+	switch {
+	case r <= mid:
+		return t.query(2*node, nl, mid, l, r)
+	case l >= mid:
+		return t.query(2*node+1, mid, nr, l, r)
+	default:
+		return t.combine(t.query(2*node, nl, mid, l, r), t.query(2*node+1, mid, nr, l, r))
+	}
+}
+
+func (t *LazySegTree[T, U]) update(node, nl, nr, l, r int, u U) {
+	if l <= nl && nr <= r {
+		// The segment is completely inside the range - update lazily
+		t.applyNode(node, nr - nl, u)
+		return
+	}
+
+	t.push(node, nl, nr)
+
+	mid := (nl + nr) / 2
+	if l < mid {
+		t.update(2*node, nl, mid, l, r, u)
+	}
+	if r > mid {
+		t.update(2*node+1, mid, nr, l, r, u)
+	}
+
+	t.tree[node] = t.combine(t.tree[2*node], t.tree[2*node+1])
+}
+
+func (t *LazySegTree[T, U]) set(node, nl, nr, i int, v T) {
+	if nr - nl == 1 {
+		t.tree[node] = v
+		return
+	}
+
+	t.push(node, nl, nr)
+
+	if mid := (nl + nr) / 2; i < mid {
+		t.set(2*node, nl, mid, i, v)
+	} else {
+		t.set(2*node+1, mid, nr, i, v)
+	}
+
+	t.tree[node] = t.combine(t.tree[2*node], t.tree[2*node+1])
+}
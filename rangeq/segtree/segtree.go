@@ -0,0 +1,134 @@
+/*
+Package segtree provides an example of a segment tree implementation.
+
+A segment tree stores a sequence of values and the aggregates of its segments,
+so that the aggregate of any range of values can be computed in O(log n) time.
+The aggregate is defined by an associative combine function and its identity
+value, e.g. the sum and zero, the minimum and the maximal value of the type, etc.
+The combine function does not have to be commutative.
+
+The package contains two trees:
+
+	SegTree     - supports queries of ranges and updates of single values
+	LazySegTree - also supports updates of ranges in O(log n) time using lazy
+	              propagation, the updates are applied to the aggregates of
+	              segments and are pushed down to the children only when required
+
+All ranges are half-open, i.e. the range [l, r) contains the values with
+indexes l, l+1, ..., r-1. The methods panic if an index is out of range, just
+like indexing of slices does.
+*/
+package segtree
+
+import "fmt"
+
+// SegTree implements a segment tree with point updates.
+type SegTree[T any] struct {
+	// Number of values
+	n		int
+	// Number of leaves, the power of two not less than n
+	leaves	int
+	// Aggregates of segments, the root is at index 1 and the
+	// leaves are at indexes leaves..2*leaves-1
+	tree	[]T
+
+	identity	T
+	combine		func(a, b T) T
+}
+
+// NewSegTree returns new segment tree built from values in O(n) time, combine is
+// the associative function of the aggregate and identity is its identity value.
+func NewSegTree[T any](values []T, identity T, combine func(a, b T) T) *SegTree[T] {
+	leaves := 1
+	for leaves < len(values) {
+		leaves <<= 1
+	}
+
+	t := &SegTree[T]{
+		n:			len(values),
+		leaves:		leaves,
+		tree:		make([]T, 2*leaves),
+		identity:	identity,
+		combine:	combine,
+	}
+
+	// Fill leaves, unused leaves contain the identity value
+	for i := range t.tree[leaves:] {
+		if i < len(values) {
+			t.tree[leaves+i] = values[i]
+		} else {
+			t.tree[leaves+i] = identity
+		}
+	}
+
+	// Build aggregates from bottom to top
+	for i := leaves - 1; i > 0; i-- {
+		t.tree[i] = combine(t.tree[2*i], t.tree[2*i+1])
+	}
+
+	return t
+}
+
+// Len returns the number of values in the tree.
+func (t *SegTree[T]) Len() int {
+	return t.n
+}
+
+// Get returns the value with index i.
+func (t *SegTree[T]) Get(i int) T {
+	checkIndex(i, t.n)
+
+	return t.tree[t.leaves+i]
+}
+
+// Set sets the value with index i to v and updates aggregates in O(log n) time.
+func (t *SegTree[T]) Set(i int, v T) {
+	checkIndex(i, t.n)
+
+	i += t.leaves
+	t.tree[i] = v
+	for i >>= 1; i > 0; i >>= 1 {
+		t.tree[i] = t.combine(t.tree[2*i], t.tree[2*i+1])
+	}
+}
+
+// Query returns the aggregate of values in the range [l, r) in O(log n) time.
+// The identity value is returned for the empty range.
+func (t *SegTree[T]) Query(l, r int) T {
+	checkRange(l, r, t.n)
+
+	// Aggregates of the left and the right parts are collected
+	// separately to keep the order of non-commutative combining
+	left, right := t.identity, t.identity
+	for l, r = l + t.leaves, r + t.leaves; l < r; l, r = l>>1, r>>1 {
+		if l & 1 == 1 {
+			left = t.combine(left, t.tree[l])
+			l++
+		}
+		if r & 1 == 1 {
+			r--
+			right = t.combine(t.tree[r], right)
+		}
+	}
+
+	return t.combine(left, right)
+}
+
+// All returns the aggregate of all values of the tree in O(1) time.
+func (t *SegTree[T]) All() T {
+	return t.tree[1]
+}
+
+// checkIndex panics if the index i is out of the range [0, n)
+func checkIndex(i, n int) {
+	if i < 0 || i >= n {
+		panic(fmt.Sprintf("index %d out of range [0, %d)", i, n))
+	}
+}
+
+// checkRange panics if the range [l, r) is not a valid subrange of [0, n)
+func checkRange(l, r, n int) {
+	if l < 0 || r > n || l > r {
+		panic(fmt.Sprintf("invalid range [%d, %d) of [0, %d)", l, r, n))
+	}
+}
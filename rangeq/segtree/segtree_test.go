@@ -0,0 +1,354 @@
+package segtree
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+const (
+	valuesCount	=	1024
+	MaxItem		=	99999
+
+	// Seed of random sources of tests
+	testSeed	=	2035
+
+	// Number of random operations for each tree
+	opsCount	=	10240
+	// Self-test checks all nodes of the tree, so it is run once per selfTestStep operations
+	selfTestStep	=	64
+)
+
+// newTestValues returns n random values, both negative and positive, generated by rnd
+func newTestValues(rnd *rand.Rand, n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = rnd.Intn(MaxItem + 1) - MaxItem / 2
+	}
+
+	return values
+}
+
+//nolint:gochecknoglobals
+var testSizes = []int{1, 2, 3, 7, 64, 100, valuesCount}
+
+func sum(a, b int) int {
+	return a + b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// randRange returns random range [l, r) of [0, n) generated by rnd
+func randRange(rnd *rand.Rand, n int) (int, int) {
+	l, r := rnd.Intn(n + 1), rnd.Intn(n + 1)
+	if l > r {
+		l, r = r, l
+	}
+
+	return l, r
+}
+
+func naiveQuery(values []int, l, r, identity int, combine func(a, b int) int) int {
+	res := identity
+	for _, v := range values[l:r] {
+		res = combine(res, v)
+	}
+
+	return res
+}
+
+func TestSegTree(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testValues := newTestValues(rnd, valuesCount)
+
+	for _, test := range []struct {
+		name		string
+		identity	int
+		combine		func(a, b int) int
+	} {
+		{ "sum", 0, sum },
+		{ "min", maxInt, minInt },
+	} {
+		for _, n := range testSizes {
+			values := make([]int, n)
+			copy(values, testValues)
+
+			tree := NewSegTree(values, test.identity, test.combine)
+			if tree.Len() != n {
+				t.Fatalf("[%s/%d] Len() returned %d", test.name, n, tree.Len())
+			}
+			if _, err := tree.SelfTest(); err != nil {
+				t.Fatalf("[%s/%d] Self-test of new tree failed: %v", test.name, n, err)
+			}
+
+			for op := 0; op < opsCount; op++ {
+				if op % 2 == 0 {
+					i, v := rnd.Intn(n), rnd.Int() % (MaxItem + 1)
+					values[i] = v
+					tree.Set(i, v)
+
+					if g := tree.Get(i); g != v {
+						t.Fatalf("[%s/%d/%d] Get(%d) returned %d after Set(), want - %d", test.name, n, op, i, g, v)
+					}
+				} else {
+					l, r := randRange(rnd, n)
+					want := naiveQuery(values, l, r, test.identity, test.combine)
+					if got := tree.Query(l, r); got != want {
+						t.Fatalf("[%s/%d/%d] Query(%d, %d) returned %d, want - %d", test.name, n, op, l, r, got, want)
+					}
+				}
+
+				if op % selfTestStep == 0 {
+					if _, err := tree.SelfTest(); err != nil {
+						t.Fatalf("[%s/%d/%d] Self-test failed: %v", test.name, n, op, err)
+					}
+				}
+			}
+
+			if want := naiveQuery(values, 0, n, test.identity, test.combine); tree.All() != want {
+				t.Errorf("[%s/%d] All() returned %d, want - %d", test.name, n, tree.All(), want)
+			}
+		}
+	}
+}
+
+func TestSegTreeNonCommutative(t *testing.T) {
+	letters := strings.Split("abcdefghijklmnopqrstuvwxyz", "")
+	tree := NewSegTree(letters, "", func(a, b string) string { return a + b })
+
+	for l := 0; l <= len(letters); l++ {
+		for r := l; r <= len(letters); r++ {
+			if got, want := tree.Query(l, r), strings.Join(letters[l:r], ""); got != want {
+				t.Fatalf("Query(%d, %d) returned %q, want - %q", l, r, got, want)
+			}
+		}
+	}
+}
+
+func TestSegTreeEmpty(t *testing.T) {
+	tree := NewSegTree(nil, 0, sum)
+
+	if tree.Len() != 0 || tree.All() != 0 || tree.Query(0, 0) != 0 {
+		t.Errorf("Empty tree returned Len() - %d, All() - %d, Query(0, 0) - %d", tree.Len(), tree.All(), tree.Query(0, 0))
+	}
+
+	if _, err := tree.SelfTest(); err != nil {
+		t.Errorf("Self-test of empty tree failed: %v", err)
+	}
+}
+
+func TestInvalidRanges(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testValues := newTestValues(rnd, 10)
+
+	tree := NewSegTree(testValues, 0, sum)
+	lazy := NewLazySegTree(testValues, 0, sum, addSum, sum)
+
+	for i, f := range []func(){
+		func() { tree.Get(-1) },
+		func() { tree.Get(10) },
+		func() { tree.Set(10, 0) },
+		func() { tree.Query(-1, 5) },
+		func() { tree.Query(5, 11) },
+		func() { tree.Query(6, 5) },
+		func() { lazy.Get(10) },
+		func() { lazy.Set(-1, 0) },
+		func() { lazy.Query(0, 11) },
+		func() { lazy.Update(6, 5, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("[%d] Invalid index or range did not cause panic", i)
+				}
+			}()
+
+			f()
+		}()
+	}
+}
+
+// addSum applies the addition of u to each of length values with sum v
+func addSum(u, v, length int) int {
+	return v + u * length
+}
+
+// addMin applies the addition of u to each values with minimum v
+func addMin(u, v, _ int) int {
+	return v + u
+}
+
+// assign is the assignment update
+type assign struct {
+	value	int
+}
+
+func assignSum(u assign, _, length int) int {
+	return u.value * length
+}
+
+func assignMin(u assign, _, _ int) int {
+	return u.value
+}
+
+func assignCompose(newer, _ assign) assign {
+	return newer
+}
+
+// lazyTester is the common interface of lazy trees with different types of updates
+type lazyTester interface {
+	Len() int
+	Get(i int) int
+	Set(i, v int)
+	Query(l, r int) int
+	SelfTest() (int, error)
+	// update applies the update generated by rnd to the range of the tree and values
+	update(rnd *rand.Rand, l, r int, values []int)
+}
+
+type lazyAdd struct {
+	*LazySegTree[int, int]
+}
+
+func (t lazyAdd) update(rnd *rand.Rand, l, r int, values []int) {
+	u := rnd.Intn(201) - 100
+	for i := l; i < r; i++ {
+		values[i] += u
+	}
+	t.Update(l, r, u)
+}
+
+type lazyAssign struct {
+	*LazySegTree[int, assign]
+}
+
+func (t lazyAssign) update(rnd *rand.Rand, l, r int, values []int) {
+	u := assign{rnd.Intn(MaxItem + 1)}
+	for i := l; i < r; i++ {
+		values[i] = u.value
+	}
+	t.Update(l, r, u)
+}
+
+func TestLazySegTree(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testValues := newTestValues(rnd, valuesCount)
+
+	for _, test := range []struct {
+		name		string
+		identity	int
+		combine		func(a, b int) int
+		newTree		func(values []int) lazyTester
+	} {
+		{
+			"add/sum", 0, sum,
+			func(values []int) lazyTester { return lazyAdd{NewLazySegTree(values, 0, sum, addSum, sum)} },
+		},
+		{
+			"add/min", maxInt, minInt,
+			func(values []int) lazyTester { return lazyAdd{NewLazySegTree(values, maxInt, minInt, addMin, sum)} },
+		},
+		{
+			"assign/sum", 0, sum,
+			func(values []int) lazyTester {
+				return lazyAssign{NewLazySegTree(values, 0, sum, assignSum, assignCompose)}
+			},
+		},
+		{
+			"assign/min", maxInt, minInt,
+			func(values []int) lazyTester {
+				return lazyAssign{NewLazySegTree(values, maxInt, minInt, assignMin, assignCompose)}
+			},
+		},
+	} {
+		for _, n := range testSizes {
+			values := make([]int, n)
+			copy(values, testValues)
+
+			tree := test.newTree(values)
+			if tree.Len() != n {
+				t.Fatalf("[%s/%d] Len() returned %d", test.name, n, tree.Len())
+			}
+
+			for op := 0; op < opsCount; op++ {
+				switch op % 4 {
+				case 0:
+					l, r := randRange(rnd, n)
+					tree.update(rnd, l, r, values)
+				case 1:
+					i, v := rnd.Intn(n), rnd.Int() % (MaxItem + 1)
+					values[i] = v
+					tree.Set(i, v)
+				case 2:
+					i := rnd.Intn(n)
+					if g := tree.Get(i); g != values[i] {
+						t.Fatalf("[%s/%d/%d] Get(%d) returned %d, want - %d", test.name, n, op, i, g, values[i])
+					}
+				default:
+					l, r := randRange(rnd, n)
+					want := naiveQuery(values, l, r, test.identity, test.combine)
+					if got := tree.Query(l, r); got != want {
+						t.Fatalf("[%s/%d/%d] Query(%d, %d) returned %d, want - %d", test.name, n, op, l, r, got, want)
+					}
+				}
+
+				if op % selfTestStep == 0 {
+					if _, err := tree.SelfTest(); err != nil {
+						t.Fatalf("[%s/%d/%d] Self-test failed: %v", test.name, n, op, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLazySegTreeEmpty(t *testing.T) {
+	tree := NewLazySegTree(nil, 0, sum, addSum, sum)
+
+	tree.Update(0, 0, 1)
+	if tree.Len() != 0 || tree.Query(0, 0) != 0 {
+		t.Errorf("Empty tree returned Len() - %d, Query(0, 0) - %d", tree.Len(), tree.Query(0, 0))
+	}
+
+	if height, err := tree.SelfTest(); err != nil || height != 0 {
+		t.Errorf("Self-test of empty tree returned %d, %v", height, err)
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	testValues := newTestValues(rnd, 100)
+
+	tree := NewSegTree(testValues, 0, sum)
+	tree.tree[tree.leaves + 100] = 1
+	if _, err := tree.SelfTest(); err == nil || !strings.HasPrefix(err.Error(), "v#1: unused leaf 100") {
+		t.Errorf("SelfTest() returned %v, want v#1 error", err)
+	}
+
+	tree = NewSegTree(testValues, 0, sum)
+	tree.tree[tree.leaves + 5]++
+	if _, err := tree.SelfTest(); err == nil || !strings.HasPrefix(err.Error(), "v#2: node") {
+		t.Errorf("SelfTest() returned %v, want v#2 error", err)
+	}
+
+	lazy := NewLazySegTree(testValues, 0, sum, addSum, sum)
+	lazy.Update(10, 20, 5)
+	lazy.tree[1]++
+	if _, err := lazy.SelfTest(); err == nil || !strings.HasPrefix(err.Error(), "v#2: node [0, 100)") {
+		t.Errorf("SelfTest() returned %v, want v#2 error", err)
+	}
+
+	// Node 2 is the leaf of the tree with two values
+	lazy = NewLazySegTree(testValues[:2], 0, sum, addSum, sum)
+	lazy.pending[2] = true
+	if _, err := lazy.SelfTest(); err == nil || !strings.HasPrefix(err.Error(), "v#3: leaf 0") {
+		t.Errorf("SelfTest() returned %v, want v#3 error", err)
+	}
+}
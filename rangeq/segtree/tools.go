@@ -0,0 +1,82 @@
+package segtree
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SelfTest performs a self-test of the tree and returns the height of the tree,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero. The aggregates are compared using reflect.DeepEqual, so the
+// combine function must give exactly the same results for the same arguments.
+func (t *SegTree[T]) SelfTest() (int, error) {
+	for i := t.n; i < t.leaves; i++ {
+		if !reflect.DeepEqual(t.tree[t.leaves+i], t.identity) {
+			return 0, fmt.Errorf("v#1: unused leaf %d contains %v instead of the identity value %v",
+				i, t.tree[t.leaves+i], t.identity)
+		}
+	}
+
+	for i := t.leaves - 1; i > 0; i-- {
+		if want := t.combine(t.tree[2*i], t.tree[2*i+1]); !reflect.DeepEqual(t.tree[i], want) {
+			return 0, fmt.Errorf("v#2: node %d contains aggregate %v, want - %v", i, t.tree[i], want)
+		}
+	}
+
+	// OK, calculate the height of the tree
+	height := 1
+	for l := t.leaves; l > 1; l >>= 1 {
+		height++
+	}
+
+	return height, nil
+}
+
+// SelfTest performs a self-test of the tree and returns the height of the tree,
+// and a description of the problem if detected. If an issue is detected, the
+// height is zero. The aggregates are compared using reflect.DeepEqual, so the
+// apply and combine functions must give exactly the same results for the same
+// arguments.
+func (t *LazySegTree[T, U]) SelfTest() (int, error) {
+	if t.n == 0 {
+		return 0, nil
+	}
+
+	return t.check(1, 0, t.n)
+}
+
+// check checks the subtree with root node that covers the segment [nl, nr) and returns its height
+func (t *LazySegTree[T, U]) check(node, nl, nr int) (int, error) {
+	if nr - nl == 1 {
+		if t.pending[node] {
+			return 0, fmt.Errorf("v#3: leaf %d has pending update %v", nl, t.lazy[node])
+		}
+
+		return 1, nil
+	}
+
+	mid := (nl + nr) / 2
+	lh, err := t.check(2*node, nl, mid)
+	if err != nil {
+		return 0, err
+	}
+	rh, err := t.check(2*node+1, mid, nr)
+	if err != nil {
+		return 0, err
+	}
+
+	// The pending update is already applied to the aggregate of the node, but not to its children
+	want := t.combine(t.tree[2*node], t.tree[2*node+1])
+	if t.pending[node] {
+		want = t.apply(t.lazy[node], want, nr - nl)
+	}
+	if !reflect.DeepEqual(t.tree[node], want) {
+		return 0, fmt.Errorf("v#2: node [%d, %d) contains aggregate %v, want - %v", nl, nr, t.tree[node], want)
+	}
+
+	if rh > lh {
+		lh = rh
+	}
+
+	return lh + 1, nil
+}
@@ -0,0 +1,173 @@
+/*
+Package dsu provides an example of a disjoint-set union (union-find) implementation.
+
+The structure keeps a partition of elements into disjoint sets, each set is
+represented by a tree of elements with the representative of the set in the
+root. Union of sets attaches the root of one tree to the root of another using
+one of the strategies - by rank or by size, that keeps trees shallow. Find of
+the representative compresses the path from the element to the root, so both
+operations take nearly constant O(α(n)) amortized time.
+
+The package contains three variants of the structure:
+  - DSU - elements are integers in the range [0, Len())
+  - Generic - elements are arbitrary comparable values
+  - RollbackDSU - integer elements without path compression, each union can be
+    undone, that is required by offline algorithms like dynamic connectivity
+*/
+package dsu
+
+import "fmt"
+
+// Strategy defines which of two roots becomes the root of the united set
+type Strategy int
+const (
+	// ByRank - the root of the tree with the greater rank (upper bound of the height) wins
+	ByRank = Strategy(iota)
+	// BySize - the root of the tree with the greater number of elements wins
+	BySize
+)
+
+func (s Strategy) String() string {
+	switch s {
+		case ByRank:	return "ByRank"
+		case BySize:	return "BySize"
+	}
+
+	panic(fmt.Sprintf("Unexpected strategy value: %d", s))
+}
+
+// DSU implements a disjoint-set union of integer elements.
+type DSU struct {
+	parent	[]int
+	// Number of elements in the set, valid only for roots
+	size	[]int
+	// Upper bound of the height of the tree, valid only for roots
+	rank	[]int
+	// Number of disjoint sets
+	count	int
+
+	strategy	Strategy
+}
+
+// NewDSU returns new structure with n elements, each element is in its own set.
+func NewDSU(n int, strategy Strategy) *DSU {
+	// Check for the correct strategy
+	_ = strategy.String()
+
+	if n < 0 {
+		panic(fmt.Sprintf("Invalid number of elements %d", n))
+	}
+
+	d := &DSU{
+		parent:		make([]int, 0, n),
+		size:		make([]int, 0, n),
+		rank:		make([]int, 0, n),
+		strategy:	strategy,
+	}
+	for i := 0; i < n; i++ {
+		d.Add()
+	}
+
+	return d
+}
+
+// Strategy returns the strategy of union
+func (d *DSU) Strategy() Strategy {
+	return d.strategy
+}
+
+// Len returns the number of elements
+func (d *DSU) Len() int {
+	return len(d.parent)
+}
+
+// Count returns the number of disjoint sets
+func (d *DSU) Count() int {
+	return d.count
+}
+
+// Add adds a new element in its own set and returns the element.
+func (d *DSU) Add() int {
+	x := len(d.parent)
+
+	d.parent = append(d.parent, x)
+	d.size = append(d.size, 1)
+	d.rank = append(d.rank, 0)
+	d.count++
+
+	return x
+}
+
+// Find returns the representative of the set containing x.
+func (d *DSU) Find(x int) int {
+	d.checkElement(x)
+
+	// Find the root
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+
+	// Compress the path - attach all elements on the path directly to the root
+	for d.parent[x] != root {
+		x, d.parent[x] = d.parent[x], root
+	}
+
+	return root
+}
+
+// Union unites sets containing x and y. It returns false if they are already in the same set.
+func (d *DSU) Union(x, y int) bool {
+	x, y = d.Find(x), d.Find(y)
+	if x == y {
+		// Nothing to do
+		return false
+	}
+
+	// Make x the root of the united set
+	if less(d.strategy, d.size, d.rank, x, y) {
+		x, y = y, x
+	}
+
+	d.parent[y] = x
+	d.size[x] += d.size[y]
+	if d.rank[x] == d.rank[y] {
+		d.rank[x]++
+	}
+	d.count--
+
+	return true
+}
+
+// Connected returns true if x and y are in the same set.
+func (d *DSU) Connected(x, y int) bool {
+	return d.Find(x) == d.Find(y)
+}
+
+// Size returns the number of elements in the set containing x.
+func (d *DSU) Size(x int) int {
+	return d.size[d.Find(x)]
+}
+
+// SelfTest performs a self-test of the structure and returns the maximal height of
+// trees of sets, and a description of the problem if detected. If an issue is
+// detected, the height is zero.
+func (d *DSU) SelfTest() (int, error) {
+	return selfTest(d.strategy, d.parent, d.size, d.rank, d.count)
+}
+
+// checkElement panics if x is not an element of the structure
+func (d *DSU) checkElement(x int) {
+	if x < 0 || x >= len(d.parent) {
+		panic(fmt.Sprintf("element %d out of range [0, %d)", x, len(d.parent)))
+	}
+}
+
+// less reports whether the tree with root x should be attached to the tree with root y
+func less(strategy Strategy, size, rank []int, x, y int) bool {
+	if strategy == ByRank {
+		return rank[x] < rank[y]
+	}
+
+	return size[x] < size[y]
+}
@@ -0,0 +1,297 @@
+package dsu
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+const (
+	elemsCount	=	10240
+
+	// Number of random unions for each test
+	unionsCount	=	8192
+	// Run self-test after each selfTestStep modifications
+	selfTestStep	=	64
+	// Seed of random sources of tests
+	testSeed	=	2038
+)
+
+// newTestPairs returns n random pairs of elements to union generated by rnd
+func newTestPairs(rnd *rand.Rand, n int) [][2]int {
+	pairs := make([][2]int, 0, n)
+	for i := 0; i < n; i++ {
+		pairs = append(pairs, [2]int{rnd.Intn(elemsCount), rnd.Intn(elemsCount)})
+	}
+
+	return pairs
+}
+
+//nolint:gochecknoglobals
+var testStrategies = []Strategy{ByRank, BySize}
+
+// naiveDSU labels each element by the number of its set, union relabels all elements of one set
+type naiveDSU []int
+
+func newNaiveDSU(n int) naiveDSU {
+	nd := make(naiveDSU, n)
+	for i := range nd {
+		nd[i] = i
+	}
+
+	return nd
+}
+
+func (nd naiveDSU) union(x, y int) bool {
+	lx, ly := nd[x], nd[y]
+	if lx == ly {
+		return false
+	}
+
+	for i := range nd {
+		if nd[i] == ly {
+			nd[i] = lx
+		}
+	}
+
+	return true
+}
+
+func (nd naiveDSU) size(x int) int {
+	n := 0
+	for _, l := range nd {
+		if l == nd[x] {
+			n++
+		}
+	}
+
+	return n
+}
+
+// ufModel is a common interface of DSU and RollbackDSU for tests
+type ufModel interface {
+	Len() int
+	Count() int
+	Find(x int) int
+	Union(x, y int) bool
+	Connected(x, y int) bool
+	Size(x int) int
+	SelfTest() (int, error)
+}
+
+func testUnions(t *testing.T, rnd *rand.Rand, d ufModel, name string) {
+	t.Helper()
+
+	testPairs := newTestPairs(rnd, unionsCount)
+	nd := newNaiveDSU(d.Len())
+	count := d.Len()
+
+	for i, p := range testPairs {
+		want := nd.union(p[0], p[1])
+		if want {
+			count--
+		}
+
+		if ok := d.Union(p[0], p[1]); ok != want {
+			t.Fatalf("[%s:%d] Union(%d, %d) returned %t, want - %t", name, i, p[0], p[1], ok, want)
+		}
+
+		if d.Count() != count {
+			t.Fatalf("[%s:%d] Count() returned %d, want - %d", name, i, d.Count(), count)
+		}
+
+		if i % selfTestStep != 0 {
+			continue
+		}
+
+		if _, err := d.SelfTest(); err != nil {
+			t.Fatalf("[%s:%d] structure issue after Union(%d, %d): %v", name, i, p[0], p[1], err)
+		}
+
+		// Check random elements against the model
+		x, y := rnd.Intn(d.Len()), rnd.Intn(d.Len())
+		if c := d.Connected(x, y); c != (nd[x] == nd[y]) {
+			t.Fatalf("[%s:%d] Connected(%d, %d) returned %t, want - %t", name, i, x, y, c, !c)
+		}
+		if s := d.Size(x); s != nd.size(x) {
+			t.Fatalf("[%s:%d] Size(%d) returned %d, want - %d", name, i, x, s, nd.size(x))
+		}
+	}
+
+	// Elements are in the same set if and only if they have the same representative
+	reprs := map[int]int{}
+	for x := 0; x < d.Len(); x++ {
+		r := d.Find(x)
+		if l, ok := reprs[r]; ok && l != nd[x] {
+			t.Fatalf("[%s] element %d has representative %d of another set", name, x, r)
+		}
+		reprs[r] = nd[x]
+	}
+
+	h, err := d.SelfTest()
+	if err != nil {
+		t.Fatalf("[%s] structure issue: %v", name, err)
+	}
+
+	// Height of the biggest tree with elemsCount elements is bounded by log2(elemsCount) + 1
+	if maxHeight := 14; h > maxHeight {
+		t.Errorf("[%s] height of trees is %d, want - no more than %d", name, h, maxHeight)
+	}
+}
+
+func TestDSU(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	for _, s := range testStrategies {
+		d := NewDSU(elemsCount, s)
+		if d.Strategy() != s || d.Len() != elemsCount || d.Count() != elemsCount {
+			t.Errorf("[%v] NewDSU returned structure with strategy %v, Len() %d, Count() %d",
+				s, d.Strategy(), d.Len(), d.Count())
+		}
+
+		testUnions(t, rnd, d, s.String())
+	}
+}
+
+func TestDSUAdd(t *testing.T) {
+	d := NewDSU(0, BySize)
+	for i := 0; i < 4; i++ {
+		if x := d.Add(); x != i {
+			t.Fatalf("Add() returned %d, want - %d", x, i)
+		}
+	}
+
+	d.Union(0, 1)
+	d.Union(2, 3)
+	x := d.Add()
+	if d.Count() != 3 || d.Size(x) != 1 || d.Connected(x, 0) {
+		t.Errorf("added element %d is not in its own set: Count() %d, Size() %d", x, d.Count(), d.Size(x))
+	}
+
+	if _, err := d.SelfTest(); err != nil {
+		t.Errorf("structure issue: %v", err)
+	}
+}
+
+func TestRollbackDSU(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	for _, s := range testStrategies {
+		d := NewRollbackDSU(elemsCount, s)
+		testUnions(t, rnd, d, "rollback-" + s.String())
+
+		// Undo all unions in reverse order checking states saved on the way
+		d = NewRollbackDSU(elemsCount, s)
+		type state struct{
+			parent, size, rank	[]int
+			count				int
+		}
+		states := []state{}
+		snapshots := []int{}
+		for i, p := range newTestPairs(rnd, unionsCount) {
+			if i % selfTestStep == 0 {
+				states = append(states, state{
+					append([]int{}, d.parent...), append([]int{}, d.size...), append([]int{}, d.rank...), d.count,
+				})
+				snapshots = append(snapshots, d.Snapshot())
+			}
+			d.Union(p[0], p[1])
+		}
+
+		for i := len(states) - 1; i >= 0; i-- {
+			d.Rollback(snapshots[i])
+
+			if got := (state{d.parent, d.size, d.rank, d.count}); !reflect.DeepEqual(got, states[i]) {
+				t.Fatalf("[%v:%d] state after Rollback(%d) differs from the saved state", s, i, snapshots[i])
+			}
+
+			if _, err := d.SelfTest(); err != nil {
+				t.Fatalf("[%v:%d] structure issue after Rollback(%d): %v", s, i, snapshots[i], err)
+			}
+		}
+
+		if d.Undo() || d.Count() != elemsCount {
+			t.Errorf("[%v] Undo() on the initial state returned true or Count() %d != %d", s, d.Count(), elemsCount)
+		}
+	}
+}
+
+func TestGeneric(t *testing.T) {
+	g := NewGeneric[string](ByRank)
+
+	for _, v := range []string{"a", "b", "c", "d", "e", "f"} {
+		if !g.Add(v) {
+			t.Fatalf("Add(%q) returned false on new value", v)
+		}
+	}
+	if g.Add("a") {
+		t.Errorf("Add(%q) returned true on existing value", "a")
+	}
+
+	g.Union("a", "c")
+	g.Union("e", "f")
+	g.Union("c", "e")
+	// New values are added by Union
+	g.Union("g", "b")
+
+	if g.Len() != 7 || g.Count() != 3 {
+		t.Errorf("Len() returned %d, Count() returned %d, want - 7, 3", g.Len(), g.Count())
+	}
+
+	if want := [][]string{{"a", "c", "e", "f"}, {"b", "g"}, {"d"}}; !reflect.DeepEqual(g.Sets(), want) {
+		t.Errorf("Sets() returned %v, want - %v", g.Sets(), want)
+	}
+
+	if !g.Connected("a", "f") || g.Connected("a", "b") || g.Connected("a", "x") {
+		t.Errorf("Connected() returned wrong results")
+	}
+
+	if ra, _ := g.Find("a"); !g.Contains(ra) || g.Size(ra) != 4 || g.Size("x") != 0 {
+		t.Errorf("Find(%q) returned %q with size %d", "a", ra, g.Size(ra))
+	}
+
+	if _, ok := g.Find("x"); ok {
+		t.Errorf("Find(%q) returned true on absent value", "x")
+	}
+
+	if _, err := g.SelfTest(); err != nil {
+		t.Errorf("structure issue: %v", err)
+	}
+}
+
+func TestSelfTestFail(t *testing.T) {
+	for i, test := range []struct {
+		breaker	func(d *DSU)
+		want	string
+	} {
+		{ func(d *DSU) { d.parent[3] = -1 }, "v#1" },
+		{ func(d *DSU) { d.parent[d.Find(0)] = 1 }, "v#2" },
+		{ func(d *DSU) { d.count++ }, "v#3" },
+		{ func(d *DSU) { d.size[d.Find(0)]++ }, "v#4" },
+		{ func(d *DSU) {
+			// Make a chain 4 -> 5 -> 6 -> 7 keeping sizes and ranks consistent
+			for x := 4; x < 7; x++ {
+				d.parent[x] = x + 1
+				d.size[x+1] += d.size[x]
+				d.rank[x+1] = d.rank[x] + 1
+			}
+			d.count -= 3
+		}, "v#5" },
+	} {
+		d := NewDSU(8, ByRank)
+		d.Union(0, 1)
+		d.Union(2, 3)
+		d.Union(0, 2)
+		test.breaker(d)
+
+		h, err := d.SelfTest()
+		if err == nil || h != 0 {
+			t.Errorf("[%d] SelfTest returned %d, %v on broken structure, want - 0, %s error", i, h, err, test.want)
+			continue
+		}
+
+		if msg := err.Error(); msg[:len(test.want)] != test.want {
+			t.Errorf("[%d] SelfTest returned error %q, want - %s error", i, msg, test.want)
+		}
+	}
+}
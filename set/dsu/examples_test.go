@@ -0,0 +1,78 @@
+package dsu
+
+import (
+	"fmt"
+	"sort"
+)
+
+func Example_kruskal() {
+	// Weighted edges of the graph with 6 vertices
+	type edge struct{ u, v, w int }
+	edges := []edge{
+		{0, 1, 7}, {0, 3, 5}, {1, 2, 8}, {1, 3, 9}, {1, 4, 7},
+		{2, 4, 5}, {3, 4, 15}, {3, 5, 6}, {4, 5, 8},
+	}
+
+	// Take edges in ascending order of weights, skip edges that make cycles
+	sort.Slice(edges, func(i, j int) bool { return edges[i].w < edges[j].w })
+
+	d := NewDSU(6, ByRank)
+	total := 0
+	for _, e := range edges {
+		if d.Union(e.u, e.v) {
+			fmt.Printf("%d-%d (%d)\n", e.u, e.v, e.w)
+			total += e.w
+		}
+	}
+	fmt.Println("Total weight:", total, "components:", d.Count())
+
+	// Output:
+	// 0-3 (5)
+	// 2-4 (5)
+	// 3-5 (6)
+	// 0-1 (7)
+	// 1-4 (7)
+	// Total weight: 30 components: 1
+}
+
+func Example_clustering() {
+	g := NewGeneric[string](BySize)
+
+	// Pairs of hosts that talk to each other
+	for _, link := range [][2]string{
+		{"web1", "db1"}, {"web2", "db1"}, {"cache1", "web1"},
+		{"batch1", "db2"}, {"batch2", "db2"},
+	} {
+		g.Union(link[0], link[1])
+	}
+	g.Add("standalone")
+
+	for _, set := range g.Sets() {
+		fmt.Println(set)
+	}
+	fmt.Println("web2 and cache1 connected:", g.Connected("web2", "cache1"))
+
+	// Output:
+	// [web1 db1 web2 cache1]
+	// [batch1 db2 batch2]
+	// [standalone]
+	// web2 and cache1 connected: true
+}
+
+func Example_rollback() {
+	d := NewRollbackDSU(5, BySize)
+	d.Union(0, 1)
+
+	// Try unions and undo them
+	snapshot := d.Snapshot()
+	d.Union(1, 2)
+	d.Union(3, 4)
+	fmt.Println("Components:", d.Count(), "connected 0 and 2:", d.Connected(0, 2))
+
+	d.Rollback(snapshot)
+	fmt.Println("Components:", d.Count(), "connected 0 and 2:", d.Connected(0, 2))
+
+	// Output:
+	// Components: 2 connected 0 and 2: true
+	// Components: 4 connected 0 and 2: false
+}
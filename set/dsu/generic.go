@@ -0,0 +1,111 @@
+package dsu
+
+// Generic implements a disjoint-set union of arbitrary comparable values.
+type Generic[T comparable] struct {
+	d		*DSU
+	// Values by elements of d
+	values	[]T
+	// Elements of d by values
+	index	map[T]int
+}
+
+// NewGeneric returns new empty structure.
+func NewGeneric[T comparable](strategy Strategy) *Generic[T] {
+	return &Generic[T]{
+		d:		NewDSU(0, strategy),
+		index:	map[T]int{},
+	}
+}
+
+// Len returns the number of values
+func (g *Generic[T]) Len() int {
+	return g.d.Len()
+}
+
+// Count returns the number of disjoint sets
+func (g *Generic[T]) Count() int {
+	return g.d.Count()
+}
+
+// Add adds the value v in its own set. It returns false if v was already added.
+func (g *Generic[T]) Add(v T) bool {
+	if _, ok := g.index[v]; ok {
+		return false
+	}
+
+	g.index[v] = g.d.Add()
+	g.values = append(g.values, v)
+
+	return true
+}
+
+// Contains returns true if v was added.
+func (g *Generic[T]) Contains(v T) bool {
+	_, ok := g.index[v]
+	return ok
+}
+
+// Find returns the representative of the set containing v, or false if v was not added.
+func (g *Generic[T]) Find(v T) (T, bool) {
+	x, ok := g.index[v]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+
+	return g.values[g.d.Find(x)], true
+}
+
+// Union unites sets containing a and b, values that were not added before are added.
+// It returns false if a and b are already in the same set.
+func (g *Generic[T]) Union(a, b T) bool {
+	g.Add(a)
+	g.Add(b)
+
+	return g.d.Union(g.index[a], g.index[b])
+}
+
+// Connected returns true if a and b were added and they are in the same set.
+func (g *Generic[T]) Connected(a, b T) bool {
+	x, okA := g.index[a]
+	y, okB := g.index[b]
+
+	return okA && okB && g.d.Connected(x, y)
+}
+
+// Size returns the number of values in the set containing v, or 0 if v was not added.
+func (g *Generic[T]) Size(v T) int {
+	x, ok := g.index[v]
+	if !ok {
+		return 0
+	}
+
+	return g.d.Size(x)
+}
+
+// Sets returns all disjoint sets. Sets are ordered by their first added values,
+// values in each set are in the order of adding.
+func (g *Generic[T]) Sets() [][]T {
+	sets := make([][]T, 0, g.Count())
+	// Indexes of sets by roots
+	setIdx := make(map[int]int, g.Count())
+
+	for x, v := range g.values {
+		root := g.d.Find(x)
+		i, ok := setIdx[root]
+		if !ok {
+			i = len(sets)
+			setIdx[root] = i
+			sets = append(sets, make([]T, 0, g.d.size[root]))
+		}
+
+		sets[i] = append(sets[i], v)
+	}
+
+	return sets
+}
+
+// SelfTest performs a self-test of the structure, see DSU.SelfTest.
+func (g *Generic[T]) SelfTest() (int, error) {
+	return g.d.SelfTest()
+}
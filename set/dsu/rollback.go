@@ -0,0 +1,158 @@
+package dsu
+
+import "fmt"
+
+// RollbackDSU implements a disjoint-set union of integer elements, that can undo unions
+// in the reverse order. It does not compress paths, so Find takes O(log n) time.
+type RollbackDSU struct {
+	parent	[]int
+	size	[]int
+	rank	[]int
+	count	int
+
+	strategy	Strategy
+
+	// Unions in order of performing
+	history	[]union
+}
+
+// union describes a performed union to undo it
+type union struct {
+	// Root of the attached tree
+	child	int
+	// Root of the united set
+	root	int
+	// Was the rank of root increased
+	rankInc	bool
+}
+
+// NewRollbackDSU returns new structure with n elements, each element is in its own set.
+func NewRollbackDSU(n int, strategy Strategy) *RollbackDSU {
+	// Check for the correct strategy
+	_ = strategy.String()
+
+	if n < 0 {
+		panic(fmt.Sprintf("Invalid number of elements %d", n))
+	}
+
+	d := &RollbackDSU{
+		parent:		make([]int, n),
+		size:		make([]int, n),
+		rank:		make([]int, n),
+		count:		n,
+		strategy:	strategy,
+	}
+	for i := range d.parent {
+		d.parent[i] = i
+		d.size[i] = 1
+	}
+
+	return d
+}
+
+// Strategy returns the strategy of union
+func (d *RollbackDSU) Strategy() Strategy {
+	return d.strategy
+}
+
+// Len returns the number of elements
+func (d *RollbackDSU) Len() int {
+	return len(d.parent)
+}
+
+// Count returns the number of disjoint sets
+func (d *RollbackDSU) Count() int {
+	return d.count
+}
+
+// Find returns the representative of the set containing x.
+func (d *RollbackDSU) Find(x int) int {
+	if x < 0 || x >= len(d.parent) {
+		panic(fmt.Sprintf("element %d out of range [0, %d)", x, len(d.parent)))
+	}
+
+	for d.parent[x] != x {
+		x = d.parent[x]
+	}
+
+	return x
+}
+
+// Union unites sets containing x and y. It returns false if they are already in the same set,
+// in this case nothing is added to the history.
+func (d *RollbackDSU) Union(x, y int) bool {
+	x, y = d.Find(x), d.Find(y)
+	if x == y {
+		// Nothing to do
+		return false
+	}
+
+	// Make x the root of the united set
+	if less(d.strategy, d.size, d.rank, x, y) {
+		x, y = y, x
+	}
+
+	u := union{child: y, root: x, rankInc: d.rank[x] == d.rank[y]}
+
+	d.parent[y] = x
+	d.size[x] += d.size[y]
+	if u.rankInc {
+		d.rank[x]++
+	}
+	d.count--
+
+	d.history = append(d.history, u)
+
+	return true
+}
+
+// Connected returns true if x and y are in the same set.
+func (d *RollbackDSU) Connected(x, y int) bool {
+	return d.Find(x) == d.Find(y)
+}
+
+// Size returns the number of elements in the set containing x.
+func (d *RollbackDSU) Size(x int) int {
+	return d.size[d.Find(x)]
+}
+
+// Snapshot returns the current state, that can be restored by Rollback.
+func (d *RollbackDSU) Snapshot() int {
+	return len(d.history)
+}
+
+// Undo undoes the last performed union. It returns false if there are no unions to undo.
+func (d *RollbackDSU) Undo() bool {
+	if len(d.history) == 0 {
+		return false
+	}
+
+	u := d.history[len(d.history)-1]
+	d.history = d.history[:len(d.history)-1]
+
+	// Detach the child tree and restore the root
+	d.parent[u.child] = u.child
+	d.size[u.root] -= d.size[u.child]
+	if u.rankInc {
+		d.rank[u.root]--
+	}
+	d.count++
+
+	return true
+}
+
+// Rollback undoes all unions performed after the snapshot was made.
+func (d *RollbackDSU) Rollback(snapshot int) {
+	if snapshot < 0 || snapshot > len(d.history) {
+		panic(fmt.Sprintf("invalid snapshot %d, current state is %d", snapshot, len(d.history)))
+	}
+
+	for len(d.history) > snapshot {
+		d.Undo()
+	}
+}
+
+// SelfTest performs a self-test of the structure, see DSU.SelfTest.
+func (d *RollbackDSU) SelfTest() (int, error) {
+	return selfTest(d.strategy, d.parent, d.size, d.rank, d.count)
+}
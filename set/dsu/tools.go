@@ -0,0 +1,65 @@
+package dsu
+
+import "fmt"
+
+// selfTest checks the forest defined by parent, size and rank arrays
+func selfTest(strategy Strategy, parent, size, rank []int, count int) (int, error) {
+	// Check links to parents
+	for x, p := range parent {
+		if p < 0 || p >= len(parent) {
+			return 0, fmt.Errorf("v#1: element %d has parent %d out of range [0, %d)", x, p, len(parent))
+		}
+
+		if p == x {
+			// Root
+			continue
+		}
+
+		// The size and the rank of a child are frozen when it is attached to the parent, so
+		// they have to be less than the size and the rank of any ancestor
+		if size[x] >= size[p] || strategy == ByRank && rank[x] >= rank[p] {
+			return 0, fmt.Errorf("v#2: element %d (size %d, rank %d) is not less than its parent %d" +
+				" (size %d, rank %d)", x, size[x], rank[x], p, size[p], rank[p])
+		}
+	}
+
+	// XXX Sizes strictly increase on the way to the root, so there are no cycles
+
+	// Number of elements and the height of each tree
+	sizes := make(map[int]int, count)
+	heights := make(map[int]int, count)
+	maxHeight := 0
+	for x := range parent {
+		root, h := x, 1
+		for parent[root] != root {
+			root, h = parent[root], h + 1
+		}
+
+		sizes[root]++
+		if h > heights[root] {
+			heights[root] = h
+		}
+		if h > maxHeight {
+			maxHeight = h
+		}
+	}
+
+	if len(sizes) != count {
+		return 0, fmt.Errorf("v#3: number of sets is %d, want - %d", len(sizes), count)
+	}
+
+	for root, n := range sizes {
+		if size[root] != n {
+			return 0, fmt.Errorf("v#4: set with root %d has size %d, want - %d", root, size[root], n)
+		}
+
+		// Both strategies guarantee that the tree of n elements is not higher than log2(n) + 1
+		if h := heights[root]; 1 << (h - 1) > n {
+			return 0, fmt.Errorf("v#5: tree with root %d has height %d, that is too high for %d elements",
+				root, h, n)
+		}
+	}
+
+	// OK
+	return maxHeight, nil
+}
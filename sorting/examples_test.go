@@ -0,0 +1,49 @@
+package sorting
+
+import "fmt"
+
+func Example_sorting() {
+	s := []int{20, 10, 30, 5, 15, 25, 35, 8, 17, 37, 33, 13, 2, 23, 27}
+
+	stats := QuickSort(s, func(a, b int) bool { return a < b })
+	fmt.Println(s)
+	fmt.Println(stats.Comparisons > 0, stats.Moves)
+
+	// Output:
+	// [2 5 8 10 13 15 17 20 23 25 27 30 33 35 37]
+	// true 0
+}
+
+func Example_complexity() {
+	less := func(a, b int) bool { return a < b }
+
+	// Reversed input is the worst case for the insertion sort
+	for _, n := range []int{10, 100, 1000} {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = n - i
+		}
+
+		fmt.Printf("n = %4d: %v\n", n, InsertionSort(s, less))
+	}
+
+	// Output:
+	// n =   10: comparisons: 45, swaps: 45, moves: 0
+	// n =  100: comparisons: 4950, swaps: 4950, moves: 0
+	// n = 1000: comparisons: 499500, swaps: 499500, moves: 0
+}
+
+func Example_stableSort() {
+	type event struct {
+		ts		int
+		name	string
+	}
+	events := []event{{3, "c"}, {1, "a"}, {3, "d"}, {2, "b"}, {1, "e"}}
+
+	// Events with equal timestamps keep their order
+	RadixSort(events, func(e event) uint64 { return IntKey(int64(e.ts)) })
+	fmt.Println(events)
+
+	// Output:
+	// [{1 a} {1 e} {2 b} {3 c} {3 d}]
+}
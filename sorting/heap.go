@@ -0,0 +1,46 @@
+package sorting
+
+// HeapSort sorts s by the heap sort: the slice is turned into a max-heap in O(n) time,
+// then the maximal element is swapped to the end of the slice n-1 times.
+func HeapSort[T any](s []T, less func(a, b T) bool) Stats {
+	c := counter[T]{less: less}
+	c.heapSort(s)
+
+	return c.stats
+}
+
+func (c *counter[T]) heapSort(s []T) {
+	// Build the heap
+	for i := len(s) / 2 - 1; i >= 0; i-- {
+		c.siftDown(s, i)
+	}
+
+	// Move maximums to the end
+	for end := len(s) - 1; end > 0; end-- {
+		c.swap(s, 0, end)
+		c.siftDown(s[:end], 0)
+	}
+}
+
+// siftDown moves the element i of the max-heap s down to its place
+func (c *counter[T]) siftDown(s []T, i int) {
+	for {
+		child := 2 * i + 1
+		if child >= len(s) {
+			return
+		}
+
+		// Select the greater child
+		if child + 1 < len(s) && c.lt(s[child], s[child+1]) {
+			child++
+		}
+
+		if !c.lt(s[i], s[child]) {
+			// Heap property is restored
+			return
+		}
+
+		c.swap(s, i, child)
+		i = child
+	}
+}
@@ -0,0 +1,42 @@
+package sorting
+
+// MergeSort sorts s by the top-down merge sort using a buffer of the same length as s.
+// Moves count writes of elements both to the buffer and back to s.
+func MergeSort[T any](s []T, less func(a, b T) bool) Stats {
+	c := counter[T]{less: less}
+	c.mergeSort(s, make([]T, len(s)))
+
+	return c.stats
+}
+
+// mergeSort sorts s using buf of the same length
+func (c *counter[T]) mergeSort(s, buf []T) {
+	if len(s) < 2 {
+		return
+	}
+
+	mid := len(s) / 2
+	c.mergeSort(s[:mid], buf[:mid])
+	c.mergeSort(s[mid:], buf[mid:])
+
+	if !c.lt(s[mid], s[mid-1]) {
+		// Halves are already in order
+		return
+	}
+
+	// Merge halves into the buffer, take the left element on equality to keep stability
+	i, j := 0, mid
+	for k := range buf {
+		if j == len(s) || i < mid && !c.lt(s[j], s[i]) {
+			buf[k] = s[i]
+			i++
+		} else {
+			buf[k] = s[j]
+			j++
+		}
+	}
+	c.stats.Moves += len(s)
+
+	copy(s, buf)
+	c.stats.Moves += len(s)
+}
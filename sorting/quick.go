@@ -0,0 +1,105 @@
+package sorting
+
+// insertionThreshold is the length of ranges that IntroSort sorts by the insertion sort
+const insertionThreshold = 16
+
+// QuickSort sorts s by the quick sort, the pivot is the median of the first, the middle
+// and the last elements of the range. The smaller part is sorted recursively and the
+// greater one in the loop, so the depth of recursion is O(log n).
+func QuickSort[T any](s []T, less func(a, b T) bool) Stats {
+	c := counter[T]{less: less}
+	c.quickSort(s, -1)
+
+	return c.stats
+}
+
+// IntroSort sorts s by the introspective sort: the quick sort that switches to the heap sort
+// when the depth of recursion exceeds 2*log2(n) and finishes small ranges by the insertion sort.
+func IntroSort[T any](s []T, less func(a, b T) bool) Stats {
+	c := counter[T]{less: less}
+
+	depth := 0
+	for n := len(s); n > 1; n >>= 1 {
+		depth += 2
+	}
+	c.quickSort(s, depth)
+
+	return c.stats
+}
+
+// quickSort sorts s, if depth is not negative, the range that needs more than depth
+// partitions is sorted by the heap sort and small ranges by the insertion sort
+func (c *counter[T]) quickSort(s []T, depth int) {
+	for len(s) > 1 {
+		if depth >= 0 {
+			if len(s) <= insertionThreshold {
+				c.insertion(s)
+				return
+			}
+
+			if depth == 0 {
+				c.heapSort(s)
+				return
+			}
+			depth--
+		}
+
+		p := c.partition(s)
+
+		// Recursion for the smaller part
+		if p < len(s) - p {
+			c.quickSort(s[:p], depth)
+			s = s[p+1:]
+		} else {
+			c.quickSort(s[p+1:], depth)
+			s = s[:p]
+		}
+	}
+}
+
+// partition places the pivot to its final position p and returns p, all elements
+// before p are not greater than the pivot, after p - not less
+func (c *counter[T]) partition(s []T) int {
+	last := len(s) - 1
+	if len(s) >= 3 {
+		// Sort the first, the middle and the last elements, the median is in the middle
+		mid := len(s) / 2
+		if c.lt(s[mid], s[0]) {
+			c.swap(s, mid, 0)
+		}
+		if c.lt(s[last], s[mid]) {
+			c.swap(s, last, mid)
+			if c.lt(s[mid], s[0]) {
+				c.swap(s, mid, 0)
+			}
+		}
+
+		// Use the median as pivot placed to the end
+		c.swap(s, mid, last)
+	}
+
+	// Hoare-style scan from both ends, elements equal to the pivot stop both
+	// scans, so ranges of equal elements are split evenly
+	pivot := s[last]
+	i, j := 0, last - 1
+	for {
+		for c.lt(s[i], pivot) {
+			i++
+		}
+		for j > i && c.lt(pivot, s[j]) {
+			j--
+		}
+
+		if i >= j {
+			break
+		}
+
+		c.swap(s, i, j)
+		i, j = i + 1, j - 1
+	}
+
+	// Place the pivot
+	c.swap(s, i, last)
+
+	return i
+}
@@ -0,0 +1,61 @@
+package sorting
+
+// radixBits is the number of key bits sorted by one pass of RadixSort
+const radixBits = 8
+
+// RadixSort sorts s by the LSD radix sort in ascending order of unsigned 64-bit keys returned
+// by key, one byte of keys per pass. Passes on bytes that are equal in all keys are skipped.
+// It does not compare elements, Moves count writes of elements both to the buffer and back to s.
+// Use IntKey to sort by signed integers.
+func RadixSort[T any](s []T, key func(v T) uint64) Stats {
+	stats := Stats{}
+	if len(s) < 2 {
+		return stats
+	}
+
+	keys := make([]uint64, len(s))
+	// Bits that differ at least in two keys
+	var diff uint64
+	for i, v := range s {
+		keys[i] = key(v)
+		diff |= keys[i] ^ keys[0]
+	}
+
+	buf := make([]T, len(s))
+	bufKeys := make([]uint64, len(s))
+	const buckets = 1 << radixBits
+	for shift := 0; shift < 64; shift += radixBits {
+		if (diff >> shift) & (buckets - 1) == 0 {
+			// All keys have the same digit
+			continue
+		}
+
+		// Count elements with each digit, then convert counts to start positions
+		var pos [buckets]int
+		for _, k := range keys {
+			pos[(k >> shift) & (buckets - 1)]++
+		}
+		for d, sum := 0, 0; d < buckets; d++ {
+			pos[d], sum = sum, sum + pos[d]
+		}
+
+		// Distribute elements keeping their order within each digit
+		for i, k := range keys {
+			d := (k >> shift) & (buckets - 1)
+			buf[pos[d]], bufKeys[pos[d]] = s[i], k
+			pos[d]++
+		}
+		copy(s, buf)
+		copy(keys, bufKeys)
+		stats.Moves += 2 * len(s)
+	}
+
+	return stats
+}
+
+// IntKey converts the signed integer v to the key for RadixSort, order of keys is the same as
+// order of integers.
+func IntKey(v int64) uint64 {
+	// Flip the sign bit, so negative values precede positive ones
+	return uint64(v) ^ (1 << 63)
+}
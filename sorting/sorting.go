@@ -0,0 +1,73 @@
+/*
+Package sorting provides examples of implementations of classic sorting algorithms.
+
+All algorithms sort generic slices in ascending order defined by the comparison
+function less, except the radix sort that uses integer keys of elements. Each
+algorithm returns statistics of the performed operations - number of comparisons,
+swaps and moves of elements, that can be used to reproduce experiments with the
+complexity of algorithms.
+
+The package contains the following algorithms:
+  - InsertionSort - O(n²), stable, fast on small and nearly sorted slices
+  - MergeSort - O(n log n), stable, uses O(n) additional memory
+  - HeapSort - O(n log n) in the worst case, not stable, in-place
+  - QuickSort - O(n log n) on average, not stable, the pivot is the median of three elements
+  - IntroSort - quick sort that switches to the heap sort when recursion is too deep and
+    to the insertion sort on small ranges, O(n log n) in the worst case, not stable
+  - RadixSort - LSD radix sort by 64-bit keys, O(n), stable
+  - TreeSort - in-order traversal of the red-black tree from the [rbtree] package, O(n log n), stable
+
+[rbtree]: https://pkg.go.dev/github.com/r-che/algorithms/bst/rbtree
+*/
+package sorting
+
+import "fmt"
+
+// Stats contains statistics of operations performed by a sorting algorithm
+type Stats struct {
+	// Comparisons of two elements
+	Comparisons	int
+	// Exchanges of two elements of the slice
+	Swaps		int
+	// Writes of single elements to the slice or to the buffer
+	Moves		int
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("comparisons: %d, swaps: %d, moves: %d", s.Comparisons, s.Swaps, s.Moves)
+}
+
+// counter counts operations performed on elements
+type counter[T any] struct {
+	less	func(a, b T) bool
+	stats	Stats
+}
+
+// lt returns less(a, b) counting the comparison
+func (c *counter[T]) lt(a, b T) bool {
+	c.stats.Comparisons++
+	return c.less(a, b)
+}
+
+// swap exchanges elements i and j of s counting the swap
+func (c *counter[T]) swap(s []T, i, j int) {
+	c.stats.Swaps++
+	s[i], s[j] = s[j], s[i]
+}
+
+// InsertionSort sorts s by the insertion sort. Each element is moved to the left by swaps
+// with greater neighbors, so the number of swaps is equal to the number of inversions.
+func InsertionSort[T any](s []T, less func(a, b T) bool) Stats {
+	c := counter[T]{less: less}
+	c.insertion(s)
+
+	return c.stats
+}
+
+func (c *counter[T]) insertion(s []T) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && c.lt(s[j], s[j-1]); j-- {
+			c.swap(s, j, j-1)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package sorting
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const (
+	valuesCount	=	10240
+	MaxItem		=	99999
+	// Seed of random sources of tests
+	testSeed	=	2040
+)
+
+// newTestValues returns n random values with duplicates and negative numbers generated by rnd
+func newTestValues(rnd *rand.Rand, n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = rnd.Intn(2 * MaxItem + 1) - MaxItem
+	}
+
+	return values
+}
+
+// item is used to check stability of sorting, order of items is defined only by keys
+type item struct {
+	key	int
+	seq	int
+}
+
+func lessInt(a, b int) bool { return a < b }
+func lessItem(a, b item) bool { return a.key < b.key }
+
+// sortFunc describes the sorting function of the package adapted to the type of elements
+type sortFunc[T any] func(s []T) Stats
+
+// comparisonSorts returns all sorts by comparison adapted to less
+func comparisonSorts[T any](less func(a, b T) bool) map[string]sortFunc[T] {
+	return map[string]sortFunc[T]{
+		"Insertion":	func(s []T) Stats { return InsertionSort(s, less) },
+		"Merge":		func(s []T) Stats { return MergeSort(s, less) },
+		"Heap":			func(s []T) Stats { return HeapSort(s, less) },
+		"Quick":		func(s []T) Stats { return QuickSort(s, less) },
+		"Intro":		func(s []T) Stats { return IntroSort(s, less) },
+		"Tree":			func(s []T) Stats { return TreeSort(s, less) },
+	}
+}
+
+// allIntSorts returns all sorts of integers
+func allIntSorts() map[string]sortFunc[int] {
+	sorts := comparisonSorts(lessInt)
+	sorts["Radix"] = func(s []int) Stats { return RadixSort(s, func(v int) uint64 { return IntKey(int64(v)) }) }
+
+	return sorts
+}
+
+// testInputs returns inputs of different kinds with n elements, random values are generated by rnd
+func testInputs(rnd *rand.Rand, n int) map[string][]int {
+	random := newTestValues(rnd, n)
+	sorted := append([]int{}, random...)
+	sort.Ints(sorted)
+
+	reversed := make([]int, n)
+	for i, v := range sorted {
+		reversed[n-1-i] = v
+	}
+
+	equal := make([]int, n)
+	for i := range equal {
+		equal[i] = 7
+	}
+
+	return map[string][]int{
+		"random":	random,
+		"sorted":	sorted,
+		"reversed":	reversed,
+		"equal":	equal,
+	}
+}
+
+func TestSortInts(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	for _, n := range []int{0, 1, 2, 3, 5, 17, 100, 1000, valuesCount} {
+		for inName, input := range testInputs(rnd, n) {
+			want := append([]int{}, input...)
+			sort.Ints(want)
+
+			for name, sortFn := range allIntSorts() {
+				if name == "Insertion" && n > 1000 {
+					// Too slow
+					continue
+				}
+
+				s := append([]int{}, input...)
+				sortFn(s)
+				if !reflect.DeepEqual(s, want) {
+					t.Fatalf("[%s:%s:%d] slice is not sorted: %v", name, inName, n, s)
+				}
+			}
+		}
+	}
+}
+
+func TestStability(t *testing.T) {
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+
+	// Many duplicates of keys
+	items := make([]item, valuesCount)
+	for i, v := range newTestValues(rnd, valuesCount) {
+		items[i] = item{key: v % 100, seq: i}
+	}
+
+	want := append([]item{}, items...)
+	sort.SliceStable(want, func(i, j int) bool { return want[i].key < want[j].key })
+
+	stable := map[string]sortFunc[item]{
+		"Merge":	func(s []item) Stats { return MergeSort(s, lessItem) },
+		"Radix":	func(s []item) Stats { return RadixSort(s, func(v item) uint64 { return IntKey(int64(v.key)) }) },
+		"Tree":		func(s []item) Stats { return TreeSort(s, lessItem) },
+		"Insertion":	func(s []item) Stats { return InsertionSort(s, lessItem) },
+	}
+
+	for name, sortFn := range stable {
+		s := append([]item{}, items...)
+		sortFn(s)
+		if !reflect.DeepEqual(s, want) {
+			t.Errorf("[%s] sort is not stable", name)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	const n = 1000
+	rnd := rand.New(rand.NewSource(testSeed))	//nolint:gosec // Reproducible sequence is required
+	inputs := testInputs(rnd, n)
+
+	// Number of swaps of the insertion sort is equal to the number of inversions
+	inversions := 0
+	for i := range inputs["random"] {
+		for j := i + 1; j < n; j++ {
+			if inputs["random"][j] < inputs["random"][i] {
+				inversions++
+			}
+		}
+	}
+	if st := InsertionSort(append([]int{}, inputs["random"]...), lessInt); st.Swaps != inversions {
+		t.Errorf("InsertionSort made %d swaps, want - %d", st.Swaps, inversions)
+	}
+
+	// Sorted input is the best case for insertion and merge sorts
+	if st := InsertionSort(append([]int{}, inputs["sorted"]...), lessInt); st.Comparisons != n - 1 || st.Swaps != 0 {
+		t.Errorf("InsertionSort on sorted input: %v, want - %d comparisons, 0 swaps", st, n - 1)
+	}
+	if st := MergeSort(append([]int{}, inputs["sorted"]...), lessInt); st.Comparisons != n - 1 || st.Moves != 0 {
+		t.Errorf("MergeSort on sorted input: %v, want - %d comparisons, 0 moves", st, n - 1)
+	}
+
+	// Tree sort compares each element except the first one at least once and moves each element twice
+	if st := TreeSort(append([]int{}, inputs["sorted"]...), lessInt); st.Comparisons < n - 1 || st.Moves != 2 * n {
+		t.Errorf("TreeSort on sorted input: %v, want - at least %d comparisons, %d moves", st, n - 1, 2 * n)
+	}
+
+	// Radix sort does not compare elements
+	if st := RadixSort(append([]int{}, inputs["random"]...), func(v int) uint64 { return IntKey(int64(v)) });
+		st.Comparisons != 0 || st.Moves == 0 {
+		t.Errorf("RadixSort: %v, want - 0 comparisons and non-zero moves", st)
+	}
+
+	// Sorts with O(n log n) complexity must not exceed c * n * log2(n) comparisons on any input
+	const log2n = 10
+	for inName, input := range inputs {
+		for name, sortFn := range allIntSorts() {
+			if name == "Insertion" || name == "Radix" {
+				continue
+			}
+
+			if st := sortFn(append([]int{}, input...)); st.Comparisons > 3 * n * log2n {
+				t.Errorf("[%s:%s] too many comparisons: %v", name, inName, st)
+			}
+		}
+	}
+}
+
+func TestIntroSortWorstCase(t *testing.T) {
+	// Organ-pipe input with many equal elements makes bad pivots for median-of-three
+	s := make([]int, valuesCount)
+	for i := range s {
+		s[i] = i % 64
+		if i % 2 == 1 {
+			s[i] = -s[i]
+		}
+	}
+
+	st := IntroSort(s, lessInt)
+	if !sort.IntsAreSorted(s) {
+		t.Fatalf("IntroSort returned unsorted slice")
+	}
+
+	// n * log2(n) ~ 136K
+	if st.Comparisons > 4 * 140_000 {
+		t.Errorf("IntroSort made too many comparisons: %v", st)
+	}
+}
+
+func TestIntKey(t *testing.T) {
+	values := []int64{-1 << 63, -100, -1, 0, 1, 100, 1 << 63 - 1}
+	for i := 1; i < len(values); i++ {
+		if IntKey(values[i-1]) >= IntKey(values[i]) {
+			t.Errorf("IntKey(%d) = %d is not less than IntKey(%d) = %d",
+				values[i-1], IntKey(values[i-1]), values[i], IntKey(values[i]))
+		}
+	}
+}
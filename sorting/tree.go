@@ -0,0 +1,32 @@
+package sorting
+
+import (
+	"github.com/r-che/algorithms/bst/rbtree"
+)
+
+// TreeSort sorts s by inserting elements into the red-black tree ordered by less and by
+// in-order traversal of the tree. An element equal to elements of the tree is placed after
+// them, so the sort is stable. Each element is inserted by one pass from the root, every
+// call of less on this path is counted as a comparison. Moves count writes of elements both
+// to the tree and back to s.
+func TreeSort[T any](s []T, less func(a, b T) bool) Stats {
+	c := counter[T]{less: less}
+	tree := rbtree.NewRBTree()
+
+	for _, v := range s {
+		v := v
+		tree.InsertFunc(rbtree.NewRBNode(rbtree.FakeNode, v), func(n *rbtree.RBNode) bool {
+			return c.lt(v, n.Value().(T))
+		})
+		c.stats.Moves++
+	}
+
+	i := 0
+	for n := tree.Min(); n != nil; n = tree.Successor(n) {
+		s[i] = n.Value().(T)
+		i++
+	}
+	c.stats.Moves += len(s)
+
+	return c.stats
+}